@@ -29,6 +29,12 @@ func main() {
 
 	log.Info("Simple diet tracker app initialized")
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go application.TrashApp.Run(jobsCtx)
+	go application.IdempotencyApp.Run(jobsCtx)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -45,6 +51,8 @@ func main() {
 
 	<-quit
 
+	stopJobs()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
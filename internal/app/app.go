@@ -5,17 +5,41 @@ import (
 	"log/slog"
 	"os"
 
+	idempotencyapp "github.com/karmaplush/simple-diet-tracker/internal/app/idempotency"
 	trackerapp "github.com/karmaplush/simple-diet-tracker/internal/app/tracker"
+	trashapp "github.com/karmaplush/simple-diet-tracker/internal/app/trash"
 	grpcauthclient "github.com/karmaplush/simple-diet-tracker/internal/clients/auth/grpc"
 	"github.com/karmaplush/simple-diet-tracker/internal/config"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/achievement"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/analytics"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/auth"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/export"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/idempotency"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/nutrition"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/recipe"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/report"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/stats"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/streak"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/weight"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage/filesystem"
 	"github.com/karmaplush/simple-diet-tracker/internal/storage/sqlite"
 )
 
 type App struct {
-	TrackerApp *trackerapp.App
+	TrackerApp     *trackerapp.App
+	TrashApp       *trashapp.App
+	IdempotencyApp *idempotencyapp.App
 }
 
 func New(
@@ -35,6 +59,17 @@ func New(
 
 	log.Info("storage initialized")
 
+	blobStorage, err := filesystem.New(cfg.BlobPath)
+	if err != nil {
+		log.Error(
+			"error was occured due blob storage initalization",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
+	}
+
+	log.Info("blob storage initialized")
+
 	grpcAuthClient, err := grpcauthclient.New(context.Background(), log, cfg)
 	if err != nil {
 		log.Error("failed to init grpc auth client", slog.String("error", err.Error()))
@@ -45,7 +80,95 @@ func New(
 
 	authService := auth.New(log, grpcAuthClient, grpcAuthClient, sqliteStorage, sqliteStorage)
 	accountService := account.New(log, sqliteStorage, sqliteStorage)
-	recordService := record.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	achievementService := achievement.New(
+		log,
+		achievement.DefaultRules,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+	)
+	streakService := streak.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	photoService := photo.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		blobStorage,
+		accountService,
+	)
+	recordService := record.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+		streakService,
+		achievementService,
+		photoService,
+		sqliteStorage,
+	)
+	weightService := weight.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+		achievementService,
+	)
+	analyticsService := analytics.New(log, sqliteStorage, accountService)
+	statsService := stats.New(log, sqliteStorage, sqliteStorage, accountService)
+	reportService := report.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	fastingService := fasting.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	foodService := food.New(log, sqliteStorage, sqliteStorage, accountService)
+	nutritionService := nutrition.New(log, sqliteStorage, accountService)
+	restrictionService := restriction.New(log, sqliteStorage, sqliteStorage, accountService)
+	recipeService := recipe.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	insulinService := insulin.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+	)
+	mealPlanService := mealplan.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		recordService,
+		accountService,
+	)
+	shoppingService := shopping.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+	)
+
+	tagService := tag.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	measurementService := measurement.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+	)
+	exportService := export.New(log, sqliteStorage, sqliteStorage, sqliteStorage, accountService)
+	idempotencyService := idempotency.New(
+		log,
+		sqliteStorage,
+		sqliteStorage,
+		sqliteStorage,
+		accountService,
+		cfg.Idempotency.TTL,
+	)
 
 	trackerApp := trackerapp.New(
 		log,
@@ -53,10 +176,35 @@ func New(
 		authService,
 		accountService,
 		recordService,
+		streakService,
+		achievementService,
+		weightService,
+		analyticsService,
+		statsService,
+		reportService,
+		fastingService,
+		foodService,
+		nutritionService,
+		restrictionService,
+		recipeService,
+		insulinService,
+		mealPlanService,
+		shoppingService,
+		photoService,
+		tagService,
+		measurementService,
+		exportService,
+		idempotencyService,
 	)
 
+	trashApp := trashapp.New(log, recordService, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+
+	idempotencyApp := idempotencyapp.New(log, idempotencyService, cfg.Idempotency.PurgeInterval)
+
 	return &App{
-		TrackerApp: trackerApp,
+		TrackerApp:     trackerApp,
+		TrashApp:       trashApp,
+		IdempotencyApp: idempotencyApp,
 	}
 
 }
@@ -0,0 +1,50 @@
+package idempotencyapp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// App periodically purges expired idempotency keys.
+type App struct {
+	log      *slog.Logger
+	purger   Purger
+	interval time.Duration
+}
+
+func New(log *slog.Logger, purger Purger, interval time.Duration) *App {
+	return &App{
+		log:      log,
+		purger:   purger,
+		interval: interval,
+	}
+}
+
+// Run purges expired keys right away and then every interval until ctx is done.
+func (a *App) Run(ctx context.Context) {
+	const op = "app.idempotency.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		// Failures are logged by the purger, the next tick retries
+		purged, err := a.purger.PurgeExpired(ctx)
+		if err == nil && purged > 0 {
+			log.Info("idempotency keys purged", slog.Int("keys", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
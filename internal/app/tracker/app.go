@@ -8,16 +8,93 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/jwtauth"
 	"github.com/karmaplush/simple-diet-tracker/internal/config"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/achievements"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/energyunit"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/export"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/login"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/macrotargets"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/me"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/profile"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/registration"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/streaks"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/analytics/mealtiming"
+	intakechart "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/intake"
+	weightchart "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/weight"
+	fastinghistory "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/history"
+	fastingstart "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/start"
+	fastingstats "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stats"
+	fastingstop "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stop"
+	fastingwindows "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/windows"
+	foodcreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/create"
+	foodlist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/list"
+	insulinbolus "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/bolus"
+	insulincarbs "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/carbs"
+	insulinhistory "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/history"
+	insulinsettingsget "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/get"
+	insulinsettingsupdate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/update"
+	mealplancreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/create"
+	mealplanlist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/list"
+	mealplanlog "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/log"
+	measurementcreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/create"
+	measurementdelete "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/delete"
+	measurementlist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/list"
+	measurementsummary "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/summary"
+	measurementtrend "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/trend"
+	photoget "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/get"
+	photolist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/list"
+	photoupload "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/upload"
+	recipecreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/create"
+	recipelist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/batch"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/create"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/delete"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/history"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/imports"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/quick"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/recordsync"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/restore"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/search"
+	recordtags "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/tags"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/trash"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/update"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/micronutrients"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/monthly"
+	restrictionsget "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/get"
+	restrictionsupdate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/update"
+	shoppingcheck "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/check"
+	shoppinglist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/list"
+	intakestats "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/stats/intake"
+	tagcreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/create"
+	tagdelete "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/delete"
+	taglist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/list"
+	tagupdate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/update"
+	weightcreate "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/weights/create"
+	weightlist "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/weights/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/middlewares/idempotency"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/middlewares/logger"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/achievement"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/analytics"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/auth"
+	exportservice "github.com/karmaplush/simple-diet-tracker/internal/services/export"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	idempotencyservice "github.com/karmaplush/simple-diet-tracker/internal/services/idempotency"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/nutrition"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/recipe"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/report"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/stats"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/streak"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/weight"
 )
 
 type App struct {
@@ -31,6 +108,25 @@ func New(
 	authService *auth.Auth,
 	accountService *account.Account,
 	recordService *record.Record,
+	streakService *streak.Streak,
+	achievementService *achievement.Achievement,
+	weightService *weight.Weight,
+	analyticsService *analytics.Analytics,
+	statsService *stats.Stats,
+	reportService *report.Report,
+	fastingService *fasting.Fasting,
+	foodService *food.Food,
+	nutritionService *nutrition.Nutrition,
+	restrictionService *restriction.Restriction,
+	recipeService *recipe.Recipe,
+	insulinService *insulin.Insulin,
+	mealPlanService *mealplan.MealPlan,
+	shoppingService *shopping.Shopping,
+	photoService *photo.Photo,
+	tagService *tag.Tag,
+	measurementService *measurement.Measurement,
+	exportService *exportservice.Export,
+	idempotencyService *idempotencyservice.Idempotency,
 ) *App {
 
 	tokenAuth := jwtauth.New("HS256", []byte(cfg.AppSecret), nil)
@@ -51,11 +147,85 @@ func New(
 		router.Use(jwtauth.Verifier(tokenAuth))
 		router.Use(jwtauth.Authenticator)
 
+		// Mutating JSON routes replay the stored response to retries with the same Idempotency-Key
+		idempotent := router.With(idempotency.New(log, idempotencyService))
+
 		router.Get("/accounts/me", me.New(log, accountService))
+		router.Get("/accounts/me/streaks", streaks.New(log, streakService))
+		router.Get("/accounts/me/achievements", achievements.New(log, achievementService))
+		idempotent.Put("/accounts/me/profile", profile.New(log, accountService))
+		idempotent.Put("/accounts/me/energy-unit", energyunit.New(log, accountService))
+		idempotent.Put("/accounts/me/macro-targets", macrotargets.New(log, accountService))
+		router.Get("/accounts/me/restrictions", restrictionsget.New(log, restrictionService))
+		idempotent.Put("/accounts/me/restrictions", restrictionsupdate.New(log, restrictionService))
+		router.Get("/accounts/me/insulin-settings", insulinsettingsget.New(log, insulinService))
+		idempotent.Put("/accounts/me/insulin-settings", insulinsettingsupdate.New(log, insulinService))
 
 		router.Get("/records", list.New(log, recordService))
-		router.Post("/records", create.New(log, recordService))
-		router.Delete("/records/{recordId}", delete.New(log, recordService))
+		idempotent.Post("/records", create.New(log, recordService))
+		idempotent.Post("/records/quick", quick.New(log, recordService))
+		idempotent.Post("/records/batch", batch.New(log, recordService))
+		idempotent.Post("/records/import", imports.New(log, recordService))
+		router.Get("/records/search", search.New(log, recordService))
+		idempotent.Put("/records/{recordId}", update.New(log, recordService))
+		idempotent.Delete("/records/{recordId}", delete.New(log, recordService))
+		router.Get("/records/{recordId}/history", history.New(log, recordService))
+		router.Get("/records/trash", trash.New(log, recordService))
+		idempotent.Post("/records/{recordId}/restore", restore.New(log, recordService))
+		router.Get("/records/{recordId}/photos", photolist.New(log, photoService))
+		idempotent.Post("/records/{recordId}/photos", photoupload.New(log, photoService))
+		router.Get("/records/{recordId}/photos/{photoId}", photoget.New(log, photoService))
+		idempotent.Put("/records/{recordId}/tags", recordtags.New(log, recordService))
+		idempotent.Post("/sync", recordsync.New(log, recordService))
+
+		router.Get("/tags", taglist.New(log, tagService))
+		idempotent.Post("/tags", tagcreate.New(log, tagService))
+		idempotent.Put("/tags/{tagId}", tagupdate.New(log, tagService))
+		idempotent.Delete("/tags/{tagId}", tagdelete.New(log, tagService))
+
+		router.Get("/foods", foodlist.New(log, foodService))
+		idempotent.Post("/foods", foodcreate.New(log, foodService))
+
+		router.Get("/recipes", recipelist.New(log, recipeService))
+		idempotent.Post("/recipes", recipecreate.New(log, recipeService))
+
+		router.Get("/meal-plans", mealplanlist.New(log, mealPlanService))
+		idempotent.Post("/meal-plans", mealplancreate.New(log, mealPlanService))
+		idempotent.Post("/meal-plans/{planId}/meals/{mealId}/log", mealplanlog.New(log, mealPlanService))
+
+		router.Get("/shopping-list", shoppinglist.New(log, shoppingService))
+		idempotent.Put("/shopping-list/items/{foodId}", shoppingcheck.New(log, shoppingService))
+
+		router.Get("/weights", weightlist.New(log, weightService))
+		idempotent.Post("/weights", weightcreate.New(log, weightService))
+
+		router.Get("/measurements", measurementlist.New(log, measurementService))
+		idempotent.Post("/measurements", measurementcreate.New(log, measurementService))
+		router.Get("/measurements/summary", measurementsummary.New(log, measurementService))
+		router.Get("/measurements/trend", measurementtrend.New(log, measurementService))
+		idempotent.Delete("/measurements/{measurementId}", measurementdelete.New(log, measurementService))
+
+		router.Get("/export", export.New(log, exportService))
+
+		router.Get("/analytics/meal-timing", mealtiming.New(log, analyticsService))
+		router.Get("/stats", intakestats.New(log, statsService))
+
+		// URLFormat middleware trims extensions, so /charts/intake.svg is routed here
+		router.Get("/charts/intake", intakechart.New(log, statsService))
+		router.Get("/charts/weight", weightchart.New(log, weightService))
+		router.Get("/reports/monthly", monthly.New(log, reportService))
+		router.Get("/reports/micronutrients", micronutrients.New(log, nutritionService))
+
+		idempotent.Post("/fasting/start", fastingstart.New(log, fastingService))
+		idempotent.Post("/fasting/stop", fastingstop.New(log, fastingService))
+		router.Get("/fasting/sessions", fastinghistory.New(log, fastingService))
+		router.Get("/fasting/stats", fastingstats.New(log, fastingService))
+		router.Get("/fasting/windows", fastingwindows.New(log, fastingService))
+
+		// Dose suggestions are informational, every calculation is kept as an audit trail
+		router.Get("/insulin/carbs", insulincarbs.New(log, insulinService))
+		idempotent.Post("/insulin/bolus", insulinbolus.New(log, insulinService))
+		router.Get("/insulin/bolus", insulinhistory.New(log, insulinService))
 	})
 
 	return &App{
@@ -0,0 +1,57 @@
+package trashapp
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+}
+
+// App periodically purges records deleted longer than retention ago.
+type App struct {
+	log       *slog.Logger
+	purger    Purger
+	retention time.Duration
+	interval  time.Duration
+}
+
+func New(
+	log *slog.Logger,
+	purger Purger,
+	retention time.Duration,
+	interval time.Duration,
+) *App {
+	return &App{
+		log:       log,
+		purger:    purger,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges the trash right away and then every interval until ctx is done.
+func (a *App) Run(ctx context.Context) {
+	const op = "app.trash.Run"
+
+	log := a.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		// Failures are logged by the purger, the next tick retries
+		purged, err := a.purger.PurgeTrash(ctx, time.Now().Add(-a.retention))
+		if err == nil && purged > 0 {
+			log.Info("trash purged", slog.Int("records", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
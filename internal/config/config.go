@@ -11,7 +11,10 @@ import (
 type Config struct {
 	Env         string        `yaml:"env"          env-default:"local"`
 	StoragePath string        `yaml:"storage_path"                     env-required:"true"`
+	BlobPath    string        `yaml:"blob_path"                        env-default:"./storage/blobs"`
 	HttpServer  HttpServer    `yaml:"http_server"`
+	Trash       Trash         `yaml:"trash"`
+	Idempotency Idempotency   `yaml:"idempotency"`
 	Clients     ClientsConfig `yaml:"clients"`
 	AppSecret   string        `yaml:"app_secret"                       env-required:"true" env:"APP_SECRET"`
 	AppId       int32         `yaml:"app_id"                           env-required:"true" env:"APP_ID"`
@@ -23,6 +26,20 @@ type HttpServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+// Trash configures purging of deleted records, records stay restorable
+// for Retention after deletion.
+type Trash struct {
+	Retention     time.Duration `yaml:"retention"      env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
+// Idempotency configures stored responses of requests with the
+// Idempotency-Key header, keys can be reused after TTL.
+type Idempotency struct {
+	TTL           time.Duration `yaml:"ttl"            env-default:"24h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+}
+
 type Client struct {
 	Address      string        `yaml:"address"`
 	Timeout      time.Duration `yaml:"timeout"`
@@ -1,7 +1,57 @@
 package models
 
+import "time"
+
+type Sex string
+
+const (
+	SexFemale Sex = "female"
+	SexMale   Sex = "male"
+)
+
 type Account struct {
-	Id         int64 `json:"id"`
-	UserId     int64 `json:"userId"`
-	DailyLimit int   `json:"dailyLimit"`
+	Id           int64        `json:"id"`
+	UserId       int64        `json:"userId"`
+	DailyLimit   int          `json:"dailyLimit"`
+	Sex          Sex          `json:"sex,omitempty"`
+	BirthDate    *time.Time   `json:"birthDate,omitempty"`
+	EnergyUnit   EnergyUnit   `json:"energyUnit"`
+	MacroTargets MacroTargets `json:"macroTargets"`
+	Version      int64        `json:"version"`
+}
+
+// InEnergyUnit returns the account with the daily limit converted from kcal
+// to the account energy unit.
+func (a Account) InEnergyUnit() Account {
+	a.DailyLimit = a.EnergyUnit.FromKcal(a.DailyLimit)
+	return a
+}
+
+type Profile struct {
+	Sex       Sex       `json:"sex"`
+	BirthDate time.Time `json:"birthDate"`
+}
+
+// MacroTargets are daily amounts in grams, zero means no target.
+type MacroTargets struct {
+	Carbs   float64 `json:"carbs"`
+	Protein float64 `json:"protein"`
+	Fat     float64 `json:"fat"`
+}
+
+// Nutrients returns set targets keyed by macronutrient.
+func (t MacroTargets) Nutrients() Nutrients {
+	targets := Nutrients{}
+
+	for nutrient, amount := range map[Nutrient]float64{
+		NutrientCarbs:   t.Carbs,
+		NutrientProtein: t.Protein,
+		NutrientFat:     t.Fat,
+	} {
+		if amount > 0 {
+			targets[nutrient] = amount
+		}
+	}
+
+	return targets
 }
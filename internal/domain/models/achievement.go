@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type AchievementEventType string
+
+const (
+	EventRecordCreated   AchievementEventType = "record_created"
+	EventLoggingStreak   AchievementEventType = "logging_streak"
+	EventOnTargetStreak  AchievementEventType = "on_target_streak"
+	EventWeightMilestone AchievementEventType = "weight_milestone"
+)
+
+// AchievementEvent carries the current value of the tracked metric
+// (total records, streak length, kilograms lost).
+type AchievementEvent struct {
+	Type  AchievementEventType
+	Value int
+}
+
+type Achievement struct {
+	Code        string     `json:"code"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlockedAt,omitempty"`
+}
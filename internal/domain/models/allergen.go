@@ -0,0 +1,93 @@
+package models
+
+// Allergen is an allergen (EU list of 14) or a dietary marker a food contains.
+type Allergen string
+
+const (
+	AllergenGluten      Allergen = "gluten"
+	AllergenCrustaceans Allergen = "crustaceans"
+	AllergenEggs        Allergen = "eggs"
+	AllergenFish        Allergen = "fish"
+	AllergenPeanuts     Allergen = "peanuts"
+	AllergenSoy         Allergen = "soy"
+	AllergenMilk        Allergen = "milk"
+	AllergenTreeNuts    Allergen = "treeNuts"
+	AllergenCelery      Allergen = "celery"
+	AllergenMustard     Allergen = "mustard"
+	AllergenSesame      Allergen = "sesame"
+	AllergenSulphites   Allergen = "sulphites"
+	AllergenLupin       Allergen = "lupin"
+	AllergenMolluscs    Allergen = "molluscs"
+
+	// Dietary markers, not allergens
+	AllergenMeat  Allergen = "meat"
+	AllergenHoney Allergen = "honey"
+)
+
+var Allergens = []Allergen{
+	AllergenGluten,
+	AllergenCrustaceans,
+	AllergenEggs,
+	AllergenFish,
+	AllergenPeanuts,
+	AllergenSoy,
+	AllergenMilk,
+	AllergenTreeNuts,
+	AllergenCelery,
+	AllergenMustard,
+	AllergenSesame,
+	AllergenSulphites,
+	AllergenLupin,
+	AllergenMolluscs,
+	AllergenMeat,
+	AllergenHoney,
+}
+
+type Diet string
+
+const (
+	DietVegan       Diet = "vegan"
+	DietVegetarian  Diet = "vegetarian"
+	DietPescatarian Diet = "pescatarian"
+	DietGlutenFree  Diet = "glutenFree"
+	DietDairyFree   Diet = "dairyFree"
+)
+
+// DietAllergens lists what every diet excludes.
+var DietAllergens = map[Diet][]Allergen{
+	DietVegan: {
+		AllergenMeat,
+		AllergenFish,
+		AllergenCrustaceans,
+		AllergenMolluscs,
+		AllergenEggs,
+		AllergenMilk,
+		AllergenHoney,
+	},
+	DietVegetarian:  {AllergenMeat, AllergenFish, AllergenCrustaceans, AllergenMolluscs},
+	DietPescatarian: {AllergenMeat},
+	DietGlutenFree:  {AllergenGluten},
+	DietDairyFree:   {AllergenMilk},
+}
+
+type RestrictionMode string
+
+const (
+	// Conflicting records are saved with warnings
+	RestrictionModeWarn RestrictionMode = "warn"
+	// Conflicting records are rejected
+	RestrictionModeReject RestrictionMode = "reject"
+)
+
+type Restrictions struct {
+	Allergens []Allergen      `json:"allergens"`
+	Diets     []Diet          `json:"diets"`
+	Mode      RestrictionMode `json:"mode"`
+}
+
+// RestrictionWarning is an allergen conflicting with an account allergy
+// (Restriction is "allergy") or a diet (Restriction is the diet).
+type RestrictionWarning struct {
+	Allergen    Allergen `json:"allergen"`
+	Restriction string   `json:"restriction"`
+}
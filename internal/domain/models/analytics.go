@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+type HourBucket struct {
+	Hour  int     `json:"hour"`
+	Value int     `json:"value"`
+	Share float64 `json:"share"`
+}
+
+type MealBucket struct {
+	Meal  Meal    `json:"meal"`
+	Value int     `json:"value"`
+	Share float64 `json:"share"`
+}
+
+type EatingWindow struct {
+	Day     time.Time `json:"day"`
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+	Minutes int       `json:"minutes"`
+}
+
+type MealTiming struct {
+	Total                      int            `json:"total"`
+	ByHour                     []HourBucket   `json:"byHour"`
+	ByMeal                     []MealBucket   `json:"byMeal"`
+	EatingWindows              []EatingWindow `json:"eatingWindows"`
+	AverageEatingWindowMinutes int            `json:"averageEatingWindowMinutes"`
+	LateNightValue             int            `json:"lateNightValue"`
+	LateNightShare             float64        `json:"lateNightShare"`
+	EnergyUnit                 EnergyUnit     `json:"energyUnit"`
+}
+
+// In returns timing with energy values converted from kcal to unit,
+// shares are unit independent.
+func (t MealTiming) In(unit EnergyUnit) MealTiming {
+	t.EnergyUnit = unit.OrDefault()
+	t.Total = unit.FromKcal(t.Total)
+	t.LateNightValue = unit.FromKcal(t.LateNightValue)
+
+	byHour := make([]HourBucket, len(t.ByHour))
+	for i, bucket := range t.ByHour {
+		bucket.Value = unit.FromKcal(bucket.Value)
+		byHour[i] = bucket
+	}
+	t.ByHour = byHour
+
+	byMeal := make([]MealBucket, len(t.ByMeal))
+	for i, bucket := range t.ByMeal {
+		bucket.Value = unit.FromKcal(bucket.Value)
+		byMeal[i] = bucket
+	}
+	t.ByMeal = byMeal
+
+	return t
+}
@@ -0,0 +1,41 @@
+package models
+
+import "math"
+
+// EnergyUnit is the unit energy values are shown in, storage is always kcal.
+type EnergyUnit string
+
+const (
+	EnergyUnitKcal EnergyUnit = "kcal"
+	EnergyUnitKj   EnergyUnit = "kJ"
+)
+
+// KjPerKcal is the thermochemical calorie in kilojoules.
+const KjPerKcal = 4.184
+
+// FromKcal converts a kcal value to u, unknown units are treated as kcal.
+func (u EnergyUnit) FromKcal(kcal int) int {
+	if u != EnergyUnitKj {
+		return kcal
+	}
+
+	return int(math.Round(float64(kcal) * KjPerKcal))
+}
+
+// ToKcal converts a value in u to kcal, unknown units are treated as kcal.
+func (u EnergyUnit) ToKcal(value int) int {
+	if u != EnergyUnitKj {
+		return value
+	}
+
+	return int(math.Round(float64(value) / KjPerKcal))
+}
+
+// OrDefault returns kcal for an empty unit.
+func (u EnergyUnit) OrDefault() EnergyUnit {
+	if u == "" {
+		return EnergyUnitKcal
+	}
+
+	return u
+}
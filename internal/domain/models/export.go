@@ -0,0 +1,13 @@
+package models
+
+// ExportSection is a kind of exported account data.
+type ExportSection string
+
+const (
+	ExportFoods           ExportSection = "foods"
+	ExportRecipes         ExportSection = "recipes"
+	ExportWeights         ExportSection = "weights"
+	ExportMeasurements    ExportSection = "measurements"
+	ExportFastingSessions ExportSection = "fastingSessions"
+	ExportRecords         ExportSection = "records"
+)
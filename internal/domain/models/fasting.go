@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type FastingProtocol struct {
+	Name         string `json:"name"`
+	FastingHours int    `json:"fastingHours"`
+	EatingHours  int    `json:"eatingHours"`
+}
+
+type FastingSession struct {
+	Id              int64      `json:"id"`
+	AccountId       int64      `json:"accountId"`
+	Protocol        string     `json:"protocol"`
+	PlannedMinutes  int        `json:"plannedMinutes"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationMinutes int        `json:"durationMinutes"`
+	Completed       bool       `json:"completed"`
+}
+
+type FastingStats struct {
+	SessionsCount   int             `json:"sessionsCount"`
+	CompletedCount  int             `json:"completedCount"`
+	CompletionRate  float64         `json:"completionRate"`
+	AverageMinutes  int             `json:"averageMinutes"`
+	LongestMinutes  int             `json:"longestMinutes"`
+	ActiveSession   *FastingSession `json:"activeSession,omitempty"`
+	ProtocolsCounts map[string]int  `json:"protocolsCounts"`
+}
+
+// FastingWindow is the longest gap between consecutive records ending on Day.
+type FastingWindow struct {
+	Day     time.Time `json:"day"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Minutes int       `json:"minutes"`
+}
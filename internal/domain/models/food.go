@@ -0,0 +1,44 @@
+package models
+
+// Food is a catalog entry, Value and Nutrients are per 100 g.
+// Favorite foods are preferred by the meal planner.
+type Food struct {
+	Id        int64        `json:"id"`
+	AccountId int64        `json:"accountId"`
+	Name      string       `json:"name"`
+	Value     int          `json:"value"`
+	Nutrients Nutrients    `json:"nutrients,omitempty"`
+	Allergens []Allergen   `json:"allergens,omitempty"`
+	Favorite  bool         `json:"favorite"`
+	Category  FoodCategory `json:"category,omitempty"`
+}
+
+// FoodCategory groups foods on a shopping list.
+type FoodCategory string
+
+const (
+	FoodCategoryProduce   FoodCategory = "produce"
+	FoodCategoryMeat      FoodCategory = "meat"
+	FoodCategoryFish      FoodCategory = "fish"
+	FoodCategoryDairy     FoodCategory = "dairy"
+	FoodCategoryBakery    FoodCategory = "bakery"
+	FoodCategoryGrains    FoodCategory = "grains"
+	FoodCategoryPantry    FoodCategory = "pantry"
+	FoodCategoryFrozen    FoodCategory = "frozen"
+	FoodCategoryBeverages FoodCategory = "beverages"
+	FoodCategoryOther     FoodCategory = "other"
+)
+
+// FoodCategories are ordered as shopping list groups.
+var FoodCategories = []FoodCategory{
+	FoodCategoryProduce,
+	FoodCategoryMeat,
+	FoodCategoryFish,
+	FoodCategoryDairy,
+	FoodCategoryBakery,
+	FoodCategoryGrains,
+	FoodCategoryPantry,
+	FoodCategoryFrozen,
+	FoodCategoryBeverages,
+	FoodCategoryOther,
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKey is a client supplied key of a mutating request with the
+// response it got. Status is zero while the request is in progress,
+// Fingerprint identifies the request the key was first used with.
+type IdempotencyKey struct {
+	AccountId   int64
+	Key         string
+	Fingerprint string
+	Status      int
+	Header      map[string]string
+	Body        []byte
+	DateCreated time.Time
+}
@@ -0,0 +1,31 @@
+package models
+
+type ImportRowStatus string
+
+const (
+	// ImportRowNew is a row imported as a new record.
+	ImportRowNew ImportRowStatus = "new"
+	// ImportRowDuplicate is a row matching an existing record, it is skipped.
+	ImportRowDuplicate ImportRowStatus = "duplicate"
+	// ImportRowInvalid is a row failing validation, it is skipped.
+	ImportRowInvalid ImportRowStatus = "invalid"
+)
+
+// ImportRow is a line of an imported file with the record parsed from it,
+// Errors are set for invalid rows. Line counts from 1, the header included.
+type ImportRow struct {
+	Line   int             `json:"line"`
+	Status ImportRowStatus `json:"status"`
+	Record *Record         `json:"record,omitempty"`
+	Errors []string        `json:"errors,omitempty"`
+}
+
+// ImportResult is an applied import, or the import that would be applied
+// in dry-run mode, with every row of the file.
+type ImportResult struct {
+	DryRun     bool        `json:"dryRun"`
+	Imported   int         `json:"imported"`
+	Duplicates int         `json:"duplicates"`
+	Invalid    int         `json:"invalid"`
+	Rows       []ImportRow `json:"rows"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// InsulinDisclaimer is attached to every suggested dose.
+const InsulinDisclaimer = "Informational only, not medical advice. " +
+	"Check every dose against your own judgement and the plan agreed with your diabetes care team."
+
+type GlucoseUnit string
+
+const (
+	GlucoseUnitMgDl  GlucoseUnit = "mg/dL"
+	GlucoseUnitMmolL GlucoseUnit = "mmol/L"
+)
+
+// InsulinSettings of an account: CarbRatio is grams of carbs covered by one
+// unit, CorrectionFactor is the glucose drop per unit in GlucoseUnit.
+type InsulinSettings struct {
+	CarbRatio        float64     `json:"carbRatio"`
+	CorrectionFactor float64     `json:"correctionFactor"`
+	TargetGlucose    float64     `json:"targetGlucose"`
+	GlucoseUnit      GlucoseUnit `json:"glucoseUnit"`
+	DoseIncrement    float64     `json:"doseIncrement"`
+}
+
+// MealCarbs are carbs of records with carbs data, records without it are
+// counted in UncountedRecords.
+type MealCarbs struct {
+	Meal             Meal    `json:"meal"`
+	Carbs            float64 `json:"carbs"`
+	RecordsCount     int     `json:"recordsCount"`
+	UncountedRecords int     `json:"uncountedRecords"`
+}
+
+type CarbSummary struct {
+	Day              time.Time   `json:"day"`
+	Meals            []MealCarbs `json:"meals"`
+	Carbs            float64     `json:"carbs"`
+	UncountedRecords int         `json:"uncountedRecords"`
+}
+
+type CarbsSource string
+
+const (
+	CarbsSourceManual  CarbsSource = "manual"
+	CarbsSourceRecords CarbsSource = "records"
+)
+
+// BolusInput takes carbs either directly or from records of Meal on Day,
+// without both the dose is a correction only. Glucose is optional.
+type BolusInput struct {
+	Carbs          *float64
+	Meal           Meal
+	Day            time.Time
+	Glucose        *float64
+	InsulinOnBoard float64
+}
+
+// BolusCalculation is an audit entry of a suggested dose with all inputs
+// and the settings in effect.
+type BolusCalculation struct {
+	Id               int64           `json:"id"`
+	AccountId        int64           `json:"accountId"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	Carbs            float64         `json:"carbs"`
+	CarbsSource      CarbsSource     `json:"carbsSource"`
+	Meal             Meal            `json:"meal,omitempty"`
+	Day              *time.Time      `json:"day,omitempty"`
+	RecordIds        []int64         `json:"recordIds"`
+	UncountedRecords int             `json:"uncountedRecords"`
+	Glucose          *float64        `json:"glucose,omitempty"`
+	InsulinOnBoard   float64         `json:"insulinOnBoard"`
+	Settings         InsulinSettings `json:"settings"`
+	CarbDose         float64         `json:"carbDose"`
+	CorrectionDose   float64         `json:"correctionDose"`
+	SuggestedDose    float64         `json:"suggestedDose"`
+	Disclaimer       string          `json:"disclaimer"`
+}
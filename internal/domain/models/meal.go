@@ -0,0 +1,46 @@
+package models
+
+type Meal string
+
+const (
+	MealBreakfast Meal = "breakfast"
+	MealLunch     Meal = "lunch"
+	MealDinner    Meal = "dinner"
+	MealSnack     Meal = "snack"
+)
+
+var Meals = []Meal{MealBreakfast, MealLunch, MealDinner, MealSnack}
+
+// MealByHour guesses the meal by the local hour of the day,
+// used when a record is created without an explicit meal.
+func MealByHour(hour int) Meal {
+	switch {
+	case hour >= 5 && hour <= 10:
+		return MealBreakfast
+	case hour >= 11 && hour <= 15:
+		return MealLunch
+	case hour >= 16 && hour <= 20:
+		return MealDinner
+	default:
+		return MealSnack
+	}
+}
+
+// Hour is the usual local hour of the meal, used to date records
+// known by day and meal only. Snacks are dated in the afternoon, which
+// MealByHour guesses as dinner, to keep them out of late-night and fasting
+// windows; callers of Hour set the meal explicitly.
+func (m Meal) Hour() int {
+	switch m {
+	case MealBreakfast:
+		return 8
+	case MealLunch:
+		return 13
+	case MealSnack:
+		return 16
+	case MealDinner:
+		return 19
+	default:
+		return 12
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import "time"
+
+// PlannedMeal is a catalog food portion or recipe servings planned for
+// a meal of a day, RecordId is set once the meal is logged as a record.
+type PlannedMeal struct {
+	Id        int64     `json:"id"`
+	Day       time.Time `json:"day"`
+	Meal      Meal      `json:"meal"`
+	FoodId    *int64    `json:"foodId,omitempty"`
+	RecipeId  *int64    `json:"recipeId,omitempty"`
+	Name      string    `json:"name"`
+	Grams     float64   `json:"grams,omitempty"`
+	Servings  float64   `json:"servings,omitempty"`
+	Value     int       `json:"value"`
+	Nutrients Nutrients `json:"nutrients,omitempty"`
+	RecordId  *int64    `json:"recordId,omitempty"`
+}
+
+type MealPlanDay struct {
+	Day       time.Time     `json:"day"`
+	Meals     []PlannedMeal `json:"meals"`
+	Value     int           `json:"value"`
+	Nutrients Nutrients     `json:"nutrients,omitempty"`
+}
+
+// MealPlan keeps DailyLimit and MacroTargets the plan was generated for.
+type MealPlan struct {
+	Id           int64         `json:"id"`
+	AccountId    int64         `json:"accountId"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	StartDay     time.Time     `json:"startDay"`
+	DailyLimit   int           `json:"dailyLimit"`
+	MacroTargets MacroTargets  `json:"macroTargets"`
+	Days         []MealPlanDay `json:"days"`
+}
+
+// Meals returns planned meals of all plan days.
+func (p MealPlan) Meals() []PlannedMeal {
+	var meals []PlannedMeal
+
+	for _, day := range p.Days {
+		meals = append(meals, day.Meals...)
+	}
+
+	return meals
+}
+
+// In returns a copy of the plan with energy values converted from kcal to unit.
+func (p MealPlan) In(unit EnergyUnit) MealPlan {
+	p.DailyLimit = unit.FromKcal(p.DailyLimit)
+
+	days := make([]MealPlanDay, len(p.Days))
+
+	for i, day := range p.Days {
+		meals := make([]PlannedMeal, len(day.Meals))
+
+		for j, meal := range day.Meals {
+			meal.Value = unit.FromKcal(meal.Value)
+			meals[j] = meal
+		}
+
+		day.Meals = meals
+		day.Value = unit.FromKcal(day.Value)
+		days[i] = day
+	}
+
+	p.Days = days
+
+	return p
+}
+
+// MealPlanDays groups meals ordered by day into days with totals.
+func MealPlanDays(meals []PlannedMeal) []MealPlanDay {
+	days := []MealPlanDay{}
+
+	for _, meal := range meals {
+		last := len(days) - 1
+		if last < 0 || !days[last].Day.Equal(meal.Day) {
+			days = append(days, MealPlanDay{Day: meal.Day})
+			last++
+		}
+
+		day := &days[last]
+		day.Meals = append(day.Meals, meal)
+		day.Value += meal.Value
+
+		for nutrient, amount := range meal.Nutrients {
+			if day.Nutrients == nil {
+				day.Nutrients = Nutrients{}
+			}
+			day.Nutrients[nutrient] += amount
+		}
+	}
+
+	return days
+}
@@ -0,0 +1,124 @@
+package models
+
+import "time"
+
+// MeasurementType names what is measured, besides the predefined types
+// accounts log their own, e.g. "calf".
+type MeasurementType string
+
+const (
+	MeasurementWaist   MeasurementType = "waist"
+	MeasurementHips    MeasurementType = "hips"
+	MeasurementChest   MeasurementType = "chest"
+	MeasurementNeck    MeasurementType = "neck"
+	MeasurementArm     MeasurementType = "arm"
+	MeasurementThigh   MeasurementType = "thigh"
+	MeasurementHeight  MeasurementType = "height"
+	MeasurementBodyFat MeasurementType = "body_fat"
+)
+
+// MeasurementUnit is the unit a measurement is logged in, values are
+// stored as logged.
+type MeasurementUnit string
+
+const (
+	MeasurementUnitCm      MeasurementUnit = "cm"
+	MeasurementUnitIn      MeasurementUnit = "in"
+	MeasurementUnitKg      MeasurementUnit = "kg"
+	MeasurementUnitLb      MeasurementUnit = "lb"
+	MeasurementUnitPercent MeasurementUnit = "%"
+)
+
+const (
+	CmPerIn = 2.54
+	KgPerLb = 0.45359237
+)
+
+// measurementUnitFactors map units to the base unit of their dimension:
+// centimeters, kilograms or percents.
+var measurementUnitFactors = map[MeasurementUnit]struct {
+	dimension string
+	factor    float64
+}{
+	MeasurementUnitCm:      {"length", 1},
+	MeasurementUnitIn:      {"length", CmPerIn},
+	MeasurementUnitKg:      {"mass", 1},
+	MeasurementUnitLb:      {"mass", KgPerLb},
+	MeasurementUnitPercent: {"percent", 1},
+}
+
+// Valid reports whether u is a known unit.
+func (u MeasurementUnit) Valid() bool {
+	_, ok := measurementUnitFactors[u]
+	return ok
+}
+
+// Convert converts value in u to unit, false for units of
+// different dimensions.
+func (u MeasurementUnit) Convert(value float64, unit MeasurementUnit) (float64, bool) {
+	from, ok := measurementUnitFactors[u]
+	if !ok {
+		return 0, false
+	}
+
+	to, ok := measurementUnitFactors[unit]
+	if !ok || from.dimension != to.dimension {
+		return 0, false
+	}
+
+	return value * from.factor / to.factor, true
+}
+
+// Units returns the units a predefined type is logged in,
+// nil for custom types taking any unit.
+func (t MeasurementType) Units() []MeasurementUnit {
+	switch t {
+	case MeasurementWaist, MeasurementHips, MeasurementChest, MeasurementNeck,
+		MeasurementArm, MeasurementThigh, MeasurementHeight:
+		return []MeasurementUnit{MeasurementUnitCm, MeasurementUnitIn}
+	case MeasurementBodyFat:
+		return []MeasurementUnit{MeasurementUnitPercent}
+	}
+
+	return nil
+}
+
+type Measurement struct {
+	Id          int64           `json:"id"`
+	AccountId   int64           `json:"accountId"`
+	Type        MeasurementType `json:"type"`
+	Value       float64         `json:"value"`
+	Unit        MeasurementUnit `json:"unit"`
+	DateRecord  time.Time       `json:"dateRecord"`
+	DateCreated time.Time       `json:"dateCreated"`
+}
+
+// DerivedMetrics are computed from the latest measurements and weight,
+// metrics missing their inputs are nil. Masses are in kilograms.
+type DerivedMetrics struct {
+	WaistToHeight *float64 `json:"waistToHeight,omitempty"`
+	LeanMass      *float64 `json:"leanMass,omitempty"`
+	FatMass       *float64 `json:"fatMass,omitempty"`
+}
+
+type MeasurementSummary struct {
+	Latest  []Measurement  `json:"latest"`
+	Derived DerivedMetrics `json:"derived"`
+}
+
+type MeasurementPoint struct {
+	Day   time.Time `json:"day"`
+	Value float64   `json:"value"`
+}
+
+// MeasurementSeries has the last value of every day with entries in one
+// unit. WeeklyRate is the least squares trend per 7 days.
+type MeasurementSeries struct {
+	Type       MeasurementType    `json:"type"`
+	Unit       MeasurementUnit    `json:"unit"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Points     []MeasurementPoint `json:"points"`
+	Change     *float64           `json:"change,omitempty"`
+	WeeklyRate *float64           `json:"weeklyRate,omitempty"`
+}
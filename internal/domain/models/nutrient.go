@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+type Nutrient string
+
+const (
+	NutrientSodium       Nutrient = "sodium"
+	NutrientSugar        Nutrient = "sugar"
+	NutrientSaturatedFat Nutrient = "saturatedFat"
+	NutrientFiber        Nutrient = "fiber"
+	NutrientVitaminA     Nutrient = "vitaminA"
+	NutrientVitaminC     Nutrient = "vitaminC"
+	NutrientVitaminD     Nutrient = "vitaminD"
+	NutrientCalcium      Nutrient = "calcium"
+	NutrientIron         Nutrient = "iron"
+	NutrientPotassium    Nutrient = "potassium"
+	NutrientMagnesium    Nutrient = "magnesium"
+
+	NutrientCarbs   Nutrient = "carbs"
+	NutrientProtein Nutrient = "protein"
+	NutrientFat     Nutrient = "fat"
+)
+
+// NutrientUnits lists supported micronutrients with the unit of their amounts.
+var NutrientUnits = map[Nutrient]string{
+	NutrientSodium:       "mg",
+	NutrientSugar:        "g",
+	NutrientSaturatedFat: "g",
+	NutrientFiber:        "g",
+	NutrientVitaminA:     "mcg",
+	NutrientVitaminC:     "mg",
+	NutrientVitaminD:     "mcg",
+	NutrientCalcium:      "mg",
+	NutrientIron:         "mg",
+	NutrientPotassium:    "mg",
+	NutrientMagnesium:    "mg",
+}
+
+// MacronutrientUnits lists supported macronutrients with the unit of their amounts.
+var MacronutrientUnits = map[Nutrient]string{
+	NutrientCarbs:   "g",
+	NutrientProtein: "g",
+	NutrientFat:     "g",
+}
+
+// Nutrients are amounts in NutrientUnits or MacronutrientUnits.
+type Nutrients map[Nutrient]float64
+
+// Scale returns a copy of n with every amount multiplied by factor.
+func (n Nutrients) Scale(factor float64) Nutrients {
+	if len(n) == 0 {
+		return nil
+	}
+
+	scaled := make(Nutrients, len(n))
+	for nutrient, amount := range n {
+		scaled[nutrient] = amount * factor
+	}
+
+	return scaled
+}
+
+type NutrientStatus string
+
+const (
+	NutrientStatusDeficient NutrientStatus = "deficient"
+	NutrientStatusAdequate  NutrientStatus = "adequate"
+	NutrientStatusExcess    NutrientStatus = "excess"
+	NutrientStatusNoData    NutrientStatus = "noData"
+)
+
+// ReferenceIntake is a daily target and a tolerable upper limit, zero means none.
+type ReferenceIntake struct {
+	Target     float64 `json:"target,omitempty"`
+	UpperLimit float64 `json:"upperLimit,omitempty"`
+}
+
+type NutrientIntake struct {
+	Nutrient   Nutrient       `json:"nutrient"`
+	Unit       string         `json:"unit"`
+	Average    float64        `json:"average"`
+	Target     float64        `json:"target,omitempty"`
+	UpperLimit float64        `json:"upperLimit,omitempty"`
+	Percent    float64        `json:"percent,omitempty"`
+	Status     NutrientStatus `json:"status"`
+}
+
+// NutrientAmount is a single nutrient amount of a record.
+type NutrientAmount struct {
+	RecordId   int64
+	DateRecord time.Time
+	Nutrient   Nutrient
+	Amount     float64
+}
+
+type MicronutrientReport struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Sex        Sex              `json:"sex"`
+	Age        int              `json:"age"`
+	DaysLogged int              `json:"daysLogged"`
+	Nutrients  []NutrientIntake `json:"nutrients"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// RecordPhoto is a photo attached to a record, the original file and its
+// JPEG thumbnail are kept in the blob store under BlobKey and ThumbnailKey.
+type RecordPhoto struct {
+	Id           int64     `json:"id"`
+	AccountId    int64     `json:"accountId"`
+	RecordId     int64     `json:"recordId"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	BlobKey      string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// QuickAddItem is a food reference parsed from quick-add text. Matched items
+// have FoodId with Grams or RecipeId with Servings, Value is an explicit
+// item energy in kcal.
+type QuickAddItem struct {
+	Text     string  `json:"text"`
+	Name     string  `json:"name,omitempty"`
+	FoodId   *int64  `json:"foodId,omitempty"`
+	Grams    float64 `json:"grams,omitempty"`
+	RecipeId *int64  `json:"recipeId,omitempty"`
+	Servings float64 `json:"servings,omitempty"`
+	Value    *int    `json:"value,omitempty"`
+}
+
+// Matched reports whether the item references a catalog food or recipe.
+func (i QuickAddItem) Matched() bool {
+	return i.FoodId != nil || i.RecipeId != nil
+}
+
+// QuickAdd is parsed quick-add text. Value is an explicit total energy
+// in kcal, Meal is empty when the text does not mention one.
+type QuickAdd struct {
+	DateRecord time.Time
+	Meal       Meal
+	Value      *int
+	Items      []QuickAddItem
+}
+
+// QuickAddResult holds records created from quick-add text, or the records
+// that would be created in preview mode, with unmatched item texts.
+type QuickAddResult struct {
+	Records   []Record             `json:"records"`
+	Warnings  []RestrictionWarning `json:"warnings,omitempty"`
+	Unmatched []string             `json:"unmatched,omitempty"`
+}
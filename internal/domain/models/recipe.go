@@ -0,0 +1,21 @@
+package models
+
+type RecipeIngredient struct {
+	FoodId int64   `json:"foodId"`
+	Name   string  `json:"name"`
+	Grams  float64 `json:"grams"`
+}
+
+// Recipe Value, Nutrients and Allergens are computed from ingredients
+// when the recipe is created, Value and Nutrients are per serving.
+type Recipe struct {
+	Id          int64              `json:"id"`
+	AccountId   int64              `json:"accountId"`
+	Name        string             `json:"name"`
+	Servings    int                `json:"servings"`
+	Ingredients []RecipeIngredient `json:"ingredients"`
+	Value       int                `json:"value"`
+	Nutrients   Nutrients          `json:"nutrients,omitempty"`
+	Allergens   []Allergen         `json:"allergens,omitempty"`
+	Favorite    bool               `json:"favorite"`
+}
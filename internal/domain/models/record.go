@@ -3,9 +3,78 @@ package models
 import "time"
 
 type Record struct {
-	Id          int64     `json:"id"`
-	AccountId   int64     `json:"accountId"`
-	Value       int       `json:"value"`
-	DateRecord  time.Time `json:"dateRecord"`
-	DateCreated time.Time `json:"dateCreated"`
+	Id          int64      `json:"id"`
+	Uuid        string     `json:"uuid"`
+	AccountId   int64      `json:"accountId"`
+	Value       int        `json:"value"`
+	Meal        Meal       `json:"meal"`
+	Description string     `json:"description"`
+	FoodId      *int64     `json:"foodId,omitempty"`
+	Grams       float64    `json:"grams,omitempty"`
+	RecipeId    *int64     `json:"recipeId,omitempty"`
+	Servings    float64    `json:"servings,omitempty"`
+	Nutrients   Nutrients  `json:"nutrients,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DateRecord  time.Time  `json:"dateRecord"`
+	DateCreated time.Time  `json:"dateCreated"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	Version     int64      `json:"version"`
+
+	// Seq is the position of the last change in the account change
+	// sequence, FieldTimes are times the fields were changed at.
+	Seq        int64            `json:"-"`
+	FieldTimes RecordFieldTimes `json:"-"`
+}
+
+type DailyTotal struct {
+	Day          time.Time `json:"day"`
+	Value        int       `json:"value"`
+	RecordsCount int       `json:"recordsCount"`
+}
+
+// RecordDay is the day of daily totals a record dated at t counts to,
+// totals are kept by the UTC date of records as SQLite date() takes it.
+func RecordDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// RecordSearchResult is a record matching a search query, Snippet is
+// the best matching field escaped for HTML with matched terms wrapped
+// in <mark> tags.
+type RecordSearchResult struct {
+	Record
+	Snippet string `json:"snippet"`
+}
+
+// RecordOperationType is a change of a record in a batch.
+type RecordOperationType string
+
+const (
+	RecordOperationCreate RecordOperationType = "create"
+	RecordOperationUpdate RecordOperationType = "update"
+	RecordOperationDelete RecordOperationType = "delete"
+)
+
+// RecordOperation is a single change of a batch. Record is the created
+// record or the updated fields, Version is the expected version of the
+// updated or deleted record.
+type RecordOperation struct {
+	Type     RecordOperationType
+	RecordId int64
+	Version  int64
+	Record   Record
+
+	// Revision is saved along with the operation, created records
+	// fill its RecordId.
+	Revision RecordRevision
+}
+
+// RecordOperationResult is an applied batch operation, Record is nil for
+// deleted records.
+type RecordOperationResult struct {
+	Type     RecordOperationType  `json:"op"`
+	RecordId int64                `json:"recordId"`
+	Record   *Record              `json:"record,omitempty"`
+	Warnings []RestrictionWarning `json:"warnings,omitempty"`
 }
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type FoodTotal struct {
+	Name         string `json:"name"`
+	Value        int    `json:"value"`
+	RecordsCount int    `json:"recordsCount"`
+}
+
+type MonthlyReport struct {
+	Month    time.Time   `json:"month"`
+	Intake   IntakeStats `json:"intake"`
+	Weights  []Weight    `json:"weights"`
+	TopFoods []FoodTotal `json:"topFoods"`
+}
+
+// In returns the report with energy values converted from kcal to unit.
+func (r MonthlyReport) In(unit EnergyUnit) MonthlyReport {
+	r.Intake = r.Intake.In(unit)
+
+	foods := make([]FoodTotal, len(r.TopFoods))
+	for i, food := range r.TopFoods {
+		food.Value = unit.FromKcal(food.Value)
+		foods[i] = food
+	}
+	r.TopFoods = foods
+
+	return r
+}
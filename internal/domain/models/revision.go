@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+type RecordAction string
+
+const (
+	RecordCreated  RecordAction = "created"
+	RecordUpdated  RecordAction = "updated"
+	RecordDeleted  RecordAction = "deleted"
+	RecordRestored RecordAction = "restored"
+)
+
+// RecordValues are the user editable fields of a record, Value is in kcal
+// when stored.
+type RecordValues struct {
+	Value       int       `json:"value"`
+	Meal        Meal      `json:"meal"`
+	Description string    `json:"description"`
+	FoodId      *int64    `json:"foodId,omitempty"`
+	Grams       float64   `json:"grams,omitempty"`
+	RecipeId    *int64    `json:"recipeId,omitempty"`
+	Servings    float64   `json:"servings,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	DateRecord  time.Time `json:"dateRecord"`
+}
+
+// RecordRevision is an append-only entry of a record history. Old is nil
+// for created records, New for deleted ones. ActorId is the account that
+// made the change, accounts only change their own records, so it is always
+// AccountId for now.
+type RecordRevision struct {
+	Id          int64         `json:"id"`
+	RecordId    int64         `json:"recordId"`
+	AccountId   int64         `json:"accountId"`
+	ActorId     int64         `json:"actorId"`
+	Action      RecordAction  `json:"action"`
+	Old         *RecordValues `json:"old,omitempty"`
+	New         *RecordValues `json:"new,omitempty"`
+	DateCreated time.Time     `json:"dateCreated"`
+}
+
+func (r Record) Values() RecordValues {
+	return RecordValues{
+		Value:       r.Value,
+		Meal:        r.Meal,
+		Description: r.Description,
+		FoodId:      r.FoodId,
+		Grams:       r.Grams,
+		RecipeId:    r.RecipeId,
+		Servings:    r.Servings,
+		Tags:        r.Tags,
+		DateRecord:  r.DateRecord,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// ShoppingItem is a catalog food needed in Grams, Quantity is Grams
+// normalized to Unit for display.
+type ShoppingItem struct {
+	FoodId   int64        `json:"foodId"`
+	Name     string       `json:"name"`
+	Category FoodCategory `json:"category"`
+	Grams    float64      `json:"grams"`
+	Quantity float64      `json:"quantity"`
+	Unit     string       `json:"unit"`
+	Checked  bool         `json:"checked"`
+}
+
+type ShoppingCategory struct {
+	Category FoodCategory   `json:"category"`
+	Items    []ShoppingItem `json:"items"`
+}
+
+// ShoppingList covers planned meals of days in [From, To] and whole recipes.
+type ShoppingList struct {
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	RecipeIds  []int64            `json:"recipeIds"`
+	Categories []ShoppingCategory `json:"categories"`
+}
+
+// ShoppingCheck is a check-off state of a food on the shopping list.
+type ShoppingCheck struct {
+	FoodId  int64 `json:"foodId"`
+	Checked bool  `json:"checked"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type IntakeStats struct {
+	From         time.Time    `json:"from"`
+	To           time.Time    `json:"to"`
+	DailyLimit   int          `json:"dailyLimit"`
+	Total        int          `json:"total"`
+	Average      int          `json:"average"`
+	DaysLogged   int          `json:"daysLogged"`
+	DaysOnTarget int          `json:"daysOnTarget"`
+	Days         []DailyTotal `json:"days"`
+	Tags         []TagStats   `json:"tags,omitempty"`
+	EnergyUnit   EnergyUnit   `json:"energyUnit"`
+}
+
+// In returns stats with energy values converted from kcal to unit.
+func (s IntakeStats) In(unit EnergyUnit) IntakeStats {
+	s.EnergyUnit = unit.OrDefault()
+	s.DailyLimit = unit.FromKcal(s.DailyLimit)
+	s.Total = unit.FromKcal(s.Total)
+	s.Average = unit.FromKcal(s.Average)
+
+	days := make([]DailyTotal, len(s.Days))
+	for i, day := range s.Days {
+		day.Value = unit.FromKcal(day.Value)
+		days[i] = day
+	}
+	s.Days = days
+
+	if s.Tags != nil {
+		tags := make([]TagStats, len(s.Tags))
+		for i, tag := range s.Tags {
+			tag.Total = unit.FromKcal(tag.Total)
+			tag.DayAverage = unit.FromKcal(tag.DayAverage)
+			tags[i] = tag
+		}
+		s.Tags = tags
+	}
+
+	return s
+}
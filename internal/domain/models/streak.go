@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Streak struct {
+	Current int        `json:"current"`
+	Longest int        `json:"longest"`
+	LastDay *time.Time `json:"lastDay,omitempty"`
+}
+
+type Streaks struct {
+	AccountId int64  `json:"accountId"`
+	Logging   Streak `json:"logging"`
+	OnTarget  Streak `json:"onTarget"`
+}
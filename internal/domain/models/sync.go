@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// RecordField is a record field merged independently on sync.
+type RecordField string
+
+const (
+	RecordFieldValue       RecordField = "value"
+	RecordFieldMeal        RecordField = "meal"
+	RecordFieldDescription RecordField = "description"
+	RecordFieldDateRecord  RecordField = "dateRecord"
+)
+
+// RecordFields are the fields merged on sync.
+var RecordFields = []RecordField{
+	RecordFieldValue,
+	RecordFieldMeal,
+	RecordFieldDescription,
+	RecordFieldDateRecord,
+}
+
+// RecordFieldTimes are times the record fields were last changed at.
+type RecordFieldTimes map[RecordField]time.Time
+
+// RecordChange is a record created, changed or deleted on a client at
+// UpdatedAt, nil fields were not changed.
+type RecordChange struct {
+	Uuid        string
+	UpdatedAt   time.Time
+	Deleted     bool
+	Value       *int
+	Meal        *Meal
+	Description *string
+	DateRecord  *time.Time
+}
+
+// SyncState is the account change sequence, changes up to Floor may be
+// purged and can not be synced incrementally.
+type SyncState struct {
+	Seq   int64
+	Floor int64
+}
+
+// SyncChange is a record changed on the server, tombstones of deleted
+// records have no Record.
+type SyncChange struct {
+	Uuid    string  `json:"uuid"`
+	Deleted bool    `json:"deleted"`
+	Record  *Record `json:"record,omitempty"`
+}
+
+// SyncResult has server changes since the sync token. Reset asks the
+// client to drop synced records before applying changes, HasMore to sync
+// again with Token.
+type SyncResult struct {
+	Token   string       `json:"token"`
+	Reset   bool         `json:"reset"`
+	HasMore bool         `json:"hasMore"`
+	Changes []SyncChange `json:"changes"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Tag labels records, e.g. "restaurant" or "travel". Names are lowercase
+// and unique per account, RecordsCount is the number of tagged records.
+type Tag struct {
+	Id           int64  `json:"id"`
+	AccountId    int64  `json:"accountId"`
+	Name         string `json:"name"`
+	RecordsCount int    `json:"recordsCount"`
+}
+
+// TagDailyTotal sums records with the tag on a day.
+type TagDailyTotal struct {
+	Tag          string
+	Day          time.Time
+	Value        int
+	RecordsCount int
+}
+
+// TagStats shows how tagged records affect intake: Total sums tagged
+// records only, DayAverage is the average whole day intake on days
+// with tagged records, comparable with IntakeStats.Average.
+type TagStats struct {
+	Tag          string `json:"tag"`
+	Total        int    `json:"total"`
+	RecordsCount int    `json:"recordsCount"`
+	DaysLogged   int    `json:"daysLogged"`
+	DayAverage   int    `json:"dayAverage"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Weight struct {
+	Id          int64     `json:"id"`
+	AccountId   int64     `json:"accountId"`
+	Value       float64   `json:"value"`
+	DateRecord  time.Time `json:"dateRecord"`
+	DateCreated time.Time `json:"dateCreated"`
+}
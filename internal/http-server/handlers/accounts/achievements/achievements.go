@@ -0,0 +1,51 @@
+package achievements
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=AchievementsProvider
+type AchievementsProvider interface {
+	GetAchievementsForCurrentUser(ctx context.Context) ([]models.Achievement, error)
+}
+
+func New(
+	log *slog.Logger,
+	achievementsProvider AchievementsProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.accounts.achievements.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		achievements, err := achievementsProvider.GetAchievementsForCurrentUser(r.Context())
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, achievements)
+	}
+}
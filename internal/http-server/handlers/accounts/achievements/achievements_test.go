@@ -0,0 +1,108 @@
+package achievements_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/achievements"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/achievements/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDate         time.Time            = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+	mockAchievements []models.Achievement = []models.Achievement{
+		{
+			Code:        "first_record",
+			Title:       "First bite",
+			Description: "Log your first record",
+			Unlocked:    true,
+			UnlockedAt:  &mockDate,
+		},
+		{
+			Code:        "records_100",
+			Title:       "Centurion",
+			Description: "Log 100 records",
+		},
+	}
+)
+
+func TestAchievementsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockAchievements     []models.Achievement
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockAchievements:     mockAchievements,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockAchievements:     nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockAchievements:     nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewAchievementsProvider(t)
+			mockProvider.On("GetAchievementsForCurrentUser", mock.Anything).
+				Return(tc.mockAchievements, tc.expectedError).
+				Once()
+
+			handler := achievements.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/accounts/me/achievements", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var result []models.Achievement
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockAchievements, result)
+			}
+
+		})
+	}
+}
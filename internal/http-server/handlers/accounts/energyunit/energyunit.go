@@ -0,0 +1,97 @@
+package energyunit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=EnergyUnitUpdater
+type EnergyUnitUpdater interface {
+	UpdateEnergyUnitForCurrentUser(
+		ctx context.Context,
+		version int64,
+		unit models.EnergyUnit,
+	) (models.Account, error)
+}
+
+type Request struct {
+	EnergyUnit string `json:"energyUnit" validate:"required,oneof=kcal kJ"`
+}
+
+func New(
+	log *slog.Logger,
+	unitUpdater EnergyUnitUpdater,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.accounts.energyunit.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			log.Info("invalid precondition", slog.String("err", err.Error()))
+			render.Status(r, etag.Status(err))
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		acc, err := unitUpdater.UpdateEnergyUnitForCurrentUser(r.Context(), version, models.EnergyUnit(req.EnergyUnit))
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, account.ErrInvalidEnergyUnit) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid energy unit"))
+				return
+			}
+
+			if errors.Is(err, account.ErrVersionMismatch) {
+				render.Status(r, http.StatusPreconditionFailed)
+				render.JSON(w, r, response.ErrorMessage("version mismatch"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		etag.Set(w, acc.Version)
+		render.JSON(w, r, acc.InEnergyUnit())
+	}
+}
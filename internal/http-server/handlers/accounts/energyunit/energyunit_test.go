@@ -0,0 +1,175 @@
+package energyunit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/energyunit"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/energyunit/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockAccount models.Account = models.Account{
+	Id:         1,
+	Version:    3,
+	UserId:     1,
+	DailyLimit: 2000,
+	EnergyUnit: models.EnergyUnitKj,
+}
+
+func TestEnergyUnitHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty unit",
+			ifMatch:              `"3"`,
+			reqBody:              `{}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown unit",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "cal"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid energy unit",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        account.ErrInvalidEnergyUnit,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid energy unit",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			reqBody:              `{"energyUnit": "kJ"}`,
+			expectedError:        account.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewEnergyUnitUpdater(t)
+			mockUpdater.On(
+				"UpdateEnergyUnitForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("int64"),
+				models.EnergyUnitKj,
+			).Return(mockAccount, tc.expectedError).Maybe()
+
+			handler := energyunit.New(slog.Default(), mockUpdater)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/accounts/me/energy-unit",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, etag.Format(mockAccount.Version), responseRecorder.Header().Get("ETag"))
+
+				var acc models.Account
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &acc)
+				require.NoError(t, err)
+
+				// The daily limit is stored in kcal and shown in kJ
+				expected := mockAccount
+				expected.DailyLimit = 8368
+				assert.Equal(t, expected, acc)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/export"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=Exporter
+type Exporter interface {
+	ExportForCurrentUser(
+		ctx context.Context,
+		from time.Time,
+		to time.Time,
+		encoder export.Encoder,
+	) error
+}
+
+func New(
+	log *slog.Logger,
+	exporter Exporter,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.accounts.export.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format := export.Format(r.URL.Query().Get("format"))
+		if format == "" {
+			format = export.FormatJSON
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		from, to, err := query.OpenRange(r, loc)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		// "to" is an inclusive day, the service takes an exclusive bound
+		if !to.IsZero() {
+			to = to.AddDate(0, 0, 1)
+		}
+
+		encoder, err := export.New(format, w)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		download := &downloadEncoder{Encoder: encoder, w: w, format: format}
+
+		err = exporter.ExportForCurrentUser(r.Context(), from, to, download)
+		if err != nil {
+			if download.started {
+				// Headers are sent, the client gets a truncated file
+				log.Error("export interrupted", slog.String("err", err.Error()))
+				return
+			}
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+	}
+}
+
+// downloadEncoder sends download headers when the export begins, so errors
+// before it are still responded with JSON.
+type downloadEncoder struct {
+	export.Encoder
+	w       http.ResponseWriter
+	format  export.Format
+	started bool
+}
+
+func (e *downloadEncoder) Begin(acc models.Account) error {
+	e.started = true
+
+	e.w.Header().Set("Content-Type", e.format.ContentType())
+	e.w.Header().Set(
+		"Content-Disposition",
+		fmt.Sprintf(
+			`attachment; filename="export-%s.%s"`,
+			time.Now().UTC().Format(query.DateFormat),
+			e.format,
+		),
+	)
+
+	return e.Encoder.Begin(acc)
+}
@@ -0,0 +1,189 @@
+package export_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/export"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/export/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	exportformat "github.com/karmaplush/simple-diet-tracker/internal/lib/export"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecord = models.Record{
+	Id:         10,
+	AccountId:  1,
+	Value:      300,
+	Meal:       models.MealBreakfast,
+	DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+}
+
+// exported writes a single record like the service does and fails with err
+// before or after the export begins.
+func exported(err error, begun bool) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		if err != nil && !begun {
+			return
+		}
+
+		encoder := args.Get(3).(exportformat.Encoder)
+		_ = encoder.Begin(models.Account{Id: 1, EnergyUnit: models.EnergyUnitKcal})
+		_ = encoder.Section(models.ExportRecords)
+		_ = encoder.Item(mockRecord)
+
+		if err == nil {
+			_ = encoder.End()
+		}
+	}
+}
+
+func TestExportHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		begun                bool
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedErrorMessage string
+	}{
+		{
+			name:                "json by default",
+			query:               "",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+		},
+		{
+			name:                "csv",
+			query:               "?format=csv&from=2024-04-01&to=2024-04-30",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+		},
+		{
+			name:                "ndjson",
+			query:               "?format=ndjson",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+		},
+		{
+			name:                 "unknown format",
+			query:                "?format=xlsx",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: exportformat.ErrUnknownFormat.Error(),
+		},
+		{
+			name:                 "invalid range",
+			query:                "?from=2024-05-01&to=2024-04-01",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?from=01.04.2024",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                "interrupted export",
+			query:               "?format=ndjson",
+			expectedError:       errors.New("some unexpected service layer error was occured"),
+			begun:               true,
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockExporter := mocks.NewExporter(t)
+			mockExporter.On(
+				"ExportForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+				mock.Anything,
+			).Run(exported(tc.expectedError, tc.begun)).Return(tc.expectedError).Maybe()
+
+			handler := export.New(slog.Default(), mockExporter)
+
+			req, err := http.NewRequest(http.MethodGet, "/export"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+				assert.Equal(t, "", responseRecorder.Header().Get("Content-Disposition"))
+				return
+			}
+
+			assert.Equal(t, tc.expectedContentType, responseRecorder.Header().Get("Content-Type"))
+			require.Contains(t, responseRecorder.Header().Get("Content-Disposition"), "attachment")
+
+			if tc.expectedError == nil {
+				require.NotEmpty(t, responseRecorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestExportHandlerRange(t *testing.T) {
+	mockExporter := mocks.NewExporter(t)
+	mockExporter.On(
+		"ExportForCurrentUser",
+		mock.Anything,
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		mock.Anything,
+	).Run(exported(nil, false)).Return(nil).Once()
+
+	handler := export.New(slog.Default(), mockExporter)
+
+	req, err := http.NewRequest(http.MethodGet, "/export?from=2024-04-01&to=2024-04-30", nil)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var decoded struct {
+		Records []models.Record `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &decoded))
+	assert.Equal(t, []models.Record{mockRecord}, decoded.Records)
+}
@@ -0,0 +1,174 @@
+package macrotargets_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/macrotargets"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/macrotargets/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockTargets = models.MacroTargets{Carbs: 250, Protein: 150}
+
+var mockAccount models.Account = models.Account{
+	Id:           1,
+	Version:      3,
+	UserId:       1,
+	DailyLimit:   2000,
+	EnergyUnit:   models.EnergyUnitKcal,
+	MacroTargets: mockTargets,
+}
+
+func TestMacroTargetsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "negative target",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150, "fat": -1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "target too big",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 2500, "protein": 150}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid macro targets",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        account.ErrInvalidMacroTargets,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid macro targets",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			reqBody:              `{"carbs": 250, "protein": 150}`,
+			expectedError:        account.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewMacroTargetsUpdater(t)
+			mockUpdater.On(
+				"UpdateMacroTargetsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("int64"),
+				mockTargets,
+			).Return(mockAccount, tc.expectedError).Maybe()
+
+			handler := macrotargets.New(slog.Default(), mockUpdater)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/accounts/me/macro-targets",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, etag.Format(mockAccount.Version), responseRecorder.Header().Get("ETag"))
+
+				var acc models.Account
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &acc)
+				require.NoError(t, err)
+				assert.Equal(t, mockAccount, acc)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
 	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
 )
@@ -45,6 +46,7 @@ func New(
 			return
 		}
 
-		render.JSON(w, r, acc)
+		etag.Set(w, acc.Version)
+		render.JSON(w, r, acc.InEnergyUnit())
 	}
 }
@@ -31,6 +31,7 @@ func TestMeHandler(t *testing.T) {
 		mockError            error
 		expectedCode         int
 		expectedErrorMessage string
+		expectedAccount      models.Account
 	}{
 		{
 			name:                 "success",
@@ -39,6 +40,26 @@ func TestMeHandler(t *testing.T) {
 			mockError:            nil,
 			expectedCode:         http.StatusOK,
 			expectedErrorMessage: "",
+			expectedAccount:      models.Account{Id: 42, UserId: 42, DailyLimit: 1888},
+		},
+		{
+			name:     "success in kJ",
+			jwtToken: "good_jwt_token",
+			mockAccount: models.Account{
+				Id:         42,
+				UserId:     42,
+				DailyLimit: 2000,
+				EnergyUnit: models.EnergyUnitKj,
+			},
+			mockError:            nil,
+			expectedCode:         http.StatusOK,
+			expectedErrorMessage: "",
+			expectedAccount: models.Account{
+				Id:         42,
+				UserId:     42,
+				DailyLimit: 8368,
+				EnergyUnit: models.EnergyUnitKj,
+			},
 		},
 		{
 			name:                 "invalid jwt",
@@ -101,7 +122,7 @@ func TestMeHandler(t *testing.T) {
 				var acc models.Account
 				err = json.Unmarshal(rr.Body.Bytes(), &acc)
 				require.NoError(t, err)
-				assert.Equal(t, tc.mockAccount, acc)
+				assert.Equal(t, tc.expectedAccount, acc)
 			}
 		})
 	}
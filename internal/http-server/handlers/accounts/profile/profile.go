@@ -0,0 +1,110 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=ProfileUpdater
+type ProfileUpdater interface {
+	UpdateProfileForCurrentUser(
+		ctx context.Context,
+		version int64,
+		profile models.Profile,
+	) (models.Account, error)
+}
+
+type Request struct {
+	Sex       string `json:"sex"       validate:"required,oneof=female male"`
+	BirthDate string `json:"birthDate" validate:"required"`
+}
+
+func New(
+	log *slog.Logger,
+	profileUpdater ProfileUpdater,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.accounts.profile.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			log.Info("invalid precondition", slog.String("err", err.Error()))
+			render.Status(r, etag.Status(err))
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		birthDate, err := time.Parse(query.DateFormat, req.BirthDate)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(query.ErrInvalidDate.Error()))
+			return
+		}
+
+		acc, err := profileUpdater.UpdateProfileForCurrentUser(r.Context(), version, models.Profile{
+			Sex:       models.Sex(req.Sex),
+			BirthDate: birthDate,
+		})
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, account.ErrInvalidBirthDate) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("birth date should be in the past"))
+				return
+			}
+
+			if errors.Is(err, account.ErrVersionMismatch) {
+				render.Status(r, http.StatusPreconditionFailed)
+				render.JSON(w, r, response.ErrorMessage("version mismatch"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		etag.Set(w, acc.Version)
+		render.JSON(w, r, acc.InEnergyUnit())
+	}
+}
@@ -0,0 +1,184 @@
+package profile_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/profile"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/profile/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockBirthDate time.Time      = time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC)
+	mockAccount   models.Account = models.Account{
+		Id:         1,
+		Version:    3,
+		UserId:     1,
+		DailyLimit: 2000,
+		Sex:        models.SexFemale,
+		BirthDate:  &mockBirthDate,
+	}
+)
+
+func TestProfileHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty sex",
+			ifMatch:              `"3"`,
+			reqBody:              `{"birthDate": "1990-06-15"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid sex",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "unknown", "birthDate": "1990-06-15"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid birth date",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "male", "birthDate": "15.06.1990"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "service layer: birth date in future",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "male", "birthDate": "2990-06-15"}`,
+			expectedError:        account.ErrInvalidBirthDate,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "birth date should be in the past",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "female"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			reqBody:              `{"sex": "female", "birthDate": "1990-06-15"}`,
+			expectedError:        account.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewProfileUpdater(t)
+			mockUpdater.On(
+				"UpdateProfileForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("int64"),
+				mock.AnythingOfType("models.Profile"),
+			).Return(mockAccount, tc.expectedError).Maybe()
+
+			handler := profile.New(slog.Default(), mockUpdater)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/accounts/me/profile",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, etag.Format(mockAccount.Version), responseRecorder.Header().Get("ETag"))
+
+				var acc models.Account
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &acc)
+				require.NoError(t, err)
+				assert.Equal(t, mockAccount, acc)
+			}
+		})
+	}
+}
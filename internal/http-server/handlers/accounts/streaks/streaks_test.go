@@ -0,0 +1,99 @@
+package streaks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/streaks"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/accounts/streaks/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDay     time.Time      = time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+	mockStreaks models.Streaks = models.Streaks{
+		AccountId: 1,
+		Logging:   models.Streak{Current: 3, Longest: 10, LastDay: &mockDay},
+		OnTarget:  models.Streak{Current: 1, Longest: 4, LastDay: &mockDay},
+	}
+)
+
+func TestStreaksHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockStreaks          models.Streaks
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockStreaks:          mockStreaks,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockStreaks:          models.Streaks{},
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockStreaks:          models.Streaks{},
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewStreaksProvider(t)
+			mockProvider.On("GetStreaksForCurrentUser", mock.Anything).
+				Return(tc.mockStreaks, tc.expectedError).
+				Once()
+
+			handler := streaks.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/accounts/me/streaks", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var result models.Streaks
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockStreaks, result)
+			}
+
+		})
+	}
+}
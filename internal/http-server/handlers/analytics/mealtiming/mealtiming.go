@@ -0,0 +1,76 @@
+package mealtiming
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MealTimingProvider
+type MealTimingProvider interface {
+	GetMealTimingForCurrentUser(
+		ctx context.Context,
+		from time.Time,
+		to time.Time,
+	) (models.MealTiming, error)
+}
+
+const (
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+)
+
+func New(
+	log *slog.Logger,
+	mealTimingProvider MealTimingProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.analytics.mealtiming.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		from, to, err := query.DateRange(r, loc, defaultRangeDays, maxRangeDays)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		timing, err := mealTimingProvider.GetMealTimingForCurrentUser(r.Context(), from, to)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, timing)
+	}
+}
@@ -0,0 +1,97 @@
+package intake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/chart"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=IntakeStatsProvider
+type IntakeStatsProvider interface {
+	GetIntakeStatsForCurrentUser(
+		ctx context.Context,
+		from time.Time,
+		to time.Time,
+	) (models.IntakeStats, error)
+}
+
+const (
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+	labelFormat      = "01-02"
+)
+
+func New(
+	log *slog.Logger,
+	statsProvider IntakeStatsProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.charts.intake.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		from, to, err := query.DateRange(r, time.UTC, defaultRangeDays, maxRangeDays)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		stats, err := statsProvider.GetIntakeStatsForCurrentUser(r.Context(), from, to)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		points := make([]chart.Point, 0, len(stats.Days))
+		for _, day := range stats.Days {
+			points = append(points, chart.Point{
+				Label: day.Day.Format(labelFormat),
+				Value: float64(day.Value),
+			})
+		}
+
+		limit := float64(stats.DailyLimit)
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+
+		err = chart.Bars(w, chart.Chart{
+			Title: fmt.Sprintf(
+				"Intake %s - %s, %s",
+				stats.From.Format(query.DateFormat),
+				stats.To.Format(query.DateFormat),
+				stats.EnergyUnit.OrDefault(),
+			),
+			Points:     points,
+			Limit:      &limit,
+			LimitLabel: fmt.Sprintf("limit %d", stats.DailyLimit),
+		})
+		if err != nil {
+			log.Error("failed to render chart", slog.String("err", err.Error()))
+		}
+	}
+}
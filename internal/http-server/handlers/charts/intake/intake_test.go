@@ -0,0 +1,114 @@
+package intake_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/intake"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/intake/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDay   time.Time          = time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+	mockStats models.IntakeStats = models.IntakeStats{
+		From:       mockDay,
+		To:         mockDay.AddDate(0, 0, 1),
+		DailyLimit: 2000,
+		Days: []models.DailyTotal{
+			{Day: mockDay, Value: 1800, RecordsCount: 3},
+			{Day: mockDay.AddDate(0, 0, 1), Value: 2300, RecordsCount: 4},
+		},
+	}
+)
+
+func TestIntakeChartHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "?from=2024-04-19&to=2024-04-20",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid range",
+			query:                "?from=2024-04-20&to=2024-04-19",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewIntakeStatsProvider(t)
+			mockProvider.On(
+				"GetIntakeStatsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockStats, tc.expectedError).Maybe()
+
+			handler := intake.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/charts/intake.svg"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, "image/svg+xml", responseRecorder.Header().Get("Content-Type"))
+				body := responseRecorder.Body.String()
+				require.True(t, strings.HasPrefix(body, "<svg"))
+				require.Contains(t, body, "limit 2000")
+			}
+
+		})
+	}
+}
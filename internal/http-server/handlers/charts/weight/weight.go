@@ -0,0 +1,65 @@
+package weight
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/chart"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=WeightProvider
+type WeightProvider interface {
+	GetWeightsForCurrentUser(ctx context.Context) ([]models.Weight, error)
+}
+
+func New(
+	log *slog.Logger,
+	weightProvider WeightProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.charts.weight.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		weights, err := weightProvider.GetWeightsForCurrentUser(r.Context())
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		points := make([]chart.Point, 0, len(weights))
+		for _, weight := range weights {
+			points = append(points, chart.Point{
+				Label: weight.DateRecord.Format(query.DateFormat),
+				Value: weight.Value,
+			})
+		}
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+
+		if err := chart.Line(w, chart.Chart{Title: "Weight, kg", Points: points}); err != nil {
+			log.Error("failed to render chart", slog.String("err", err.Error()))
+		}
+	}
+}
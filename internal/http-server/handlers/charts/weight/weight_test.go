@@ -0,0 +1,95 @@
+package weight_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/weight"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/charts/weight/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDate    time.Time       = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+	mockWeights []models.Weight = []models.Weight{
+		{Id: 1, AccountId: 1, Value: 80.5, DateRecord: mockDate},
+		{Id: 2, AccountId: 1, Value: 79.8, DateRecord: mockDate.AddDate(0, 0, 7)},
+	}
+)
+
+func TestWeightChartHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewWeightProvider(t)
+			mockProvider.On("GetWeightsForCurrentUser", mock.Anything).
+				Return(mockWeights, tc.expectedError).
+				Once()
+
+			handler := weight.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/charts/weight.svg", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, "image/svg+xml", responseRecorder.Header().Get("Content-Type"))
+				body := responseRecorder.Body.String()
+				require.True(t, strings.HasPrefix(body, "<svg"))
+				require.Contains(t, body, "<polyline")
+			}
+
+		})
+	}
+}
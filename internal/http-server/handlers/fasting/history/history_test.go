@@ -0,0 +1,103 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/history"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/history/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDate     time.Time               = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+	mockSessions []models.FastingSession = []models.FastingSession{
+		{
+			Id:             1,
+			AccountId:      1,
+			Protocol:       "18:6",
+			PlannedMinutes: 18 * 60,
+			StartedAt:      mockDate,
+		},
+	}
+)
+
+func TestFastingHistoryHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockSessions         []models.FastingSession
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockSessions:         mockSessions,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockSessions:         nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockSessions:         nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewFastingHistoryProvider(t)
+			mockProvider.On("GetFastingHistoryForCurrentUser", mock.Anything).
+				Return(tc.mockSessions, tc.expectedError).
+				Once()
+
+			handler := history.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/fasting/sessions", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var sessions []models.FastingSession
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &sessions)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockSessions, sessions)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package start
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=FastingStarter
+type FastingStarter interface {
+	StartFastingForCurrentUser(
+		ctx context.Context,
+		protocol string,
+		plannedMinutes int,
+		startedAt time.Time,
+	) (models.FastingSession, error)
+}
+
+type Request struct {
+	Protocol       string    `json:"protocol"                 validate:"required,oneof=16:8 18:6 omad custom"`
+	PlannedMinutes int       `json:"plannedMinutes,omitempty" validate:"omitempty,gt=0"`
+	StartedAt      time.Time `json:"startedAt,omitempty"`
+}
+
+func New(
+	log *slog.Logger,
+	fastingStarter FastingStarter,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.fasting.start.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		session, err := fastingStarter.StartFastingForCurrentUser(
+			r.Context(),
+			req.Protocol,
+			req.PlannedMinutes,
+			req.StartedAt,
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, fasting.ErrUnknownProtocol) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("plannedMinutes is required for custom protocol"))
+				return
+			}
+
+			if errors.Is(err, fasting.ErrFastingInProgress) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("fasting already in progress"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, session)
+	}
+}
@@ -0,0 +1,156 @@
+package start_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/start"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/start/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockSession models.FastingSession = models.FastingSession{
+	Id:             1,
+	AccountId:      1,
+	Protocol:       "16:8",
+	PlannedMinutes: 16 * 60,
+	StartedAt:      time.Date(2024, 4, 19, 20, 0, 0, 0, time.UTC),
+}
+
+func TestStartFastingHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"protocol": "16:8"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with custom protocol and start",
+			reqBody:              `{"protocol": "custom", "plannedMinutes": 720, "startedAt": "2024-04-19T20:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty protocol",
+			reqBody:              `{}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown protocol",
+			reqBody:              `{"protocol": "20:4"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "negative planned minutes",
+			reqBody:              `{"protocol": "custom", "plannedMinutes": -1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: custom protocol without planned minutes",
+			reqBody:              `{"protocol": "custom"}`,
+			expectedError:        fasting.ErrUnknownProtocol,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "plannedMinutes is required for custom protocol",
+		},
+		{
+			name:                 "service layer: fasting in progress",
+			reqBody:              `{"protocol": "omad"}`,
+			expectedError:        fasting.ErrFastingInProgress,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "fasting already in progress",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"protocol": "16:8"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"protocol": "16:8"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"protocol": "16:8"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockStarter := mocks.NewFastingStarter(t)
+			mockStarter.On(
+				"StartFastingForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("int"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockSession, tc.expectedError).Maybe()
+
+			handler := start.New(slog.Default(), mockStarter)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/fasting/start",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var session models.FastingSession
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &session)
+				require.NoError(t, err)
+				assert.Equal(t, mockSession, session)
+			}
+		})
+	}
+}
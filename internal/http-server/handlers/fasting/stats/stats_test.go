@@ -0,0 +1,94 @@
+package stats_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stats"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stats/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockStats models.FastingStats = models.FastingStats{
+	SessionsCount:   4,
+	CompletedCount:  3,
+	CompletionRate:  0.75,
+	AverageMinutes:  950,
+	LongestMinutes:  1100,
+	ProtocolsCounts: map[string]int{"16:8": 4},
+}
+
+func TestFastingStatsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewFastingStatsProvider(t)
+			mockProvider.On("GetFastingStatsForCurrentUser", mock.Anything).
+				Return(mockStats, tc.expectedError).
+				Once()
+
+			handler := stats.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/fasting/stats", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var fastingStats models.FastingStats
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &fastingStats)
+				require.NoError(t, err)
+				assert.Equal(t, mockStats, fastingStats)
+			}
+
+		})
+	}
+}
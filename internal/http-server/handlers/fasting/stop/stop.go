@@ -0,0 +1,78 @@
+package stop
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=FastingStopper
+type FastingStopper interface {
+	StopFastingForCurrentUser(ctx context.Context, endedAt time.Time) (models.FastingSession, error)
+}
+
+// Request body is optional, active session is stopped now by default.
+type Request struct {
+	EndedAt time.Time `json:"endedAt,omitempty"`
+}
+
+func New(
+	log *slog.Logger,
+	fastingStopper FastingStopper,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.fasting.stop.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		session, err := fastingStopper.StopFastingForCurrentUser(r.Context(), req.EndedAt)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, fasting.ErrNoActiveFasting) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("no active fasting"))
+				return
+			}
+
+			if errors.Is(err, fasting.ErrInvalidEndTime) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("endedAt is before fasting start"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, session)
+	}
+}
@@ -0,0 +1,140 @@
+package stop_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stop"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/stop/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockStart   time.Time             = time.Date(2024, 4, 19, 20, 0, 0, 0, time.UTC)
+	mockEnd     time.Time             = mockStart.Add(16 * time.Hour)
+	mockSession models.FastingSession = models.FastingSession{
+		Id:              1,
+		AccountId:       1,
+		Protocol:        "16:8",
+		PlannedMinutes:  16 * 60,
+		StartedAt:       mockStart,
+		EndedAt:         &mockEnd,
+		DurationMinutes: 16 * 60,
+		Completed:       true,
+	}
+)
+
+func TestStopFastingHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success without body",
+			reqBody:              "",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with end",
+			reqBody:              `{"endedAt": "2024-04-20T12:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: no active fasting",
+			reqBody:              "",
+			expectedError:        fasting.ErrNoActiveFasting,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "no active fasting",
+		},
+		{
+			name:                 "service layer: end before start",
+			reqBody:              `{"endedAt": "2024-04-19T12:00:00Z"}`,
+			expectedError:        fasting.ErrInvalidEndTime,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "endedAt is before fasting start",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"endedAt": `,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockStopper := mocks.NewFastingStopper(t)
+			mockStopper.On(
+				"StopFastingForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+			).Return(mockSession, tc.expectedError).Maybe()
+
+			handler := stop.New(slog.Default(), mockStopper)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/fasting/stop",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var session models.FastingSession
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &session)
+				require.NoError(t, err)
+				assert.Equal(t, mockSession, session)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package windows_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/windows"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/fasting/windows/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDay     time.Time              = time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+	mockWindows []models.FastingWindow = []models.FastingWindow{
+		{
+			Day:     mockDay,
+			Start:   mockDay.Add(-4 * time.Hour),
+			End:     mockDay.Add(9 * time.Hour),
+			Minutes: 13 * 60,
+		},
+	}
+)
+
+func TestFastingWindowsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success with defaults",
+			query:                "",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with range and timezone",
+			query:                "?from=2024-04-01&to=2024-04-30&tz=Europe/Berlin",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?from=invalid",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "from after to",
+			query:                "?from=2024-05-01&to=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "range too long",
+			query:                "?from=2020-01-01&to=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?tz=Mars/Olympus",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewFastingWindowsProvider(t)
+			mockProvider.On(
+				"GetFastingWindowsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockWindows, tc.expectedError).Maybe()
+
+			handler := windows.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/fasting/windows"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var windows []models.FastingWindow
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &windows)
+				require.NoError(t, err)
+				assert.Equal(t, mockWindows, windows)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=FoodCreator
+type FoodCreator interface {
+	CreateFoodForCurrentUser(ctx context.Context, food models.Food) (models.Food, error)
+}
+
+// Request values are per 100 g of food, value is in the account energy unit.
+type Request struct {
+	Name      string            `json:"name"      validate:"required,max=255"`
+	Value     int               `json:"value"     validate:"gte=0"`
+	Nutrients models.Nutrients  `json:"nutrients"`
+	Allergens []models.Allergen `json:"allergens"`
+	Favorite  bool              `json:"favorite"`
+	Category  string            `json:"category"  validate:"omitempty,oneof=produce meat fish dairy bakery grains pantry frozen beverages other"`
+}
+
+func New(
+	log *slog.Logger,
+	foodCreator FoodCreator,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.foods.create.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		created, err := foodCreator.CreateFoodForCurrentUser(r.Context(), models.Food{
+			Name:      req.Name,
+			Value:     req.Value,
+			Nutrients: req.Nutrients,
+			Allergens: req.Allergens,
+			Favorite:  req.Favorite,
+			Category:  models.FoodCategory(req.Category),
+		})
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, food.ErrInvalidNutrients) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid nutrients"))
+				return
+			}
+
+			if errors.Is(err, food.ErrInvalidCategory) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid category"))
+				return
+			}
+
+			if errors.Is(err, restriction.ErrInvalidAllergens) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid allergens"))
+				return
+			}
+
+			if errors.Is(err, food.ErrFoodExists) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("food already exists"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, created)
+	}
+}
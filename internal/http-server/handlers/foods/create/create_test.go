@@ -0,0 +1,163 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockFood models.Food = models.Food{
+	Id:        1,
+	AccountId: 1,
+	Name:      "Oatmeal",
+	Value:     370,
+	Nutrients: models.Nutrients{models.NutrientFiber: 10},
+	Allergens: []models.Allergen{models.AllergenGluten},
+	Category:  models.FoodCategoryGrains,
+}
+
+func TestCreateFoodHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"name": "Oatmeal", "value": 370, "nutrients": {"fiber": 10}, "allergens": ["gluten"], "category": "grains"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty name",
+			reqBody:              `{"value": 370}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "negative value",
+			reqBody:              `{"name": "Oatmeal", "value": -1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown category",
+			reqBody:              `{"name": "Oatmeal", "value": 370, "category": "cereals"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid category",
+			reqBody:              `{"name": "Oatmeal", "value": 370}`,
+			expectedError:        food.ErrInvalidCategory,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid category",
+		},
+		{
+			name:                 "service layer: invalid nutrients",
+			reqBody:              `{"name": "Oatmeal", "value": 370, "nutrients": {"fiber": -1}}`,
+			expectedError:        food.ErrInvalidNutrients,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid nutrients",
+		},
+		{
+			name:                 "service layer: invalid allergens",
+			reqBody:              `{"name": "Oatmeal", "value": 370, "allergens": ["chocolate"]}`,
+			expectedError:        restriction.ErrInvalidAllergens,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid allergens",
+		},
+		{
+			name:                 "service layer: food exists",
+			reqBody:              `{"name": "Oatmeal", "value": 370}`,
+			expectedError:        food.ErrFoodExists,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "food already exists",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"name": "Oatmeal", "value": 370}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"name": "Oatmeal", "value": 370}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"name": "Oatmeal"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewFoodCreator(t)
+			mockCreator.On(
+				"CreateFoodForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.Food"),
+			).Return(mockFood, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/foods",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var created models.Food
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &created)
+				require.NoError(t, err)
+				assert.Equal(t, mockFood, created)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/foods/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockFoods []models.Food = []models.Food{
+	{
+		Id:        1,
+		AccountId: 1,
+		Name:      "Oatmeal",
+		Value:     370,
+		Nutrients: models.Nutrients{models.NutrientFiber: 10, models.NutrientIron: 4.3},
+	},
+}
+
+func TestFoodsListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockFoods            []models.Food
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockFoods:            mockFoods,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockFoods:            nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockFoods:            nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewFoodProvider(t)
+			mockProvider.On("GetFoodsForCurrentUser", mock.Anything).
+				Return(tc.mockFoods, tc.expectedError).
+				Once()
+
+			handler := list.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/foods", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var foods []models.Food
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &foods)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockFoods, foods)
+			}
+
+		})
+	}
+}
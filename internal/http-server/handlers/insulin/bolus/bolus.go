@@ -0,0 +1,121 @@
+package bolus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=BolusCalculator
+type BolusCalculator interface {
+	CalculateBolusForCurrentUser(
+		ctx context.Context,
+		input models.BolusInput,
+	) (models.BolusCalculation, error)
+}
+
+// Request takes carbs either directly or from records of meal on date
+// (today by default, in the "tz" query param location).
+type Request struct {
+	Carbs          *float64 `json:"carbs"          validate:"omitempty,gte=0,lte=1000"`
+	Meal           string   `json:"meal"           validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Date           string   `json:"date"`
+	Glucose        *float64 `json:"glucose"        validate:"omitempty,gt=0"`
+	InsulinOnBoard float64  `json:"insulinOnBoard" validate:"gte=0"`
+}
+
+func New(
+	log *slog.Logger,
+	bolusCalculator BolusCalculator,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.insulin.bolus.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		day, err := query.ParseDay(req.Date, loc)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		calculation, err := bolusCalculator.CalculateBolusForCurrentUser(r.Context(), models.BolusInput{
+			Carbs:          req.Carbs,
+			Meal:           models.Meal(req.Meal),
+			Day:            day,
+			Glucose:        req.Glucose,
+			InsulinOnBoard: req.InsulinOnBoard,
+		})
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, insulin.ErrSettingsNotFound) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("insulin settings are not configured"))
+				return
+			}
+
+			if errors.Is(err, insulin.ErrAmbiguousCarbs) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("carbs can be either given or taken from a meal"))
+				return
+			}
+
+			if errors.Is(err, insulin.ErrInvalidGlucose) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("glucose reading out of range"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, calculation)
+	}
+}
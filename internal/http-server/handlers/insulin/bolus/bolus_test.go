@@ -0,0 +1,204 @@
+package bolus_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/bolus"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/bolus/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var glucose = 210.0
+
+var mockCalculation models.BolusCalculation = models.BolusCalculation{
+	Id:          1,
+	AccountId:   1,
+	CreatedAt:   time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC),
+	Carbs:       60,
+	CarbsSource: models.CarbsSourceManual,
+	RecordIds:   []int64{},
+	Glucose:     &glucose,
+	Settings: models.InsulinSettings{
+		CarbRatio:        10,
+		CorrectionFactor: 50,
+		TargetGlucose:    110,
+		GlucoseUnit:      models.GlucoseUnitMgDl,
+		DoseIncrement:    0.5,
+	},
+	CarbDose:       6,
+	CorrectionDose: 2,
+	SuggestedDose:  8,
+	Disclaimer:     models.InsulinDisclaimer,
+}
+
+func TestBolusHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success with carbs",
+			reqBody:              `{"carbs": 60, "glucose": 210}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with meal",
+			query:                "?tz=Europe/Berlin",
+			reqBody:              `{"meal": "lunch", "date": "2024-04-01", "insulinOnBoard": 0.5}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "negative carbs",
+			reqBody:              `{"carbs": -1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown meal",
+			reqBody:              `{"meal": "brunch"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid date",
+			reqBody:              `{"meal": "lunch", "date": "yesterday"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "service layer: settings not found",
+			reqBody:              `{"carbs": 60}`,
+			expectedError:        insulin.ErrSettingsNotFound,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "insulin settings are not configured",
+		},
+		{
+			name:                 "service layer: carbs and meal",
+			reqBody:              `{"carbs": 60, "meal": "lunch"}`,
+			expectedError:        insulin.ErrAmbiguousCarbs,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "carbs can be either given or taken from a meal",
+		},
+		{
+			name:                 "service layer: glucose out of range",
+			reqBody:              `{"carbs": 60, "glucose": 900}`,
+			expectedError:        insulin.ErrInvalidGlucose,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "glucose reading out of range",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"carbs": 60}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"carbs": 60}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"carbs": 60`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCalculator := mocks.NewBolusCalculator(t)
+			mockCalculator.On(
+				"CalculateBolusForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.BolusInput"),
+			).Return(mockCalculation, tc.expectedError).Maybe()
+
+			handler := bolus.New(slog.Default(), mockCalculator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/insulin/bolus"+tc.query,
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var calculation models.BolusCalculation
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &calculation)
+				require.NoError(t, err)
+				assert.Equal(t, mockCalculation, calculation)
+			}
+		})
+	}
+}
+
+func TestBolusHandlerInput(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	mockCalculator := mocks.NewBolusCalculator(t)
+	mockCalculator.On("CalculateBolusForCurrentUser", mock.Anything, models.BolusInput{
+		Meal:           models.MealLunch,
+		Day:            time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+		InsulinOnBoard: 0.5,
+	}).Return(mockCalculation, nil).Once()
+
+	handler := bolus.New(slog.Default(), mockCalculator)
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"/insulin/bolus?tz=Europe/Berlin",
+		bytes.NewReader([]byte(`{"meal": "lunch", "date": "2024-04-01", "insulinOnBoard": 0.5}`)),
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
@@ -0,0 +1,120 @@
+package carbs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/carbs"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/carbs/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockSummary models.CarbSummary = models.CarbSummary{
+	Day: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	Meals: []models.MealCarbs{
+		{Meal: models.MealBreakfast, Carbs: 52.5, RecordsCount: 2, UncountedRecords: 1},
+		{Meal: models.MealLunch, Carbs: 70, RecordsCount: 1},
+		{Meal: models.MealDinner},
+		{Meal: models.MealSnack},
+	},
+	Carbs:            122.5,
+	UncountedRecords: 1,
+}
+
+func TestCarbSummaryHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedDay          time.Time
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success with date",
+			query:                "?date=2024-04-01",
+			expectedDay:          time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?date=01.04.2024",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?tz=Mars/Olympus",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "?date=2024-04-01",
+			expectedDay:          time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "?date=2024-04-01",
+			expectedDay:          time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewCarbSummaryProvider(t)
+			mockProvider.On("GetCarbSummaryForCurrentUser", mock.Anything, tc.expectedDay).
+				Return(mockSummary, tc.expectedError).
+				Maybe()
+
+			handler := carbs.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/insulin/carbs"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var summary models.CarbSummary
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &summary)
+				require.NoError(t, err)
+				assert.Equal(t, mockSummary, summary)
+			}
+
+		})
+	}
+}
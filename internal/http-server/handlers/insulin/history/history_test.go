@@ -0,0 +1,113 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/history"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/history/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockCalculations []models.BolusCalculation = []models.BolusCalculation{
+	{
+		Id:               2,
+		AccountId:        1,
+		CreatedAt:        time.Date(2024, 4, 1, 13, 0, 0, 0, time.UTC),
+		Carbs:            70,
+		CarbsSource:      models.CarbsSourceRecords,
+		Meal:             models.MealLunch,
+		RecordIds:        []int64{4, 5},
+		UncountedRecords: 1,
+		Settings: models.InsulinSettings{
+			CarbRatio:        10,
+			CorrectionFactor: 50,
+			TargetGlucose:    110,
+			GlucoseUnit:      models.GlucoseUnitMgDl,
+			DoseIncrement:    0.5,
+		},
+		CarbDose:      7,
+		SuggestedDose: 7,
+		Disclaimer:    models.InsulinDisclaimer,
+	},
+}
+
+func TestBolusHistoryHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockCalculations     []models.BolusCalculation
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockCalculations:     mockCalculations,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockCalculations:     nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockCalculations:     nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewBolusHistoryProvider(t)
+			mockProvider.On("GetBolusHistoryForCurrentUser", mock.Anything).
+				Return(tc.mockCalculations, tc.expectedError).
+				Once()
+
+			handler := history.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/insulin/bolus", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var calculations []models.BolusCalculation
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &calculations)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockCalculations, calculations)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=InsulinSettingsProvider
+type InsulinSettingsProvider interface {
+	GetInsulinSettingsForCurrentUser(ctx context.Context) (models.InsulinSettings, error)
+}
+
+func New(
+	log *slog.Logger,
+	settingsProvider InsulinSettingsProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.insulin.settings.get.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		settings, err := settingsProvider.GetInsulinSettingsForCurrentUser(r.Context())
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, insulin.ErrSettingsNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("insulin settings are not configured"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, settings)
+	}
+}
@@ -0,0 +1,100 @@
+package get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/get"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/get/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockSettings models.InsulinSettings = models.InsulinSettings{
+	CarbRatio:        10,
+	CorrectionFactor: 50,
+	TargetGlucose:    110,
+	GlucoseUnit:      models.GlucoseUnitMgDl,
+	DoseIncrement:    0.5,
+}
+
+func TestGetInsulinSettingsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: settings not found",
+			expectedError:        insulin.ErrSettingsNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "insulin settings are not configured",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewInsulinSettingsProvider(t)
+			mockProvider.On("GetInsulinSettingsForCurrentUser", mock.Anything).
+				Return(mockSettings, tc.expectedError).
+				Once()
+
+			handler := get.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/accounts/me/insulin-settings", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var settings models.InsulinSettings
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &settings)
+				require.NoError(t, err)
+				assert.Equal(t, mockSettings, settings)
+			}
+
+		})
+	}
+}
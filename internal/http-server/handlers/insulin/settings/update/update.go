@@ -0,0 +1,95 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=InsulinSettingsUpdater
+type InsulinSettingsUpdater interface {
+	UpdateInsulinSettingsForCurrentUser(
+		ctx context.Context,
+		settings models.InsulinSettings,
+	) (models.InsulinSettings, error)
+}
+
+// Request with zero dose increment uses insulin.DefaultDoseIncrement.
+type Request struct {
+	CarbRatio        float64 `json:"carbRatio"        validate:"gt=0"`
+	CorrectionFactor float64 `json:"correctionFactor" validate:"gt=0"`
+	TargetGlucose    float64 `json:"targetGlucose"    validate:"gt=0"`
+	GlucoseUnit      string  `json:"glucoseUnit"      validate:"required,oneof=mg/dL mmol/L"`
+	DoseIncrement    float64 `json:"doseIncrement"    validate:"gte=0,lte=1"`
+}
+
+func New(
+	log *slog.Logger,
+	settingsUpdater InsulinSettingsUpdater,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.insulin.settings.update.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		settings, err := settingsUpdater.UpdateInsulinSettingsForCurrentUser(
+			r.Context(),
+			models.InsulinSettings{
+				CarbRatio:        req.CarbRatio,
+				CorrectionFactor: req.CorrectionFactor,
+				TargetGlucose:    req.TargetGlucose,
+				GlucoseUnit:      models.GlucoseUnit(req.GlucoseUnit),
+				DoseIncrement:    req.DoseIncrement,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, insulin.ErrInvalidSettings) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage(err.Error()))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, settings)
+	}
+}
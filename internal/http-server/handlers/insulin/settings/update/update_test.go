@@ -0,0 +1,135 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/update"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/insulin/settings/update/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockSettings models.InsulinSettings = models.InsulinSettings{
+	CarbRatio:        10,
+	CorrectionFactor: 50,
+	TargetGlucose:    110,
+	GlucoseUnit:      models.GlucoseUnitMgDl,
+	DoseIncrement:    0.5,
+}
+
+func TestUpdateInsulinSettingsHandler(t *testing.T) {
+	validBody := `{"carbRatio": 10, "correctionFactor": 50, "targetGlucose": 110, "glucoseUnit": "mg/dL"}`
+
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              validBody,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "unknown glucose unit",
+			reqBody:              `{"carbRatio": 10, "correctionFactor": 50, "targetGlucose": 110, "glucoseUnit": "mg"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "zero carb ratio",
+			reqBody:              `{"correctionFactor": 50, "targetGlucose": 110, "glucoseUnit": "mg/dL"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid settings",
+			reqBody:              `{"carbRatio": 10, "correctionFactor": 50, "targetGlucose": 110, "glucoseUnit": "mmol/L"}`,
+			expectedError:        fmt.Errorf("%w: target glucose", insulin.ErrInvalidSettings),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid insulin settings: target glucose",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              validBody,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              validBody,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"carbRatio": 10`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewInsulinSettingsUpdater(t)
+			mockUpdater.On(
+				"UpdateInsulinSettingsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.InsulinSettings"),
+			).Return(mockSettings, tc.expectedError).Maybe()
+
+			handler := update.New(slog.Default(), mockUpdater)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/accounts/me/insulin-settings",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var settings models.InsulinSettings
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &settings)
+				require.NoError(t, err)
+				assert.Equal(t, mockSettings, settings)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MealPlanGenerator
+type MealPlanGenerator interface {
+	GenerateMealPlanForCurrentUser(
+		ctx context.Context,
+		startDay time.Time,
+		days int,
+	) (models.MealPlan, error)
+}
+
+// Request plans Days days starting at Date
+// (today by default, in the "tz" query param location).
+type Request struct {
+	Date string `json:"date"`
+	Days int    `json:"days" validate:"required,gte=1,lte=7"`
+}
+
+func New(
+	log *slog.Logger,
+	planGenerator MealPlanGenerator,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.mealplans.create.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		day, err := query.ParseDay(req.Date, loc)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		plan, err := planGenerator.GenerateMealPlanForCurrentUser(r.Context(), day, req.Days)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, mealplan.ErrInvalidDays) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid number of plan days"))
+				return
+			}
+
+			if errors.Is(err, mealplan.ErrNoCandidates) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("no foods or recipes to plan with"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, plan)
+	}
+}
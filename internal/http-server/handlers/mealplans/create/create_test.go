@@ -0,0 +1,217 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	foodId   int64 = 2
+	startDay       = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+var mockPlan models.MealPlan = models.MealPlan{
+	Id:           1,
+	AccountId:    1,
+	CreatedAt:    time.Date(2024, 3, 31, 20, 0, 0, 0, time.UTC),
+	StartDay:     startDay,
+	DailyLimit:   2000,
+	MacroTargets: models.MacroTargets{Protein: 150},
+	Days: []models.MealPlanDay{
+		{
+			Day: startDay,
+			Meals: []models.PlannedMeal{
+				{
+					Id:        1,
+					Day:       startDay,
+					Meal:      models.MealLunch,
+					FoodId:    &foodId,
+					Name:      "Chicken breast",
+					Grams:     300,
+					Value:     495,
+					Nutrients: models.Nutrients{models.NutrientProtein: 93},
+				},
+			},
+			Value:     495,
+			Nutrients: models.Nutrients{models.NutrientProtein: 93},
+		},
+	},
+}
+
+func TestCreateMealPlanHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"days": 7}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with date",
+			query:                "?tz=Europe/Berlin",
+			reqBody:              `{"date": "2024-04-01", "days": 1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "missing days",
+			reqBody:              `{"date": "2024-04-01"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "too many days",
+			reqBody:              `{"days": 8}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid date",
+			reqBody:              `{"date": "tomorrow", "days": 1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?tz=Mars/Olympus",
+			reqBody:              `{"days": 1}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: no candidates",
+			reqBody:              `{"days": 1}`,
+			expectedError:        mealplan.ErrNoCandidates,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "no foods or recipes to plan with",
+		},
+		{
+			name:                 "service layer: invalid days",
+			reqBody:              `{"days": 1}`,
+			expectedError:        mealplan.ErrInvalidDays,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid number of plan days",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"days": 1}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"days": 1}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"days": 1`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockGenerator := mocks.NewMealPlanGenerator(t)
+			mockGenerator.On(
+				"GenerateMealPlanForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("int"),
+			).Return(mockPlan, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockGenerator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/meal-plans"+tc.query,
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var plan models.MealPlan
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &plan)
+				require.NoError(t, err)
+				assert.Equal(t, mockPlan, plan)
+			}
+		})
+	}
+}
+
+func TestCreateMealPlanHandlerInput(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	mockGenerator := mocks.NewMealPlanGenerator(t)
+	mockGenerator.On(
+		"GenerateMealPlanForCurrentUser",
+		mock.Anything,
+		time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+		3,
+	).Return(mockPlan, nil).Once()
+
+	handler := create.New(slog.Default(), mockGenerator)
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"/meal-plans?tz=Europe/Berlin",
+		bytes.NewReader([]byte(`{"date": "2024-04-01", "days": 3}`)),
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
@@ -0,0 +1,111 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var startDay = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+var mockPlans []models.MealPlan = []models.MealPlan{
+	{
+		Id:         1,
+		AccountId:  1,
+		CreatedAt:  time.Date(2024, 3, 31, 20, 0, 0, 0, time.UTC),
+		StartDay:   startDay,
+		DailyLimit: 2000,
+		Days: []models.MealPlanDay{
+			{
+				Day: startDay,
+				Meals: []models.PlannedMeal{
+					{Id: 1, Day: startDay, Meal: models.MealSnack, Name: "Rice", Grams: 175, Value: 228},
+				},
+				Value: 228,
+			},
+		},
+	},
+}
+
+func TestMealPlansListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockPlans            []models.MealPlan
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockPlans:            mockPlans,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockPlans:            nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockPlans:            nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewMealPlansProvider(t)
+			mockProvider.On("GetMealPlansForCurrentUser", mock.Anything).
+				Return(tc.mockPlans, tc.expectedError).
+				Once()
+
+			handler := list.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/meal-plans", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var plans []models.MealPlan
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &plans)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockPlans, plans)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=PlannedMealLogger
+type PlannedMealLogger interface {
+	LogPlannedMealForCurrentUser(
+		ctx context.Context,
+		planId int64,
+		mealId int64,
+		loc *time.Location,
+	) (models.Record, []models.RestrictionWarning, error)
+}
+
+type PathParams struct {
+	PlanId int64 `validate:"required,gte=1"`
+	MealId int64 `validate:"required,gte=1"`
+}
+
+type Response struct {
+	models.Record
+	Warnings []models.RestrictionWarning `json:"warnings,omitempty"`
+}
+
+// New logs a planned meal as a record dated the planned day
+// in the "tz" query param location.
+func New(
+	log *slog.Logger,
+	mealLogger PlannedMealLogger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.mealplans.log.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		planId, err := strconv.ParseInt(chi.URLParam(r, "planId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid plan id"))
+			return
+		}
+
+		mealId, err := strconv.ParseInt(chi.URLParam(r, "mealId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid meal id"))
+			return
+		}
+
+		pathParams := PathParams{PlanId: planId, MealId: mealId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		created, warnings, err := mealLogger.LogPlannedMealForCurrentUser(
+			r.Context(),
+			pathParams.PlanId,
+			pathParams.MealId,
+			loc,
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, mealplan.ErrMealPlanNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("meal plan not found"))
+				return
+			}
+
+			if errors.Is(err, mealplan.ErrPlannedMealNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("planned meal not found"))
+				return
+			}
+
+			if errors.Is(err, mealplan.ErrPlannedMealLogged) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("planned meal is already logged"))
+				return
+			}
+
+			if errors.Is(err, record.ErrRestricted) {
+				errResponse := response.ErrorMessage("record conflicts with account restrictions")
+				errResponse.Errors = warnings
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, errResponse)
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{Record: created, Warnings: warnings})
+	}
+}
@@ -0,0 +1,188 @@
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	logHandler "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/log"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/mealplans/log/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var foodId int64 = 2
+
+var mockRecord models.Record = models.Record{
+	Id:          10,
+	AccountId:   1,
+	DateRecord:  time.Date(2024, 4, 1, 19, 0, 0, 0, time.UTC),
+	Value:       495,
+	Meal:        models.MealDinner,
+	Description: "Chicken breast",
+	FoodId:      &foodId,
+	Grams:       300,
+}
+
+var warnings = []models.RestrictionWarning{
+	{Allergen: models.AllergenPeanuts, Restriction: "allergy"},
+}
+
+func TestLogPlannedMealHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		mockWarnings         []models.RestrictionWarning
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with warnings",
+			path:                 "/meal-plans/1/meals/5/log?tz=Europe/Berlin",
+			mockWarnings:         warnings,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect plan id",
+			path:                 "/meal-plans/first/meals/5/log",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid plan id",
+		},
+		{
+			name:                 "incorrect meal id",
+			path:                 "/meal-plans/1/meals/lunch/log",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid meal id",
+		},
+		{
+			name:                 "invalid meal id",
+			path:                 "/meal-plans/1/meals/0/log",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid timezone",
+			path:                 "/meal-plans/1/meals/5/log?tz=Mars/Olympus",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: plan not found",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        mealplan.ErrMealPlanNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "meal plan not found",
+		},
+		{
+			name:                 "service layer: meal not found",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        mealplan.ErrPlannedMealNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "planned meal not found",
+		},
+		{
+			name:                 "service layer: meal logged",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        mealplan.ErrPlannedMealLogged,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "planned meal is already logged",
+		},
+		{
+			name:                 "service layer: restricted",
+			path:                 "/meal-plans/1/meals/5/log",
+			mockWarnings:         warnings,
+			expectedError:        record.ErrRestricted,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "record conflicts with account restrictions",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			path:                 "/meal-plans/1/meals/5/log",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockLogger := mocks.NewPlannedMealLogger(t)
+			mockLogger.On(
+				"LogPlannedMealForCurrentUser",
+				mock.Anything,
+				int64(1),
+				int64(5),
+				mock.AnythingOfType("*time.Location"),
+			).Return(mockRecord, tc.mockWarnings, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Post("/meal-plans/{planId}/meals/{mealId}/log", logHandler.New(slog.Default(), mockLogger))
+
+			req, err := http.NewRequest(http.MethodPost, tc.path, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			var body struct {
+				Message  string                      `json:"message"`
+				Errors   []models.RestrictionWarning `json:"errors"`
+				Warnings []models.RestrictionWarning `json:"warnings"`
+				Id       int64                       `json:"id"`
+			}
+			err = json.Unmarshal(responseRecorder.Body.Bytes(), &body)
+			require.NoError(t, err)
+
+			if tc.expectedErrorMessage != "" {
+				assert.Equal(t, tc.expectedErrorMessage, body.Message)
+				if tc.expectedError == record.ErrRestricted {
+					assert.Equal(t, warnings, body.Errors)
+				}
+			} else {
+				assert.Equal(t, mockRecord.Id, body.Id)
+				assert.Equal(t, tc.mockWarnings, body.Warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MeasurementCreator
+type MeasurementCreator interface {
+	CreateMeasurementForCurrentUser(
+		ctx context.Context,
+		measurement models.Measurement,
+	) (models.Measurement, error)
+}
+
+// Request Type is a predefined type (waist, hips, chest, neck, arm, thigh,
+// height in cm or in, body_fat in %) or a custom one taking any unit.
+type Request struct {
+	Type       string    `json:"type"       validate:"required,max=32"`
+	Value      float64   `json:"value"      validate:"required,gt=0"`
+	Unit       string    `json:"unit"       validate:"required,oneof=cm in kg lb %"`
+	DateRecord time.Time `json:"dateRecord" validate:"required"`
+}
+
+func New(
+	log *slog.Logger,
+	measurementCreator MeasurementCreator,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.measurements.create.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		created, err := measurementCreator.CreateMeasurementForCurrentUser(r.Context(), models.Measurement{
+			Type:       models.MeasurementType(req.Type),
+			Value:      req.Value,
+			Unit:       models.MeasurementUnit(req.Unit),
+			DateRecord: req.DateRecord,
+		})
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrInvalidType) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid measurement type"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrInvalidUnit) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid unit for measurement type"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrInvalidValue) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid measurement value"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, created)
+	}
+}
@@ -0,0 +1,191 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockMeasurement = models.Measurement{
+	Id:          1,
+	AccountId:   1,
+	Type:        models.MeasurementBodyFat,
+	Value:       22.5,
+	Unit:        models.MeasurementUnitPercent,
+	DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+	DateCreated: time.Date(2024, 4, 19, 8, 5, 0, 0, time.UTC),
+}
+
+func TestCreateMeasurementHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"type": "body_fat", "value": 22.5, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty type",
+			reqBody:              `{"type": "", "value": 22.5, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "negative value",
+			reqBody:              `{"type": "waist", "value": -1, "unit": "cm", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown unit",
+			reqBody:              `{"type": "waist", "value": 3, "unit": "ft", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "empty date record",
+			reqBody:              `{"type": "waist", "value": 90, "unit": "cm"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid type",
+			reqBody:              `{"type": "upper arm", "value": 30, "unit": "cm", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        measurement.ErrInvalidType,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid measurement type",
+		},
+		{
+			name:                 "service layer: invalid unit",
+			reqBody:              `{"type": "waist", "value": 30, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        measurement.ErrInvalidUnit,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid unit for measurement type",
+		},
+		{
+			name:                 "service layer: invalid value",
+			reqBody:              `{"type": "body_fat", "value": 120, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        measurement.ErrInvalidValue,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid measurement value",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"type": "body_fat", "value": 22.5, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"type": "body_fat", "value": 22.5, "unit": "%", "dateRecord": "2024-04-19T08:00:00Z"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"type": "body_fat"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewMeasurementCreator(t)
+			mockCreator.On(
+				"CreateMeasurementForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.Measurement"),
+			).Return(mockMeasurement, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/measurements",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var created models.Measurement
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &created)
+				require.NoError(t, err)
+				assert.Equal(t, mockMeasurement, created)
+			}
+		})
+	}
+}
+
+func TestCreateMeasurementHandlerInput(t *testing.T) {
+	mockCreator := mocks.NewMeasurementCreator(t)
+	mockCreator.On(
+		"CreateMeasurementForCurrentUser",
+		mock.Anything,
+		models.Measurement{
+			Type:       "Waist",
+			Value:      34.5,
+			Unit:       models.MeasurementUnitIn,
+			DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+		},
+	).Return(mockMeasurement, nil).Once()
+
+	handler := create.New(slog.Default(), mockCreator)
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"/measurements",
+		bytes.NewReader([]byte(`{"type": "Waist", "value": 34.5, "unit": "in", "dateRecord": "2024-04-19T08:00:00Z"}`)),
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
@@ -0,0 +1,80 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MeasurementRemover
+type MeasurementRemover interface {
+	DeleteMeasurementForCurrentUser(ctx context.Context, measurementId int64) error
+}
+
+type PathParams struct {
+	MeasurementId int64 `validate:"required,gte=1"`
+}
+
+func New(
+	log *slog.Logger,
+	measurementRemover MeasurementRemover,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.measurements.delete.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		measurementId, err := strconv.ParseInt(chi.URLParam(r, "measurementId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid measurement id"))
+			return
+		}
+
+		pathParams := PathParams{MeasurementId: measurementId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		err = measurementRemover.DeleteMeasurementForCurrentUser(r.Context(), pathParams.MeasurementId)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrMeasurementNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("measurement not found"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusNoContent)
+		render.JSON(w, r, nil)
+	}
+}
@@ -0,0 +1,111 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	deleteHandler "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/delete"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/delete/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestDeleteMeasurementHandler(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		measurementIdPathParam string
+		expectedError          error
+		expectedStatusCode     int
+		expectedErrorMessage   string
+	}{
+		{
+			name:                   "success",
+			measurementIdPathParam: "3",
+			expectedError:          nil,
+			expectedStatusCode:     http.StatusNoContent,
+			expectedErrorMessage:   "",
+		},
+		{
+			name:                   "incorrect measurementId param",
+			measurementIdPathParam: "invalid",
+			expectedError:          nil,
+			expectedStatusCode:     http.StatusBadRequest,
+			expectedErrorMessage:   "invalid measurement id",
+		},
+		{
+			name:                   "invalid measurementId param",
+			measurementIdPathParam: "-3",
+			expectedError:          nil,
+			expectedStatusCode:     http.StatusBadRequest,
+			expectedErrorMessage:   "validation failed",
+		},
+		{
+			name:                   "service layer: measurement not found",
+			measurementIdPathParam: "3",
+			expectedError:          measurement.ErrMeasurementNotFound,
+			expectedStatusCode:     http.StatusNotFound,
+			expectedErrorMessage:   "measurement not found",
+		},
+		{
+			name:                   "service layer: invalid jwt",
+			measurementIdPathParam: "3",
+			expectedError:          account.ErrInvalidJWT,
+			expectedStatusCode:     http.StatusUnauthorized,
+			expectedErrorMessage:   "invalid credentials",
+		},
+		{
+			name:                   "unexpected service error",
+			measurementIdPathParam: "3",
+			expectedError:          errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:     http.StatusInternalServerError,
+			expectedErrorMessage:   "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockRemover := mocks.NewMeasurementRemover(t)
+			mockRemover.On(
+				"DeleteMeasurementForCurrentUser",
+				mock.Anything,
+				int64(3),
+			).Return(tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Delete("/measurements/{measurementId}", deleteHandler.New(slog.Default(), mockRemover))
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/measurements/%s", tc.measurementIdPathParam), nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MeasurementProvider
+type MeasurementProvider interface {
+	GetMeasurementsForCurrentUser(
+		ctx context.Context,
+		measurementType string,
+	) ([]models.Measurement, error)
+}
+
+func New(
+	log *slog.Logger,
+	measurementProvider MeasurementProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.measurements.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		measurements, err := measurementProvider.GetMeasurementsForCurrentUser(
+			r.Context(),
+			r.URL.Query().Get("type"),
+		)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrInvalidType) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid measurement type"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, measurements)
+	}
+}
@@ -0,0 +1,127 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockMeasurements []models.Measurement = []models.Measurement{
+	{
+		Id:          1,
+		AccountId:   1,
+		Type:        models.MeasurementWaist,
+		Value:       88.5,
+		Unit:        models.MeasurementUnitCm,
+		DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+		DateCreated: time.Date(2024, 4, 19, 8, 5, 0, 0, time.UTC),
+	},
+}
+
+func TestMeasurementsListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedType         string
+		mockMeasurements     []models.Measurement
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "",
+			expectedType:         "",
+			mockMeasurements:     mockMeasurements,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with type",
+			query:                "?type=waist",
+			expectedType:         "waist",
+			mockMeasurements:     mockMeasurements,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid type",
+			query:                "?type=upper%20arm",
+			expectedType:         "upper arm",
+			mockMeasurements:     nil,
+			expectedError:        measurement.ErrInvalidType,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid measurement type",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedType:         "",
+			mockMeasurements:     nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedType:         "",
+			mockMeasurements:     nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewMeasurementProvider(t)
+			mockProvider.On("GetMeasurementsForCurrentUser", mock.Anything, tc.expectedType).
+				Return(tc.mockMeasurements, tc.expectedError).
+				Once()
+
+			handler := list.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/measurements"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var measurements []models.Measurement
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &measurements)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockMeasurements, measurements)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package summary_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/summary"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/summary/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	waistToHeight = 0.5
+	leanMass      = 60.0
+	fatMass       = 20.0
+
+	mockSummary = models.MeasurementSummary{
+		Latest: []models.Measurement{
+			{
+				Id:          2,
+				AccountId:   1,
+				Type:        models.MeasurementBodyFat,
+				Value:       25,
+				Unit:        models.MeasurementUnitPercent,
+				DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+				DateCreated: time.Date(2024, 4, 19, 8, 5, 0, 0, time.UTC),
+			},
+		},
+		Derived: models.DerivedMetrics{
+			WaistToHeight: &waistToHeight,
+			LeanMass:      &leanMass,
+			FatMass:       &fatMass,
+		},
+	}
+)
+
+func TestMeasurementSummaryHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewSummaryProvider(t)
+			mockProvider.On("GetMeasurementSummaryForCurrentUser", mock.Anything).
+				Return(mockSummary, tc.expectedError).
+				Once()
+
+			handler := summary.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/measurements/summary", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var result models.MeasurementSummary
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.Equal(t, mockSummary, result)
+			}
+
+		})
+	}
+}
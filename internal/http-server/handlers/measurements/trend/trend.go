@@ -0,0 +1,82 @@
+package trend
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=TrendProvider
+type TrendProvider interface {
+	GetMeasurementTrendForCurrentUser(
+		ctx context.Context,
+		measurementType string,
+		from time.Time,
+		to time.Time,
+	) (models.MeasurementSeries, error)
+}
+
+const (
+	defaultRangeDays = 90
+	maxRangeDays     = 731
+)
+
+func New(
+	log *slog.Logger,
+	trendProvider TrendProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.measurements.trend.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		from, to, err := query.DateRange(r, time.UTC, defaultRangeDays, maxRangeDays)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		series, err := trendProvider.GetMeasurementTrendForCurrentUser(
+			r.Context(),
+			r.URL.Query().Get("type"),
+			from,
+			to,
+		)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, measurement.ErrInvalidType) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid measurement type"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, series)
+	}
+}
@@ -0,0 +1,155 @@
+package trend_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/trend"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/measurements/trend/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	change     = -1.4
+	weeklyRate = -1.4
+
+	mockSeries = models.MeasurementSeries{
+		Type: models.MeasurementWaist,
+		Unit: models.MeasurementUnitIn,
+		From: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		Points: []models.MeasurementPoint{
+			{Day: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), Value: 37.4},
+			{Day: time.Date(2024, 4, 9, 0, 0, 0, 0, time.UTC), Value: 36},
+		},
+		Change:     &change,
+		WeeklyRate: &weeklyRate,
+	}
+)
+
+func TestMeasurementTrendHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "?type=waist&from=2024-04-02&to=2024-04-15",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?type=waist&to=15.04.2024",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "invalid range",
+			query:                "?type=waist&from=2024-04-20&to=2024-04-19",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "service layer: missing type",
+			query:                "",
+			expectedError:        measurement.ErrInvalidType,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid measurement type",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "?type=waist",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "?type=waist",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewTrendProvider(t)
+			mockProvider.On(
+				"GetMeasurementTrendForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockSeries, tc.expectedError).Maybe()
+
+			handler := trend.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/measurements/trend"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var series models.MeasurementSeries
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &series)
+				require.NoError(t, err)
+				assert.Equal(t, mockSeries, series)
+			}
+
+		})
+	}
+}
+
+func TestMeasurementTrendHandlerInput(t *testing.T) {
+	mockProvider := mocks.NewTrendProvider(t)
+	mockProvider.On(
+		"GetMeasurementTrendForCurrentUser",
+		mock.Anything,
+		"body_fat",
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	).Return(mockSeries, nil).Once()
+
+	handler := trend.New(slog.Default(), mockProvider)
+
+	req, err := http.NewRequest(http.MethodGet, "/measurements/trend?type=body_fat&to=2024-03-31", nil)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
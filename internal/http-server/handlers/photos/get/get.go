@@ -0,0 +1,118 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=PhotoOpener
+type PhotoOpener interface {
+	OpenPhotoForCurrentUser(
+		ctx context.Context,
+		recordId int64,
+		photoId int64,
+		thumbnail bool,
+	) (string, io.ReadCloser, error)
+}
+
+type PathParams struct {
+	RecordId int64 `validate:"required,gte=1"`
+	PhotoId  int64 `validate:"required,gte=1"`
+}
+
+// New serves the photo file, or its JPEG thumbnail with "size=thumbnail".
+func New(
+	log *slog.Logger,
+	photoOpener PhotoOpener,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.photos.get.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		recordId, err := strconv.ParseInt(chi.URLParam(r, "recordId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid record id"))
+			return
+		}
+
+		photoId, err := strconv.ParseInt(chi.URLParam(r, "photoId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid photo id"))
+			return
+		}
+
+		pathParams := PathParams{RecordId: recordId, PhotoId: photoId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		var thumbnail bool
+
+		switch r.URL.Query().Get("size") {
+		case "", "original":
+		case "thumbnail":
+			thumbnail = true
+		default:
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid size (original or thumbnail expected)"))
+			return
+		}
+
+		contentType, content, err := photoOpener.OpenPhotoForCurrentUser(
+			r.Context(),
+			pathParams.RecordId,
+			pathParams.PhotoId,
+			thumbnail,
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrPhotoNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("photo not found"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "private, max-age=86400")
+
+		if _, err := io.Copy(w, content); err != nil {
+			log.Error("failed to write photo", slog.String("err", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/get"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/get/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+const photoContent = "\x89PNG photo content"
+
+func TestGetPhotoHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		thumbnail            bool
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			path:                 "/records/7/photos/3",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success original",
+			path:                 "/records/7/photos/3?size=original",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success thumbnail",
+			path:                 "/records/7/photos/3?size=thumbnail",
+			thumbnail:            true,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid size",
+			path:                 "/records/7/photos/3?size=large",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid size (original or thumbnail expected)",
+		},
+		{
+			name:                 "invalid record id",
+			path:                 "/records/abc/photos/3",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "invalid photo id",
+			path:                 "/records/7/photos/abc",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid photo id",
+		},
+		{
+			name:                 "zero photo id",
+			path:                 "/records/7/photos/0",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: photo not found",
+			path:                 "/records/7/photos/3",
+			expectedError:        photo.ErrPhotoNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "photo not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			path:                 "/records/7/photos/3",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			path:                 "/records/7/photos/3",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var content io.ReadCloser
+			if tc.expectedError == nil {
+				content = io.NopCloser(strings.NewReader(photoContent))
+			}
+
+			mockOpener := mocks.NewPhotoOpener(t)
+			mockOpener.On(
+				"OpenPhotoForCurrentUser",
+				mock.Anything,
+				int64(7),
+				int64(3),
+				tc.thumbnail,
+			).Return("image/png", content, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Get("/records/{recordId}/photos/{photoId}", get.New(slog.Default(), mockOpener))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, "image/png", responseRecorder.Header().Get("Content-Type"))
+				assert.Equal(t, "nosniff", responseRecorder.Header().Get("X-Content-Type-Options"))
+				assert.Equal(t, photoContent, responseRecorder.Body.String())
+			}
+		})
+	}
+}
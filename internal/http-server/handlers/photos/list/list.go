@@ -0,0 +1,83 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=PhotosProvider
+type PhotosProvider interface {
+	GetPhotosForCurrentUser(
+		ctx context.Context,
+		recordId int64,
+	) ([]models.RecordPhoto, error)
+}
+
+type PathParams struct {
+	RecordId int64 `validate:"required,gte=1"`
+}
+
+func New(
+	log *slog.Logger,
+	photosProvider PhotosProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.photos.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		recordId, err := strconv.ParseInt(chi.URLParam(r, "recordId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid record id"))
+			return
+		}
+
+		pathParams := PathParams{RecordId: recordId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		photos, err := photosProvider.GetPhotosForCurrentUser(r.Context(), pathParams.RecordId)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrRecordNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("record not found"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, photos)
+	}
+}
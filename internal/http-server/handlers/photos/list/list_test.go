@@ -0,0 +1,130 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockPhotos = []models.RecordPhoto{
+	{
+		Id:          3,
+		AccountId:   1,
+		RecordId:    7,
+		ContentType: "image/jpeg",
+		Size:        4096,
+		Width:       1024,
+		Height:      768,
+		CreatedAt:   time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestPhotosListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			path:                 "/records/7/photos",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid record id",
+			path:                 "/records/abc/photos",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "negative record id",
+			path:                 "/records/-7/photos",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: record not found",
+			path:                 "/records/7/photos",
+			expectedError:        photo.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			path:                 "/records/7/photos",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			path:                 "/records/7/photos",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewPhotosProvider(t)
+			mockProvider.On(
+				"GetPhotosForCurrentUser",
+				mock.Anything,
+				int64(7),
+			).Return(mockPhotos, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Get("/records/{recordId}/photos", list.New(slog.Default(), mockProvider))
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var photos []models.RecordPhoto
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &photos)
+				require.NoError(t, err)
+				assert.Equal(t, mockPhotos, photos)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=PhotoUploader
+type PhotoUploader interface {
+	UploadPhotoForCurrentUser(
+		ctx context.Context,
+		recordId int64,
+		r io.Reader,
+	) (models.RecordPhoto, error)
+}
+
+const (
+	// formField is the multipart field holding the photo file
+	formField = "photo"
+	// maxBodySize leaves room for multipart headers on top of the photo
+	maxBodySize = photo.MaxSize + 1<<20
+)
+
+type PathParams struct {
+	RecordId int64 `validate:"required,gte=1"`
+}
+
+// New attaches a photo uploaded as multipart/form-data "photo" field
+// to the record, the content type is sniffed from the file itself.
+func New(
+	log *slog.Logger,
+	photoUploader PhotoUploader,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.photos.upload.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		recordId, err := strconv.ParseInt(chi.URLParam(r, "recordId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid record id"))
+			return
+		}
+
+		pathParams := PathParams{RecordId: recordId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+		file, _, err := r.FormFile(formField)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				render.Status(r, http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, response.ErrorMessage("photo is too large"))
+				return
+			}
+
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("photo file is required"))
+			return
+		}
+		defer file.Close()
+
+		uploaded, err := photoUploader.UploadPhotoForCurrentUser(r.Context(), pathParams.RecordId, file)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrRecordNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("record not found"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrPhotoTooLarge) {
+				render.Status(r, http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, response.ErrorMessage("photo is too large"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrUnsupportedType) {
+				render.Status(r, http.StatusUnsupportedMediaType)
+				render.JSON(w, r, response.ErrorMessage("unsupported photo type (JPEG, PNG or GIF expected)"))
+				return
+			}
+
+			if errors.Is(err, photo.ErrInvalidPhoto) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid photo"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, uploaded)
+	}
+}
@@ -0,0 +1,217 @@
+package upload_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/upload"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/photos/upload/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockPhoto models.RecordPhoto = models.RecordPhoto{
+	Id:          3,
+	AccountId:   1,
+	RecordId:    7,
+	ContentType: "image/png",
+	Size:        2048,
+	Width:       800,
+	Height:      600,
+	CreatedAt:   time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// multipartBody returns a form with content in the field, no field for nil content.
+func multipartBody(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	if content != nil {
+		part, err := writer.CreateFormFile(field, "photo.png")
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return &body, writer.FormDataContentType()
+}
+
+func TestUploadPhotoHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		field                string
+		content              []byte
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid record id",
+			path:                 "/records/abc/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "zero record id",
+			path:                 "/records/0/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "missing photo field",
+			path:                 "/records/7/photos",
+			field:                "file",
+			content:              []byte("png bytes"),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "photo file is required",
+		},
+		{
+			name:                 "empty form",
+			path:                 "/records/7/photos",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "photo file is required",
+		},
+		{
+			name:                 "too large body",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              make([]byte, photo.MaxSize+2<<20),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedErrorMessage: "photo is too large",
+		},
+		{
+			name:                 "service layer: record not found",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        photo.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: too large photo",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        photo.ErrPhotoTooLarge,
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedErrorMessage: "photo is too large",
+		},
+		{
+			name:                 "service layer: unsupported type",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        photo.ErrUnsupportedType,
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedErrorMessage: "unsupported photo type (JPEG, PNG or GIF expected)",
+		},
+		{
+			name:                 "service layer: invalid photo",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        photo.ErrInvalidPhoto,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid photo",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			path:                 "/records/7/photos",
+			field:                "photo",
+			content:              []byte("png bytes"),
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUploader := mocks.NewPhotoUploader(t)
+			mockUploader.On(
+				"UploadPhotoForCurrentUser",
+				mock.Anything,
+				int64(7),
+				mock.Anything,
+			).Return(mockPhoto, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Post("/records/{recordId}/photos", upload.New(slog.Default(), mockUploader))
+
+			body, contentType := multipartBody(t, tc.field, tc.content)
+
+			req, err := http.NewRequest(http.MethodPost, tc.path, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var uploaded models.RecordPhoto
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &uploaded)
+				require.NoError(t, err)
+				assert.Equal(t, mockPhoto, uploaded)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/recipe"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecipeCreator
+type RecipeCreator interface {
+	CreateRecipeForCurrentUser(ctx context.Context, recipe models.Recipe) (models.Recipe, error)
+}
+
+type Ingredient struct {
+	FoodId int64   `json:"foodId" validate:"gt=0"`
+	Grams  float64 `json:"grams"  validate:"gt=0"`
+}
+
+type Request struct {
+	Name        string            `json:"name"        validate:"required,max=255"`
+	Servings    int               `json:"servings"    validate:"required,gte=1"`
+	Ingredients []Ingredient      `json:"ingredients" validate:"required,min=1,dive"`
+	Allergens   []models.Allergen `json:"allergens"`
+	Favorite    bool              `json:"favorite"`
+}
+
+func New(
+	log *slog.Logger,
+	recipeCreator RecipeCreator,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.recipes.create.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		ingredients := make([]models.RecipeIngredient, 0, len(req.Ingredients))
+		for _, ingredient := range req.Ingredients {
+			ingredients = append(ingredients, models.RecipeIngredient{
+				FoodId: ingredient.FoodId,
+				Grams:  ingredient.Grams,
+			})
+		}
+
+		created, err := recipeCreator.CreateRecipeForCurrentUser(r.Context(), models.Recipe{
+			Name:        req.Name,
+			Servings:    req.Servings,
+			Ingredients: ingredients,
+			Allergens:   req.Allergens,
+			Favorite:    req.Favorite,
+		})
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, recipe.ErrFoodNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("food not found"))
+				return
+			}
+
+			if errors.Is(err, restriction.ErrInvalidAllergens) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid allergens"))
+				return
+			}
+
+			if errors.Is(err, recipe.ErrRecipeExists) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("recipe already exists"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, created)
+	}
+}
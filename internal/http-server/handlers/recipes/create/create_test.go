@@ -0,0 +1,160 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/recipe"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecipe models.Recipe = models.Recipe{
+	Id:        1,
+	AccountId: 1,
+	Name:      "Porridge",
+	Servings:  2,
+	Ingredients: []models.RecipeIngredient{
+		{FoodId: 1, Name: "Oatmeal", Grams: 80},
+		{FoodId: 2, Name: "Milk", Grams: 300},
+	},
+	Value:     298,
+	Nutrients: models.Nutrients{models.NutrientCalcium: 180},
+	Allergens: []models.Allergen{models.AllergenGluten, models.AllergenMilk},
+}
+
+func TestCreateRecipeHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1, "grams": 80}, {"foodId": 2, "grams": 300}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "no ingredients",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": []}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "ingredient without grams",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "zero servings",
+			reqBody:              `{"name": "Porridge", "servings": 0, "ingredients": [{"foodId": 1, "grams": 80}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: food not found",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 9, "grams": 80}]}`,
+			expectedError:        recipe.ErrFoodNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "food not found",
+		},
+		{
+			name:                 "service layer: invalid allergens",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1, "grams": 80}], "allergens": ["cocoa"]}`,
+			expectedError:        restriction.ErrInvalidAllergens,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid allergens",
+		},
+		{
+			name:                 "service layer: recipe exists",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1, "grams": 80}]}`,
+			expectedError:        recipe.ErrRecipeExists,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "recipe already exists",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1, "grams": 80}]}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"name": "Porridge", "servings": 2, "ingredients": [{"foodId": 1, "grams": 80}]}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"name": "Porridge"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewRecipeCreator(t)
+			mockCreator.On(
+				"CreateRecipeForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.Recipe"),
+			).Return(mockRecipe, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/recipes",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var created models.Recipe
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &created)
+				require.NoError(t, err)
+				assert.Equal(t, mockRecipe, created)
+			}
+		})
+	}
+}
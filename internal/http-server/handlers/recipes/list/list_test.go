@@ -0,0 +1,104 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/recipes/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecipes []models.Recipe = []models.Recipe{
+	{
+		Id:        1,
+		AccountId: 1,
+		Name:      "Porridge",
+		Servings:  2,
+		Ingredients: []models.RecipeIngredient{
+			{FoodId: 1, Name: "Oatmeal", Grams: 80},
+		},
+		Value:     148,
+		Nutrients: models.Nutrients{models.NutrientFiber: 4},
+		Allergens: []models.Allergen{models.AllergenGluten},
+	},
+}
+
+func TestRecipesListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockRecipes          []models.Recipe
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockRecipes:          mockRecipes,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockRecipes:          nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockRecipes:          nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewRecipeProvider(t)
+			mockProvider.On("GetRecipesForCurrentUser", mock.Anything).
+				Return(tc.mockRecipes, tc.expectedError).
+				Once()
+
+			handler := list.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/recipes", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var recipes []models.Recipe
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &recipes)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockRecipes, recipes)
+			}
+
+		})
+	}
+}
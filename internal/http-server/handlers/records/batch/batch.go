@@ -0,0 +1,228 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=BatchApplier
+type BatchApplier interface {
+	ApplyBatchForCurrentUser(
+		ctx context.Context,
+		operations []models.RecordOperation,
+	) ([]models.RecordOperationResult, error)
+}
+
+// Request operations are applied in order, all or none of them.
+type Request struct {
+	Operations []Operation `json:"operations" validate:"required,min=1,max=100,dive"`
+}
+
+// Operation creates a record from Record, replaces value, meal, description
+// and date of the RecordId record of the Version, or deletes it.
+type Operation struct {
+	Op       string  `json:"op"       validate:"required,oneof=create update delete"`
+	RecordId int64   `json:"recordId" validate:"gte=0"`
+	Version  int64   `json:"version"  validate:"gte=0"`
+	Record   *Record `json:"record"`
+}
+
+// Record fields are those of POST /records, value is in the account energy unit.
+type Record struct {
+	Value       int              `json:"value"       validate:"required_without_all=FoodId RecipeId,omitempty,gte=1"`
+	DateRecord  time.Time        `json:"dateRecord"  validate:"required"`
+	Meal        string           `json:"meal"        validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Description string           `json:"description" validate:"max=255"`
+	FoodId      *int64           `json:"foodId"      validate:"omitempty,gt=0"`
+	Grams       float64          `json:"grams"       validate:"required_with=FoodId,omitempty,gt=0"`
+	RecipeId    *int64           `json:"recipeId"    validate:"omitempty,gt=0"`
+	Servings    float64          `json:"servings"    validate:"required_with=RecipeId,omitempty,gt=0"`
+	Nutrients   models.Nutrients `json:"nutrients"`
+	Tags        []string         `json:"tags"        validate:"max=10,dive,required,max=32"`
+}
+
+type Response struct {
+	Results []models.RecordOperationResult `json:"results"`
+}
+
+// OperationFailure is the operation that rolled the batch back, Status is
+// what the single operation endpoint would respond with.
+type OperationFailure struct {
+	Index    int                         `json:"index"`
+	Status   int                         `json:"status"`
+	Message  string                      `json:"message"`
+	Warnings []models.RestrictionWarning `json:"warnings,omitempty"`
+}
+
+func New(
+	log *slog.Logger,
+	batchApplier BatchApplier,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.batch.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		operations := make([]models.RecordOperation, len(req.Operations))
+
+		for i, o := range req.Operations {
+			if message := check(o); message != "" {
+				log.Info("invalid request", slog.Int("operation", i), slog.String("err", message))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage(fmt.Sprintf("operation %d: %s", i, message)))
+				return
+			}
+
+			operations[i] = models.RecordOperation{
+				Type:     models.RecordOperationType(o.Op),
+				RecordId: o.RecordId,
+				Version:  o.Version,
+			}
+
+			if o.Record != nil {
+				operations[i].Record = models.Record{
+					Value:       o.Record.Value,
+					Meal:        models.Meal(o.Record.Meal),
+					Description: o.Record.Description,
+					FoodId:      o.Record.FoodId,
+					Grams:       o.Record.Grams,
+					RecipeId:    o.Record.RecipeId,
+					Servings:    o.Record.Servings,
+					Nutrients:   o.Record.Nutrients,
+					Tags:        o.Record.Tags,
+					DateRecord:  o.Record.DateRecord,
+				}
+			}
+		}
+
+		results, err := batchApplier.ApplyBatchForCurrentUser(r.Context(), operations)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, record.ErrInvalidBatch) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage(record.ErrInvalidBatch.Error()))
+				return
+			}
+
+			// Unexpected operation errors are left to the 500 below, so
+			// retries with the same Idempotency-Key apply the batch again
+			var operationErr *record.OperationError
+			if errors.As(err, &operationErr) {
+				if status, message := failure(operationErr.Err); status != http.StatusInternalServerError {
+					errResponse := response.ErrorMessage("batch operation failed")
+					errResponse.Errors = []OperationFailure{{
+						Index:    operationErr.Index,
+						Status:   status,
+						Message:  message,
+						Warnings: operationErr.Warnings,
+					}}
+					render.Status(r, http.StatusUnprocessableEntity)
+					render.JSON(w, r, errResponse)
+					return
+				}
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, Response{Results: results})
+	}
+}
+
+// check reports fields an operation of the type misses, update and delete
+// require the version as If-Match does for single records.
+func check(o Operation) string {
+	switch models.RecordOperationType(o.Op) {
+	case models.RecordOperationCreate:
+		if o.Record == nil {
+			return "record is required"
+		}
+	case models.RecordOperationUpdate:
+		if o.RecordId < 1 || o.Version < 1 {
+			return "recordId and version are required"
+		}
+
+		if o.Record == nil || o.Record.Value < 1 {
+			return "record with value is required"
+		}
+	case models.RecordOperationDelete:
+		if o.RecordId < 1 || o.Version < 1 {
+			return "recordId and version are required"
+		}
+	}
+
+	return ""
+}
+
+// failure maps an operation error to the status and message of the
+// single operation endpoint.
+func failure(err error) (int, string) {
+	switch {
+	case errors.Is(err, record.ErrRecordNotFound):
+		return http.StatusNotFound, "record not found"
+	case errors.Is(err, record.ErrVersionMismatch):
+		return http.StatusPreconditionFailed, "version mismatch"
+	case errors.Is(err, record.ErrRestricted):
+		return http.StatusConflict, "record conflicts with account restrictions"
+	case errors.Is(err, record.ErrDuplicateRecord):
+		return http.StatusBadRequest, "record changed more than once in batch"
+	case errors.Is(err, record.ErrInvalidOperation):
+		return http.StatusBadRequest, "invalid batch operation"
+	case errors.Is(err, food.ErrInvalidNutrients):
+		return http.StatusBadRequest, "invalid nutrients"
+	case errors.Is(err, record.ErrFoodNotFound):
+		return http.StatusBadRequest, "food not found"
+	case errors.Is(err, record.ErrRecipeNotFound):
+		return http.StatusBadRequest, "recipe not found"
+	case errors.Is(err, record.ErrAmbiguousFood):
+		return http.StatusBadRequest, "record can reference either food or recipe"
+	case errors.Is(err, record.ErrTagNotFound):
+		return http.StatusBadRequest, "tag not found"
+	case errors.Is(err, tag.ErrInvalidTagName):
+		return http.StatusBadRequest, "invalid tag name"
+	}
+
+	return http.StatusInternalServerError, "unexpected error"
+}
@@ -0,0 +1,279 @@
+package batch_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/batch"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/batch/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+const validBody = `{"operations": [
+	{"op": "create", "record": {"value": 300, "dateRecord": "2024-04-19T08:00:00Z", "description": "Oatmeal"}},
+	{"op": "update", "recordId": 10, "version": 2, "record": {"value": 600, "dateRecord": "2024-04-19T19:00:00Z"}},
+	{"op": "delete", "recordId": 11, "version": 1}
+]}`
+
+var mockResults = []models.RecordOperationResult{
+	{
+		Type:     models.RecordOperationCreate,
+		RecordId: 12,
+		Record: &models.Record{
+			Id:          12,
+			AccountId:   1,
+			Value:       300,
+			Meal:        models.MealBreakfast,
+			Description: "Oatmeal",
+			DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			Version:     1,
+		},
+	},
+	{
+		Type:     models.RecordOperationUpdate,
+		RecordId: 10,
+		Record: &models.Record{
+			Id:         10,
+			AccountId:  1,
+			Value:      600,
+			Meal:       models.MealDinner,
+			DateRecord: time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+			Version:    3,
+		},
+	},
+	{Type: models.RecordOperationDelete, RecordId: 11},
+}
+
+var mockWarnings = []models.RestrictionWarning{
+	{Allergen: models.AllergenPeanuts, Restriction: string(models.AllergenPeanuts)},
+}
+
+func TestBatchHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+		expectedFailure      *batch.OperationFailure
+	}{
+		{
+			name:               "success",
+			reqBody:            validBody,
+			expectedError:      nil,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                 "no operations",
+			reqBody:              `{"operations": []}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "unknown op",
+			reqBody:              `{"operations": [{"op": "upsert", "recordId": 10, "version": 1}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid record",
+			reqBody:              `{"operations": [{"op": "create", "record": {"value": 300}}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "create without record",
+			reqBody:              `{"operations": [{"op": "create"}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "operation 0: record is required",
+		},
+		{
+			name: "update without version",
+			reqBody: `{"operations": [
+				{"op": "delete", "recordId": 11, "version": 1},
+				{"op": "update", "recordId": 10, "record": {"value": 600, "dateRecord": "2024-04-19T19:00:00Z"}}
+			]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "operation 1: recordId and version are required",
+		},
+		{
+			name:                 "delete without record id",
+			reqBody:              `{"operations": [{"op": "delete", "version": 1}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "operation 0: recordId and version are required",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 1, Err: record.ErrVersionMismatch},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "batch operation failed",
+			expectedFailure: &batch.OperationFailure{
+				Index:   1,
+				Status:  http.StatusPreconditionFailed,
+				Message: "version mismatch",
+			},
+		},
+		{
+			name:                 "service layer: record not found",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 2, Err: record.ErrRecordNotFound},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "batch operation failed",
+			expectedFailure: &batch.OperationFailure{
+				Index:   2,
+				Status:  http.StatusNotFound,
+				Message: "record not found",
+			},
+		},
+		{
+			name:                 "service layer: restricted",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 0, Err: record.ErrRestricted, Warnings: mockWarnings},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "batch operation failed",
+			expectedFailure: &batch.OperationFailure{
+				Index:    0,
+				Status:   http.StatusConflict,
+				Message:  "record conflicts with account restrictions",
+				Warnings: mockWarnings,
+			},
+		},
+		{
+			name:                 "service layer: unexpected operation error",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 1, Err: errors.New("database is locked")},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              validBody,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              validBody,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"operations": [`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockApplier := mocks.NewBatchApplier(t)
+			mockApplier.On(
+				"ApplyBatchForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("[]models.RecordOperation"),
+			).Return(mockResults, tc.expectedError).Maybe()
+
+			handler := batch.New(slog.Default(), mockApplier)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/records/batch",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedFailure != nil {
+				var errorResponse struct {
+					response.ErrorResponse
+					Errors []batch.OperationFailure `json:"errors"`
+				}
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+				assert.Equal(t, []batch.OperationFailure{*tc.expectedFailure}, errorResponse.Errors)
+			} else if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var resp batch.Response
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				assert.Equal(t, mockResults, resp.Results)
+			}
+		})
+	}
+}
+
+func TestBatchHandlerInput(t *testing.T) {
+	mockApplier := mocks.NewBatchApplier(t)
+	mockApplier.On(
+		"ApplyBatchForCurrentUser",
+		mock.Anything,
+		[]models.RecordOperation{
+			{
+				Type: models.RecordOperationCreate,
+				Record: models.Record{
+					Value:       300,
+					Description: "Oatmeal",
+					DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+				},
+			},
+			{
+				Type:     models.RecordOperationUpdate,
+				RecordId: 10,
+				Version:  2,
+				Record: models.Record{
+					Value:      600,
+					DateRecord: time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+				},
+			},
+			{Type: models.RecordOperationDelete, RecordId: 11, Version: 1},
+		},
+	).Return(mockResults, nil).Once()
+
+	handler := batch.New(slog.Default(), mockApplier)
+
+	req, err := http.NewRequest(http.MethodPost, "/records/batch", bytes.NewReader([]byte(validBody)))
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
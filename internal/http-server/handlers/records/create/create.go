@@ -2,6 +2,7 @@ package create
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"time"
@@ -9,21 +10,41 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecordCreator
 type RecordCreator interface {
 	CreateRecordForCurrentUser(
 		ctx context.Context,
-		dateRecord time.Time,
-		value int,
-	) error
+		record models.Record,
+	) (models.Record, []models.RestrictionWarning, error)
 }
 
+// Request with FoodId (or RecipeId) takes missing value, description and
+// nutrients from the catalog food scaled to Grams (or recipe to Servings).
+// Value is in the account energy unit, Tags are names of existing tags.
 type Request struct {
-	Value      int       `json:"value"      validate:"required,gte=1"`
-	DateRecord time.Time `json:"dateRecord" validate:"required"`
+	Value       int              `json:"value"       validate:"required_without_all=FoodId RecipeId,omitempty,gte=1"`
+	DateRecord  time.Time        `json:"dateRecord"  validate:"required"`
+	Meal        string           `json:"meal"        validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Description string           `json:"description" validate:"max=255"`
+	FoodId      *int64           `json:"foodId"      validate:"omitempty,gt=0"`
+	Grams       float64          `json:"grams"       validate:"required_with=FoodId,omitempty,gt=0"`
+	RecipeId    *int64           `json:"recipeId"    validate:"omitempty,gt=0"`
+	Servings    float64          `json:"servings"    validate:"required_with=RecipeId,omitempty,gt=0"`
+	Nutrients   models.Nutrients `json:"nutrients"`
+	Tags        []string         `json:"tags"        validate:"max=10,dive,required,max=32"`
+}
+
+type Response struct {
+	models.Record
+	Warnings []models.RestrictionWarning `json:"warnings,omitempty"`
 }
 
 func New(
@@ -55,13 +76,70 @@ func New(
 			return
 		}
 
-		if err := recordCreator.CreateRecordForCurrentUser(r.Context(), req.DateRecord, req.Value); err != nil {
+		created, warnings, err := recordCreator.CreateRecordForCurrentUser(r.Context(), models.Record{
+			Value:       req.Value,
+			Meal:        models.Meal(req.Meal),
+			Description: req.Description,
+			FoodId:      req.FoodId,
+			Grams:       req.Grams,
+			RecipeId:    req.RecipeId,
+			Servings:    req.Servings,
+			Nutrients:   req.Nutrients,
+			Tags:        req.Tags,
+			DateRecord:  req.DateRecord,
+		})
+		if err != nil {
+			if errors.Is(err, record.ErrRestricted) {
+				errResponse := response.ErrorMessage("record conflicts with account restrictions")
+				errResponse.Errors = warnings
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, errResponse)
+				return
+			}
+
+			if errors.Is(err, food.ErrInvalidNutrients) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid nutrients"))
+				return
+			}
+
+			if errors.Is(err, record.ErrFoodNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("food not found"))
+				return
+			}
+
+			if errors.Is(err, record.ErrRecipeNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("recipe not found"))
+				return
+			}
+
+			if errors.Is(err, record.ErrAmbiguousFood) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("record can reference either food or recipe"))
+				return
+			}
+
+			if errors.Is(err, record.ErrTagNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("tag not found"))
+				return
+			}
+
+			if errors.Is(err, tag.ErrInvalidTagName) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid tag name"))
+				return
+			}
+
 			render.Status(r, http.StatusInternalServerError)
 			render.JSON(w, r, response.ErrorMessage("unexpected error"))
 			return
 		}
 
+		etag.Set(w, created.Version)
 		render.Status(r, http.StatusCreated)
-		render.JSON(w, r, nil)
+		render.JSON(w, r, Response{Record: created, Warnings: warnings})
 	}
 }
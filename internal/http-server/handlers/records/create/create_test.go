@@ -9,12 +9,17 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/create"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/create/mocks"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/go-playground/assert.v1"
@@ -26,6 +31,11 @@ var (
 	emptyValue    = 0
 	negativeValue = -600
 	validValue    = 500
+	emptyMeal     = ""
+	validMeal     = "lunch"
+	invalidMeal   = "brunch"
+	validDesc     = "oatmeal with berries"
+	tooLongDesc   = strings.Repeat("a", 256)
 )
 
 func TestCreateRecordHandler(t *testing.T) {
@@ -34,6 +44,8 @@ func TestCreateRecordHandler(t *testing.T) {
 		name                 string
 		dateRecord           time.Time
 		value                int
+		meal                 string
+		description          string
 		expectedError        error
 		expectedStatusCode   int
 		expectedErrorMessage string
@@ -43,6 +55,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "success",
 			dateRecord:           validDate(),
 			value:                validValue,
+			meal:                 emptyMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusCreated,
 			expectedErrorMessage: "",
@@ -52,6 +65,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "empty (default) date record",
 			dateRecord:           emptyDate,
 			value:                validValue,
+			meal:                 emptyMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "validation failed",
@@ -61,6 +75,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "empty (default) value",
 			dateRecord:           validDate(),
 			value:                emptyValue,
+			meal:                 emptyMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "validation failed",
@@ -70,6 +85,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "empty date & value",
 			dateRecord:           emptyDate,
 			value:                emptyValue,
+			meal:                 emptyMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "validation failed",
@@ -79,6 +95,49 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "negative value",
 			dateRecord:           validDate(),
 			value:                negativeValue,
+			meal:                 emptyMeal,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+			invalidDecoing:       false,
+		},
+		{
+			name:                 "success with meal",
+			dateRecord:           validDate(),
+			value:                validValue,
+			meal:                 validMeal,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+			invalidDecoing:       false,
+		},
+		{
+			name:                 "success with description",
+			dateRecord:           validDate(),
+			value:                validValue,
+			meal:                 validMeal,
+			description:          validDesc,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+			invalidDecoing:       false,
+		},
+		{
+			name:                 "too long description",
+			dateRecord:           validDate(),
+			value:                validValue,
+			meal:                 validMeal,
+			description:          tooLongDesc,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+			invalidDecoing:       false,
+		},
+		{
+			name:                 "invalid meal",
+			dateRecord:           validDate(),
+			value:                validValue,
+			meal:                 invalidMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "validation failed",
@@ -88,6 +147,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "unexpected service error",
 			dateRecord:           validDate(),
 			value:                validValue,
+			meal:                 emptyMeal,
 			expectedError:        errors.New("some unexpected service layer error was occured"),
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedErrorMessage: "unexpected error",
@@ -97,6 +157,7 @@ func TestCreateRecordHandler(t *testing.T) {
 			name:                 "invalid decoded json",
 			dateRecord:           validDate(),
 			value:                validValue,
+			meal:                 emptyMeal,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "",
@@ -116,16 +177,21 @@ func TestCreateRecordHandler(t *testing.T) {
 			mockCreator.On(
 				"CreateRecordForCurrentUser",
 				context.Background(),
-				mock.AnythingOfType("time.Time"),
-				tc.value,
-			).Return(tc.expectedError).Maybe()
+				mock.MatchedBy(func(record models.Record) bool {
+					return record.Value == tc.value &&
+						record.Meal == models.Meal(tc.meal) &&
+						record.Description == tc.description
+				}),
+			).Return(models.Record{}, nil, tc.expectedError).Maybe()
 
 			handler := create.New(slog.Default(), mockCreator)
 
 			reqBody := fmt.Sprintf(
-				`{"value": %d, "dateRecord": "%s"}`,
+				`{"value": %d, "dateRecord": "%s", "meal": "%s", "description": "%s"}`,
 				tc.value,
 				tc.dateRecord.Format("2006-01-02T15:04:05Z"),
+				tc.meal,
+				tc.description,
 			)
 
 			if tc.invalidDecoing {
@@ -156,3 +222,289 @@ func TestCreateRecordHandler(t *testing.T) {
 	}
 
 }
+
+func TestCreateRecordHandlerFood(t *testing.T) {
+	foodId := int64(7)
+
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedRecord       models.Record
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:    "success with food and grams",
+			reqBody: `{"dateRecord": "2024-04-19T08:00:00Z", "foodId": 7, "grams": 150}`,
+			expectedRecord: models.Record{
+				FoodId:     &foodId,
+				Grams:      150,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:    "success with nutrients",
+			reqBody: `{"value": 300, "dateRecord": "2024-04-19T08:00:00Z", "nutrients": {"sodium": 420.5}}`,
+			expectedRecord: models.Record{
+				Value:      300,
+				Nutrients:  models.Nutrients{models.NutrientSodium: 420.5},
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:    "success with recipe and servings",
+			reqBody: `{"dateRecord": "2024-04-19T08:00:00Z", "recipeId": 7, "servings": 1.5}`,
+			expectedRecord: models.Record{
+				RecipeId:   &foodId,
+				Servings:   1.5,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "recipe without servings",
+			reqBody:              `{"dateRecord": "2024-04-19T08:00:00Z", "recipeId": 7}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:    "service layer: recipe not found",
+			reqBody: `{"dateRecord": "2024-04-19T08:00:00Z", "recipeId": 7, "servings": 1}`,
+			expectedRecord: models.Record{
+				RecipeId:   &foodId,
+				Servings:   1,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        record.ErrRecipeNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "recipe not found",
+		},
+		{
+			name:    "service layer: food and recipe",
+			reqBody: `{"dateRecord": "2024-04-19T08:00:00Z", "foodId": 7, "grams": 1, "recipeId": 7, "servings": 1}`,
+			expectedRecord: models.Record{
+				FoodId:     &foodId,
+				Grams:      1,
+				RecipeId:   &foodId,
+				Servings:   1,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        record.ErrAmbiguousFood,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "record can reference either food or recipe",
+		},
+		{
+			name:    "success with tags",
+			reqBody: `{"value": 900, "dateRecord": "2024-04-19T19:00:00Z", "tags": ["restaurant", "cheat-day"]}`,
+			expectedRecord: models.Record{
+				Value:      900,
+				Tags:       []string{"restaurant", "cheat-day"},
+				DateRecord: time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+			},
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "too many tags",
+			reqBody:              `{"value": 900, "dateRecord": "2024-04-19T19:00:00Z", "tags": ["a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "empty tag",
+			reqBody:              `{"value": 900, "dateRecord": "2024-04-19T19:00:00Z", "tags": [""]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:    "service layer: tag not found",
+			reqBody: `{"value": 900, "dateRecord": "2024-04-19T19:00:00Z", "tags": ["travel"]}`,
+			expectedRecord: models.Record{
+				Value:      900,
+				Tags:       []string{"travel"},
+				DateRecord: time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+			},
+			expectedError:        record.ErrTagNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "tag not found",
+		},
+		{
+			name:    "service layer: invalid tag name",
+			reqBody: `{"value": 900, "dateRecord": "2024-04-19T19:00:00Z", "tags": ["eating out!"]}`,
+			expectedRecord: models.Record{
+				Value:      900,
+				Tags:       []string{"eating out!"},
+				DateRecord: time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+			},
+			expectedError:        tag.ErrInvalidTagName,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag name",
+		},
+		{
+			name:                 "food without grams",
+			reqBody:              `{"dateRecord": "2024-04-19T08:00:00Z", "foodId": 7}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:    "service layer: invalid nutrients",
+			reqBody: `{"value": 300, "dateRecord": "2024-04-19T08:00:00Z", "nutrients": {"unobtainium": 1}}`,
+			expectedRecord: models.Record{
+				Value:      300,
+				Nutrients:  models.Nutrients{"unobtainium": 1},
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        food.ErrInvalidNutrients,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid nutrients",
+		},
+		{
+			name:    "service layer: food not found",
+			reqBody: `{"dateRecord": "2024-04-19T08:00:00Z", "foodId": 7, "grams": 150}`,
+			expectedRecord: models.Record{
+				FoodId:     &foodId,
+				Grams:      150,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+			},
+			expectedError:        record.ErrFoodNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "food not found",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewRecordCreator(t)
+			mockCreator.On(
+				"CreateRecordForCurrentUser",
+				mock.Anything,
+				tc.expectedRecord,
+			).Return(models.Record{}, nil, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/records",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			}
+		})
+	}
+}
+
+func TestCreateRecordHandlerRestrictions(t *testing.T) {
+	foodId := int64(7)
+
+	reqRecord := models.Record{
+		FoodId:     &foodId,
+		Grams:      100,
+		DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+	}
+
+	savedRecord := reqRecord
+	savedRecord.Id = 1
+	savedRecord.Value = 560
+	savedRecord.Description = "Peanut butter"
+
+	warnings := []models.RestrictionWarning{
+		{Allergen: models.AllergenPeanuts, Restriction: "allergy"},
+	}
+
+	testCases := []struct {
+		name                 string
+		mockRecord           models.Record
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "warn mode: saved with warnings",
+			mockRecord:           savedRecord,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "reject mode: rejected with warnings",
+			mockRecord:           models.Record{},
+			expectedError:        record.ErrRestricted,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "record conflicts with account restrictions",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewRecordCreator(t)
+			mockCreator.On("CreateRecordForCurrentUser", mock.Anything, reqRecord).
+				Return(tc.mockRecord, warnings, tc.expectedError).
+				Once()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/records",
+				bytes.NewReader([]byte(`{"dateRecord": "2024-04-19T08:00:00Z", "foodId": 7, "grams": 100}`)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			var body struct {
+				Message  string                      `json:"message"`
+				Errors   []models.RestrictionWarning `json:"errors"`
+				Warnings []models.RestrictionWarning `json:"warnings"`
+				Id       int64                       `json:"id"`
+			}
+			err = json.Unmarshal(responseRecorder.Body.Bytes(), &body)
+			require.NoError(t, err)
+
+			if tc.expectedErrorMessage != "" {
+				assert.Equal(t, tc.expectedErrorMessage, body.Message)
+				assert.Equal(t, warnings, body.Errors)
+			} else {
+				assert.Equal(t, tc.mockRecord.Id, body.Id)
+				assert.Equal(t, warnings, body.Warnings)
+			}
+		})
+	}
+}
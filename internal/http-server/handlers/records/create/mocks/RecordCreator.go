@@ -7,7 +7,7 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
-	time "time"
+	models "github.com/karmaplush/simple-diet-tracker/internal/domain/models"
 )
 
 // RecordCreator is an autogenerated mock type for the RecordCreator type
@@ -15,22 +15,41 @@ type RecordCreator struct {
 	mock.Mock
 }
 
-// CreateRecordForCurrentUser provides a mock function with given fields: ctx, dateRecord, value
-func (_m *RecordCreator) CreateRecordForCurrentUser(ctx context.Context, dateRecord time.Time, value int) error {
-	ret := _m.Called(ctx, dateRecord, value)
+// CreateRecordForCurrentUser provides a mock function with given fields: ctx, record
+func (_m *RecordCreator) CreateRecordForCurrentUser(ctx context.Context, record models.Record) (models.Record, []models.RestrictionWarning, error) {
+	ret := _m.Called(ctx, record)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateRecordForCurrentUser")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) error); ok {
-		r0 = rf(ctx, dateRecord, value)
+	var r0 models.Record
+	var r1 []models.RestrictionWarning
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Record) (models.Record, []models.RestrictionWarning, error)); ok {
+		return rf(ctx, record)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Record) models.Record); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Get(0).(models.Record)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Record) []models.RestrictionWarning); ok {
+		r1 = rf(ctx, record)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.RestrictionWarning)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.Record) error); ok {
+		r2 = rf(ctx, record)
 	} else {
-		r0 = ret.Error(0)
+		r2 = ret.Error(2)
 	}
 
-	return r0
+	return r0, r1, r2
 }
 
 // NewRecordCreator creates a new instance of RecordCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
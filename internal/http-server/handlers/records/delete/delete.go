@@ -2,6 +2,7 @@ package delete
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -10,7 +11,10 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecordRemover
@@ -18,6 +22,7 @@ type RecordRemover interface {
 	DeleteRecordForCurrentUser(
 		ctx context.Context,
 		recordId int64,
+		version int64,
 	) error
 }
 
@@ -56,7 +61,34 @@ func New(
 			return
 		}
 
-		if err := recordRemover.DeleteRecordForCurrentUser(r.Context(), pathParams.RecordId); err != nil {
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			log.Info("invalid precondition", slog.String("err", err.Error()))
+			render.Status(r, etag.Status(err))
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		if err := recordRemover.DeleteRecordForCurrentUser(r.Context(), pathParams.RecordId, version); err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, record.ErrRecordNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("record not found"))
+				return
+			}
+
+			if errors.Is(err, record.ErrVersionMismatch) {
+				render.Status(r, http.StatusPreconditionFailed)
+				render.JSON(w, r, response.ErrorMessage("version mismatch"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
 			render.Status(r, http.StatusInternalServerError)
 			render.JSON(w, r, response.ErrorMessage("unexpected error"))
 			return
@@ -14,6 +14,8 @@ import (
 	deleteHandler "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/delete"
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/delete/mocks"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/go-playground/assert.v1"
@@ -30,6 +32,7 @@ func TestDeleteRecordHandler(t *testing.T) {
 
 	testCases := []struct {
 		name                 string
+		ifMatch              string
 		recordIdPathParam    string
 		expectedError        error
 		expectedStatusCode   int
@@ -37,6 +40,7 @@ func TestDeleteRecordHandler(t *testing.T) {
 	}{
 		{
 			name:                 "success",
+			ifMatch:              `"3"`,
 			recordIdPathParam:    correctRecordIdParam,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusNoContent,
@@ -44,6 +48,7 @@ func TestDeleteRecordHandler(t *testing.T) {
 		},
 		{
 			name:                 "incorrect recordId param",
+			ifMatch:              `"3"`,
 			recordIdPathParam:    incorrectRecordIdParam,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
@@ -51,6 +56,7 @@ func TestDeleteRecordHandler(t *testing.T) {
 		},
 		{
 			name:                 "invalid recordId param",
+			ifMatch:              `"3"`,
 			recordIdPathParam:    invalidRecordIdParam,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
@@ -58,18 +64,60 @@ func TestDeleteRecordHandler(t *testing.T) {
 		},
 		{
 			name:                 "invalid recordId param #2",
+			ifMatch:              `"3"`,
 			recordIdPathParam:    invalidRecordIdParam2,
 			expectedError:        nil,
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedErrorMessage: "validation failed",
 		},
+		{
+			name:                 "service layer: record not found",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    correctRecordIdParam,
+			expectedError:        record.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    correctRecordIdParam,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
 		{
 			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
 			recordIdPathParam:    "10",
 			expectedError:        errors.New("some unexpected service layer error was occured"),
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedErrorMessage: "unexpected error",
 		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			recordIdPathParam:    correctRecordIdParam,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			recordIdPathParam:    correctRecordIdParam,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    correctRecordIdParam,
+			expectedError:        record.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -85,6 +133,7 @@ func TestDeleteRecordHandler(t *testing.T) {
 				"DeleteRecordForCurrentUser",
 				mock.Anything,
 				mock.AnythingOfType("int64"),
+				mock.AnythingOfType("int64"),
 			).Return(tc.expectedError).Maybe()
 
 			router := chi.NewRouter()
@@ -97,6 +146,10 @@ func TestDeleteRecordHandler(t *testing.T) {
 			req, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
 			responseRecorder := httptest.NewRecorder()
 
 			router.ServeHTTP(responseRecorder, req)
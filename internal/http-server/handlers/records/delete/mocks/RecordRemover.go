@@ -13,17 +13,17 @@ type RecordRemover struct {
 	mock.Mock
 }
 
-// DeleteRecordForCurrentUser provides a mock function with given fields: ctx, recordId
-func (_m *RecordRemover) DeleteRecordForCurrentUser(ctx context.Context, recordId int64) error {
-	ret := _m.Called(ctx, recordId)
+// DeleteRecordForCurrentUser provides a mock function with given fields: ctx, recordId, version
+func (_m *RecordRemover) DeleteRecordForCurrentUser(ctx context.Context, recordId int64, version int64) error {
+	ret := _m.Called(ctx, recordId, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteRecordForCurrentUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
-		r0 = rf(ctx, recordId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, recordId, version)
 	} else {
 		r0 = ret.Error(0)
 	}
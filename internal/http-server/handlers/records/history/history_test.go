@@ -0,0 +1,159 @@
+package history_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/history"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/history/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRevisions = []models.RecordRevision{
+	{
+		Id:        1,
+		RecordId:  10,
+		AccountId: 1,
+		ActorId:   1,
+		Action:    models.RecordCreated,
+		New: &models.RecordValues{
+			Value:       900,
+			Meal:        models.MealDinner,
+			Description: "Pizza",
+			DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+		},
+		DateCreated: time.Date(2024, 4, 19, 21, 0, 0, 0, time.UTC),
+	},
+	{
+		Id:        2,
+		RecordId:  10,
+		AccountId: 1,
+		ActorId:   1,
+		Action:    models.RecordUpdated,
+		Old: &models.RecordValues{
+			Value:       900,
+			Meal:        models.MealDinner,
+			Description: "Pizza",
+			DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+		},
+		New: &models.RecordValues{
+			Value:       600,
+			Meal:        models.MealDinner,
+			Description: "Half a pizza",
+			DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+		},
+		DateCreated: time.Date(2024, 4, 20, 8, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestRecordHistoryHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		recordIdPathParam    string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			recordIdPathParam:    "10",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect recordId param",
+			recordIdPathParam:    "invalid",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "invalid recordId param",
+			recordIdPathParam:    "0",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: record not found",
+			recordIdPathParam:    "10",
+			expectedError:        record.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			recordIdPathParam:    "10",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			recordIdPathParam:    "10",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewHistoryProvider(t)
+			mockProvider.On(
+				"GetRecordHistoryForCurrentUser",
+				mock.Anything,
+				int64(10),
+			).Return(mockRevisions, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Get("/records/{recordId}/history", history.New(slog.Default(), mockProvider))
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				fmt.Sprintf("/records/%s/history", tc.recordIdPathParam),
+				nil,
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var revisions []models.RecordRevision
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &revisions)
+				require.NoError(t, err)
+				assert.Equal(t, mockRevisions, revisions)
+			}
+		})
+	}
+}
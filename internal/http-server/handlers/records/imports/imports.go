@@ -0,0 +1,144 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/importer"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=Importer
+type Importer interface {
+	ImportRecordsForCurrentUser(
+		ctx context.Context,
+		parser importer.Parser,
+		file io.Reader,
+		loc *time.Location,
+		dryRun bool,
+	) (models.ImportResult, error)
+}
+
+const (
+	// formField is the multipart field holding the exported file
+	formField = "file"
+	// maxFileSize fits years of history of the supported exports
+	maxFileSize = 10 << 20
+	// maxBodySize leaves room for multipart headers on top of the file
+	maxBodySize = maxFileSize + 1<<20
+)
+
+// New imports records from a file uploaded as multipart/form-data "file"
+// field. The "source" query param is the tracker the file is exported from,
+// "dryRun=true" previews the import without creating records.
+func New(
+	log *slog.Logger,
+	recordImporter Importer,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.imports.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		parser, err := importer.New(importer.Source(r.URL.Query().Get("source")))
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		dryRun := false
+
+		if dryRunQueryParam := r.URL.Query().Get("dryRun"); dryRunQueryParam != "" {
+			dryRun, err = strconv.ParseBool(dryRunQueryParam)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid dryRun (true or false expected)"))
+				return
+			}
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+		file, _, err := r.FormFile(formField)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				render.Status(r, http.StatusRequestEntityTooLarge)
+				render.JSON(w, r, response.ErrorMessage("import file is too large"))
+				return
+			}
+
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("import file is required"))
+			return
+		}
+		defer file.Close()
+
+		result, err := recordImporter.ImportRecordsForCurrentUser(
+			r.Context(),
+			parser,
+			io.LimitReader(file, maxFileSize),
+			loc,
+			dryRun,
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, importer.ErrMissingColumn) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("import file misses columns of the source export"))
+				return
+			}
+
+			if errors.Is(err, importer.ErrInvalidFile) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid import file"))
+				return
+			}
+
+			if errors.Is(err, record.ErrTooManyRows) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage(record.ErrTooManyRows.Error()))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		if !dryRun {
+			render.Status(r, http.StatusCreated)
+		}
+
+		render.JSON(w, r, result)
+	}
+}
@@ -0,0 +1,258 @@
+package imports_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/imports"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/imports/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/importer"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockResult models.ImportResult = models.ImportResult{
+	Imported:   1,
+	Duplicates: 1,
+	Rows: []models.ImportRow{
+		{
+			Line:   2,
+			Status: models.ImportRowNew,
+			Record: &models.Record{
+				Id:          10,
+				AccountId:   1,
+				Value:       413,
+				Meal:        models.MealBreakfast,
+				Description: "Breakfast",
+				DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+				Version:     1,
+			},
+		},
+		{
+			Line:   3,
+			Status: models.ImportRowDuplicate,
+			Record: &models.Record{
+				Value:       650,
+				Meal:        models.MealDinner,
+				Description: "Dinner",
+				DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+			},
+		},
+	},
+}
+
+// multipartBody returns a form with content in the field, no field for nil content.
+func multipartBody(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	if content != nil {
+		part, err := writer.CreateFormFile(field, "export.csv")
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return &body, writer.FormDataContentType()
+}
+
+func TestImportHandler(t *testing.T) {
+	file := []byte("Date,Meal,Calories\n2024-04-19,Breakfast,413\n2024-04-19,Dinner,650\n")
+
+	testCases := []struct {
+		name                 string
+		query                string
+		field                string
+		content              []byte
+		dryRun               bool
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "dry run",
+			query:                "?source=cronometer&dryRun=true&tz=Europe/Moscow",
+			field:                "file",
+			content:              file,
+			dryRun:               true,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "missing source",
+			query:                "",
+			field:                "file",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: importer.ErrUnknownSource.Error(),
+		},
+		{
+			name:                 "unknown source",
+			query:                "?source=loseit",
+			field:                "file",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: importer.ErrUnknownSource.Error(),
+		},
+		{
+			name:                 "invalid dry run",
+			query:                "?source=myfitnesspal&dryRun=maybe",
+			field:                "file",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid dryRun (true or false expected)",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?source=myfitnesspal&tz=Mars/Olympus",
+			field:                "file",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "missing file field",
+			query:                "?source=myfitnesspal",
+			field:                "photo",
+			content:              file,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "import file is required",
+		},
+		{
+			name:                 "too large body",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              make([]byte, 12<<20),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusRequestEntityTooLarge,
+			expectedErrorMessage: "import file is too large",
+		},
+		{
+			name:                 "service layer: missing column",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        fmt.Errorf("%w: Calories", importer.ErrMissingColumn),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "import file misses columns of the source export",
+		},
+		{
+			name:                 "service layer: invalid file",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        fmt.Errorf("%w: empty file", importer.ErrInvalidFile),
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid import file",
+		},
+		{
+			name:                 "service layer: too many rows",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        record.ErrTooManyRows,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: record.ErrTooManyRows.Error(),
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			query:                "?source=myfitnesspal",
+			field:                "file",
+			content:              file,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			result := mockResult
+			result.DryRun = tc.dryRun
+
+			mockImporter := mocks.NewImporter(t)
+			mockImporter.On(
+				"ImportRecordsForCurrentUser",
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				mock.Anything,
+				tc.dryRun,
+			).Return(result, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Post("/records/import", imports.New(slog.Default(), mockImporter))
+
+			body, contentType := multipartBody(t, tc.field, tc.content)
+
+			req, err := http.NewRequest(http.MethodPost, "/records/import"+tc.query, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", contentType)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var imported models.ImportResult
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &imported)
+				require.NoError(t, err)
+				assert.Equal(t, result, imported)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/middleware"
@@ -12,11 +13,16 @@ import (
 	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecordProvider
 type RecordProvider interface {
-	GetRecordsForCurrentUser(ctx context.Context, date time.Time) ([]models.Record, error)
+	GetRecordsForCurrentUser(
+		ctx context.Context,
+		date time.Time,
+		tags []string,
+	) ([]models.Record, error)
 }
 
 const (
@@ -53,10 +59,32 @@ func New(
 			recordsDate = parsedDate.Truncate(24 * time.Hour)
 		}
 
-		records, err := recordProvider.GetRecordsForCurrentUser(r.Context(), recordsDate)
+		// Tags are comma separated or repeated, records having any of them match
+		var tags []string
+		for _, param := range r.URL.Query()["tags"] {
+			for _, name := range strings.Split(param, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					tags = append(tags, name)
+				}
+			}
+		}
+
+		records, err := recordProvider.GetRecordsForCurrentUser(r.Context(), recordsDate, tags)
 
 		if err != nil {
 
+			if errors.Is(err, tag.ErrInvalidTagName) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid tag name"))
+				return
+			}
+
+			if errors.Is(err, tag.ErrTooManyTags) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("too many tags"))
+				return
+			}
+
 			if errors.Is(err, account.ErrInvalidJWT) {
 				render.Status(r, http.StatusUnauthorized)
 				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
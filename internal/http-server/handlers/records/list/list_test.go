@@ -15,14 +15,16 @@ import (
 	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/list/mocks"
 	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
 	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/go-playground/assert.v1"
 )
 
 var (
-	// Truncate for removing monotonic part from Time struct
-	mockDate    time.Time       = time.Now().Truncate(0)
+	// Truncate for removing monotonic part from Time struct, UTC as
+	// decoded from JSON
+	mockDate    time.Time       = time.Now().UTC().Truncate(0)
 	mockRecords []models.Record = []models.Record{
 		{
 			Id:          1,
@@ -38,6 +40,7 @@ func TestRecordsListHandler(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		dateQueryParam       string
+		tagsQueryParam       string
 		mockRecords          []models.Record
 		expectedError        error
 		expectedStatusCode   int
@@ -59,6 +62,15 @@ func TestRecordsListHandler(t *testing.T) {
 			expectedStatusCode:   http.StatusOK,
 			expectedErrorMessage: "",
 		},
+		{
+			name:                 "success with tags",
+			dateQueryParam:       "2024-04-19",
+			tagsQueryParam:       "restaurant,cheat-day",
+			mockRecords:          mockRecords,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
 		{
 			name:                 "invalid query param value",
 			dateQueryParam:       "invalid",
@@ -75,6 +87,24 @@ func TestRecordsListHandler(t *testing.T) {
 			expectedStatusCode:   http.StatusUnauthorized,
 			expectedErrorMessage: "invalid credentials",
 		},
+		{
+			name:                 "service layer: invalid tag name",
+			dateQueryParam:       "",
+			tagsQueryParam:       "eating%20out!",
+			mockRecords:          mockRecords,
+			expectedError:        tag.ErrInvalidTagName,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag name",
+		},
+		{
+			name:                 "service layer: too many tags",
+			dateQueryParam:       "",
+			tagsQueryParam:       "a,b,c,d,e,f,g,h,i,j,k",
+			mockRecords:          mockRecords,
+			expectedError:        tag.ErrTooManyTags,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "too many tags",
+		},
 		{
 			name:                 "service layer: unexpected error",
 			dateQueryParam:       "",
@@ -97,11 +127,12 @@ func TestRecordsListHandler(t *testing.T) {
 				"GetRecordsForCurrentUser",
 				mock.Anything,
 				mock.AnythingOfType("time.Time"),
+				mock.Anything,
 			).Return(tc.mockRecords, tc.expectedError).Maybe()
 
 			handler := list.New(slog.Default(), mockProvider)
 
-			url := fmt.Sprintf("/records/?date=%s", tc.dateQueryParam)
+			url := fmt.Sprintf("/records/?date=%s&tags=%s", tc.dateQueryParam, tc.tagsQueryParam)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
@@ -130,3 +161,27 @@ func TestRecordsListHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordsListHandlerTags(t *testing.T) {
+	mockProvider := mocks.NewRecordProvider(t)
+	mockProvider.On(
+		"GetRecordsForCurrentUser",
+		mock.Anything,
+		time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC),
+		[]string{"restaurant", "cheat-day", "travel"},
+	).Return(mockRecords, nil).Once()
+
+	handler := list.New(slog.Default(), mockProvider)
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"/records/?date=2024-04-19&tags=restaurant,%20cheat-day,&tags=travel",
+		nil,
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
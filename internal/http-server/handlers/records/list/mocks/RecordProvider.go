@@ -17,9 +17,9 @@ type RecordProvider struct {
 	mock.Mock
 }
 
-// GetRecordsForCurrentUser provides a mock function with given fields: ctx, date
-func (_m *RecordProvider) GetRecordsForCurrentUser(ctx context.Context, date time.Time) ([]models.Record, error) {
-	ret := _m.Called(ctx, date)
+// GetRecordsForCurrentUser provides a mock function with given fields: ctx, date, tags
+func (_m *RecordProvider) GetRecordsForCurrentUser(ctx context.Context, date time.Time, tags []string) ([]models.Record, error) {
+	ret := _m.Called(ctx, date, tags)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetRecordsForCurrentUser")
@@ -27,19 +27,19 @@ func (_m *RecordProvider) GetRecordsForCurrentUser(ctx context.Context, date tim
 
 	var r0 []models.Record
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.Record, error)); ok {
-		return rf(ctx, date)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []string) ([]models.Record, error)); ok {
+		return rf(ctx, date, tags)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.Record); ok {
-		r0 = rf(ctx, date)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []string) []models.Record); ok {
+		r0 = rf(ctx, date, tags)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Record)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
-		r1 = rf(ctx, date)
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, []string) error); ok {
+		r1 = rf(ctx, date, tags)
 	} else {
 		r1 = ret.Error(1)
 	}
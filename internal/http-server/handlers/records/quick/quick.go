@@ -0,0 +1,137 @@
+package quick
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/quickadd"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=QuickAdder
+type QuickAdder interface {
+	QuickAddForCurrentUser(
+		ctx context.Context,
+		text string,
+		loc *time.Location,
+		preview bool,
+	) (models.QuickAddResult, error)
+}
+
+// Request Text is free text like "2 eggs and toast 350kcal at 8am yesterday",
+// times are in the "tz" query param location. Preview parses the text without
+// creating records.
+type Request struct {
+	Text    string `json:"text"    validate:"required,max=500"`
+	Preview bool   `json:"preview"`
+}
+
+func New(
+	log *slog.Logger,
+	quickAdder QuickAdder,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.quick.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		result, err := quickAdder.QuickAddForCurrentUser(r.Context(), req.Text, loc, req.Preview)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, quickadd.ErrEmptyText) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("empty text"))
+				return
+			}
+
+			if errors.Is(err, quickadd.ErrNoItems) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("no food or energy found in text"))
+				return
+			}
+
+			if errors.Is(err, quickadd.ErrInvalidTime) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid time"))
+				return
+			}
+
+			if errors.Is(err, quickadd.ErrInvalidDate) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid date"))
+				return
+			}
+
+			if errors.Is(err, record.ErrUnmatched) {
+				errResponse := response.ErrorMessage("quick-add items not found in catalog")
+				errResponse.Errors = result.Unmatched
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, errResponse)
+				return
+			}
+
+			if errors.Is(err, record.ErrRestricted) {
+				errResponse := response.ErrorMessage("record conflicts with account restrictions")
+				errResponse.Errors = result.Warnings
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, errResponse)
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		if req.Preview {
+			render.JSON(w, r, result)
+			return
+		}
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, result)
+	}
+}
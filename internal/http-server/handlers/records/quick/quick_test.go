@@ -0,0 +1,232 @@
+package quick_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/quick"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/quick/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/quickadd"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var foodId int64 = 1
+
+var mockResult = models.QuickAddResult{
+	Records: []models.Record{
+		{
+			Id:          1,
+			AccountId:   1,
+			Value:       310,
+			Meal:        models.MealBreakfast,
+			Description: "Egg",
+			FoodId:      &foodId,
+			Grams:       200,
+			DateRecord:  time.Date(2024, 4, 9, 8, 0, 0, 0, time.UTC),
+			DateCreated: time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC),
+		},
+	},
+	Unmatched: []string{"pickles"},
+}
+
+func TestQuickAddHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"text": "2 eggs at 8am yesterday"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success preview",
+			query:                "?tz=Europe/Berlin",
+			reqBody:              `{"text": "2 eggs and pickles", "preview": true}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty text",
+			reqBody:              `{"text": ""}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "too long text",
+			reqBody:              fmt.Sprintf(`{"text": "%0501d"}`, 0),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?tz=Mars/Olympus",
+			reqBody:              `{"text": "toast"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: blank text",
+			reqBody:              `{"text": "   "}`,
+			expectedError:        quickadd.ErrEmptyText,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "empty text",
+		},
+		{
+			name:                 "service layer: nothing recognized",
+			reqBody:              `{"text": "yesterday"}`,
+			expectedError:        quickadd.ErrNoItems,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "no food or energy found in text",
+		},
+		{
+			name:                 "service layer: invalid time",
+			reqBody:              `{"text": "toast at 25:00"}`,
+			expectedError:        quickadd.ErrInvalidTime,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid time",
+		},
+		{
+			name:                 "service layer: invalid date",
+			reqBody:              `{"text": "toast on 2024-02-30"}`,
+			expectedError:        quickadd.ErrInvalidDate,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date",
+		},
+		{
+			name:                 "service layer: unmatched items",
+			reqBody:              `{"text": "2 eggs and pickles"}`,
+			expectedError:        record.ErrUnmatched,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "quick-add items not found in catalog",
+		},
+		{
+			name:                 "service layer: restricted",
+			reqBody:              `{"text": "2 eggs"}`,
+			expectedError:        record.ErrRestricted,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "record conflicts with account restrictions",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"text": "2 eggs"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"text": "2 eggs"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"text": "2 eggs"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockQuickAdder := mocks.NewQuickAdder(t)
+			mockQuickAdder.On(
+				"QuickAddForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("*time.Location"),
+				mock.AnythingOfType("bool"),
+			).Return(mockResult, tc.expectedError).Maybe()
+
+			handler := quick.New(slog.Default(), mockQuickAdder)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/records/quick"+tc.query,
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+
+				if errors.Is(tc.expectedError, record.ErrUnmatched) {
+					assert.Equal(t, []interface{}{"pickles"}, errorResponse.Errors)
+				}
+			} else {
+				var result models.QuickAddResult
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.Equal(t, mockResult, result)
+			}
+		})
+	}
+}
+
+func TestQuickAddHandlerInput(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	mockQuickAdder := mocks.NewQuickAdder(t)
+	mockQuickAdder.On(
+		"QuickAddForCurrentUser",
+		mock.Anything,
+		"toast 300kcal",
+		berlin,
+		true,
+	).Return(mockResult, nil).Once()
+
+	handler := quick.New(slog.Default(), mockQuickAdder)
+
+	req, err := http.NewRequest(
+		http.MethodPost,
+		"/records/quick?tz=Europe/Berlin",
+		bytes.NewReader([]byte(`{"text": "toast 300kcal", "preview": true}`)),
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
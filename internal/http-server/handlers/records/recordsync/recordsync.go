@@ -0,0 +1,161 @@
+package recordsync
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=Syncer
+type Syncer interface {
+	SyncForCurrentUser(
+		ctx context.Context,
+		token string,
+		changes []models.RecordChange,
+	) (models.SyncResult, error)
+}
+
+// Request token is the one of the previous sync, empty on the first one.
+type Request struct {
+	Token   string   `json:"token"`
+	Changes []Change `json:"changes" validate:"max=100,dive"`
+}
+
+// Change is a record created, changed or deleted offline at UpdatedAt,
+// omitted fields were not changed. Value is in the account energy unit.
+type Change struct {
+	Uuid        string     `json:"uuid"        validate:"required,uuid"`
+	UpdatedAt   time.Time  `json:"updatedAt"   validate:"required"`
+	Deleted     bool       `json:"deleted"`
+	Value       *int       `json:"value"       validate:"omitempty,gte=1"`
+	Meal        *string    `json:"meal"        validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Description *string    `json:"description" validate:"omitempty,max=255"`
+	DateRecord  *time.Time `json:"dateRecord"`
+}
+
+// ChangeFailure is the change that failed the sync, no change is applied.
+type ChangeFailure struct {
+	Index   int    `json:"index"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func New(
+	log *slog.Logger,
+	syncer Syncer,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.recordsync.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		changes := make([]models.RecordChange, len(req.Changes))
+
+		for i, c := range req.Changes {
+			changes[i] = models.RecordChange{
+				Uuid:        c.Uuid,
+				UpdatedAt:   c.UpdatedAt,
+				Deleted:     c.Deleted,
+				Value:       c.Value,
+				Description: c.Description,
+				DateRecord:  c.DateRecord,
+			}
+
+			if c.Meal != nil {
+				meal := models.Meal(*c.Meal)
+				changes[i].Meal = &meal
+			}
+		}
+
+		result, err := syncer.SyncForCurrentUser(r.Context(), req.Token, changes)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, record.ErrInvalidSyncToken) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid sync token"))
+				return
+			}
+
+			if errors.Is(err, record.ErrTooManyChanges) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage(record.ErrTooManyChanges.Error()))
+				return
+			}
+
+			// Unexpected change errors are left to the 500 below, so
+			// retries with the same Idempotency-Key sync again
+			var operationErr *record.OperationError
+			if errors.As(err, &operationErr) {
+				if status, message := failure(operationErr.Err); status != http.StatusInternalServerError {
+					errResponse := response.ErrorMessage("sync change failed")
+					errResponse.Errors = []ChangeFailure{{
+						Index:   operationErr.Index,
+						Status:  status,
+						Message: message,
+					}}
+					render.Status(r, http.StatusUnprocessableEntity)
+					render.JSON(w, r, errResponse)
+					return
+				}
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, result)
+	}
+}
+
+// failure maps a change error to its status and message.
+func failure(err error) (int, string) {
+	switch {
+	case errors.Is(err, record.ErrIncompleteChange):
+		return http.StatusBadRequest, "new record requires value and dateRecord"
+	case errors.Is(err, record.ErrInvalidUuid):
+		return http.StatusBadRequest, "invalid record uuid"
+	case errors.Is(err, record.ErrDuplicateRecord):
+		return http.StatusBadRequest, "record changed more than once in sync"
+	case errors.Is(err, record.ErrVersionMismatch), errors.Is(err, record.ErrRecordNotFound):
+		return http.StatusConflict, "record changed during sync"
+	}
+
+	return http.StatusInternalServerError, "unexpected error"
+}
@@ -0,0 +1,238 @@
+package recordsync_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/recordsync"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/recordsync/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+const validBody = `{"token": "41", "changes": [
+	{"uuid": "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c", "updatedAt": "2024-04-19T08:05:00Z",
+		"value": 300, "meal": "breakfast", "dateRecord": "2024-04-19T08:00:00Z"},
+	{"uuid": "6ba7b810-9dad-41d1-80b4-00c04fd430c8", "updatedAt": "2024-04-19T09:00:00Z", "deleted": true}
+]}`
+
+var mockResult = models.SyncResult{
+	Token: "44",
+	Changes: []models.SyncChange{
+		{
+			Uuid: "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c",
+			Record: &models.Record{
+				Id:         12,
+				Uuid:       "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c",
+				AccountId:  1,
+				Value:      300,
+				Meal:       models.MealBreakfast,
+				DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+				Version:    1,
+			},
+		},
+		{Uuid: "6ba7b810-9dad-41d1-80b4-00c04fd430c8", Deleted: true},
+	},
+}
+
+func TestSyncHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+		expectedFailure      *recordsync.ChangeFailure
+	}{
+		{
+			name:               "success",
+			reqBody:            validBody,
+			expectedError:      nil,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "first sync without changes",
+			reqBody:            `{}`,
+			expectedError:      nil,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:                 "invalid uuid",
+			reqBody:              `{"changes": [{"uuid": "record-1", "updatedAt": "2024-04-19T08:05:00Z", "value": 300}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "missing updated at",
+			reqBody:              `{"changes": [{"uuid": "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c", "value": 300}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name: "invalid meal",
+			reqBody: `{"changes": [{"uuid": "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c",
+				"updatedAt": "2024-04-19T08:05:00Z", "meal": ""}]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid sync token",
+			reqBody:              validBody,
+			expectedError:        record.ErrInvalidSyncToken,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid sync token",
+		},
+		{
+			name:                 "service layer: incomplete change",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 0, Err: record.ErrIncompleteChange},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "sync change failed",
+			expectedFailure: &recordsync.ChangeFailure{
+				Index:   0,
+				Status:  http.StatusBadRequest,
+				Message: "new record requires value and dateRecord",
+			},
+		},
+		{
+			name:                 "service layer: concurrent change",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 1, Err: record.ErrVersionMismatch},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "sync change failed",
+			expectedFailure: &recordsync.ChangeFailure{
+				Index:   1,
+				Status:  http.StatusConflict,
+				Message: "record changed during sync",
+			},
+		},
+		{
+			name:                 "service layer: unexpected change error",
+			reqBody:              validBody,
+			expectedError:        &record.OperationError{Index: 0, Err: errors.New("database is locked")},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              validBody,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              validBody,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"changes": [`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockSyncer := mocks.NewSyncer(t)
+			mockSyncer.On(
+				"SyncForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("[]models.RecordChange"),
+			).Return(mockResult, tc.expectedError).Maybe()
+
+			handler := recordsync.New(slog.Default(), mockSyncer)
+
+			req, err := http.NewRequest(http.MethodPost, "/sync", bytes.NewReader([]byte(tc.reqBody)))
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedFailure != nil {
+				var errorResponse struct {
+					response.ErrorResponse
+					Errors []recordsync.ChangeFailure `json:"errors"`
+				}
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+				assert.Equal(t, []recordsync.ChangeFailure{*tc.expectedFailure}, errorResponse.Errors)
+			} else if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var result models.SyncResult
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &result)
+				require.NoError(t, err)
+				assert.Equal(t, mockResult, result)
+			}
+		})
+	}
+}
+
+func TestSyncHandlerInput(t *testing.T) {
+	value := 300
+	meal := models.MealBreakfast
+	dateRecord := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+
+	mockSyncer := mocks.NewSyncer(t)
+	mockSyncer.On(
+		"SyncForCurrentUser",
+		mock.Anything,
+		"41",
+		[]models.RecordChange{
+			{
+				Uuid:       "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c",
+				UpdatedAt:  time.Date(2024, 4, 19, 8, 5, 0, 0, time.UTC),
+				Value:      &value,
+				Meal:       &meal,
+				DateRecord: &dateRecord,
+			},
+			{
+				Uuid:      "6ba7b810-9dad-41d1-80b4-00c04fd430c8",
+				UpdatedAt: time.Date(2024, 4, 19, 9, 0, 0, 0, time.UTC),
+				Deleted:   true,
+			},
+		},
+	).Return(mockResult, nil).Once()
+
+	handler := recordsync.New(slog.Default(), mockSyncer)
+
+	req, err := http.NewRequest(http.MethodPost, "/sync", bytes.NewReader([]byte(validBody)))
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
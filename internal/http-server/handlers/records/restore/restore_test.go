@@ -0,0 +1,132 @@
+package restore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/restore"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/restore/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecord = models.Record{
+	Id:          10,
+	AccountId:   1,
+	Value:       900,
+	Meal:        models.MealDinner,
+	Description: "Pizza",
+	DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+	DateCreated: time.Date(2024, 4, 19, 21, 0, 0, 0, time.UTC),
+}
+
+func TestRestoreRecordHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		recordIdPathParam    string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			recordIdPathParam:    "10",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect recordId param",
+			recordIdPathParam:    "invalid",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "invalid recordId param",
+			recordIdPathParam:    "0",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: record not found",
+			recordIdPathParam:    "10",
+			expectedError:        record.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			recordIdPathParam:    "10",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			recordIdPathParam:    "10",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockRestorer := mocks.NewRecordRestorer(t)
+			mockRestorer.On(
+				"RestoreRecordForCurrentUser",
+				mock.Anything,
+				int64(10),
+			).Return(mockRecord, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Post("/records/{recordId}/restore", restore.New(slog.Default(), mockRestorer))
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				fmt.Sprintf("/records/%s/restore", tc.recordIdPathParam),
+				nil,
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var restored models.Record
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &restored)
+				require.NoError(t, err)
+				assert.Equal(t, mockRecord, restored)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecordSearcher
+type RecordSearcher interface {
+	SearchRecordsForCurrentUser(
+		ctx context.Context,
+		q string,
+		from time.Time,
+		to time.Time,
+	) ([]models.RecordSearchResult, error)
+}
+
+type QueryParams struct {
+	Q string `validate:"required,max=200"`
+}
+
+func New(
+	log *slog.Logger,
+	recordSearcher RecordSearcher,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.search.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		params := QueryParams{Q: r.URL.Query().Get("q")}
+
+		if err := validator.New().Struct(params); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		from, to, err := query.OpenRange(r, loc)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		// "to" is an inclusive day, the service takes an exclusive bound
+		if !to.IsZero() {
+			to = to.AddDate(0, 0, 1)
+		}
+
+		results, err := recordSearcher.SearchRecordsForCurrentUser(r.Context(), params.Q, from, to)
+
+		if err != nil {
+
+			if errors.Is(err, record.ErrInvalidQuery) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("search query has no terms"))
+				return
+			}
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, results)
+	}
+}
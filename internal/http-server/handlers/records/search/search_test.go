@@ -0,0 +1,190 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/search"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/search/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockResults = []models.RecordSearchResult{
+	{
+		Record: models.Record{
+			Id:          1,
+			AccountId:   1,
+			Value:       900,
+			Meal:        models.MealDinner,
+			Description: "Pizza with friends",
+			Tags:        []string{"cheat day"},
+			DateRecord:  time.Date(2024, 4, 2, 19, 0, 0, 0, time.UTC),
+			DateCreated: time.Date(2024, 4, 2, 19, 5, 0, 0, time.UTC),
+		},
+		Snippet: "<mark>Pizza</mark> with friends",
+	},
+}
+
+func TestRecordsSearchHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "?q=pizza&from=2024-04-01&to=2024-04-30",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "missing query",
+			query:                "?from=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "too long query",
+			query:                "?q=" + strings.Repeat("a", 201),
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?q=pizza&from=01.04.2024",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "invalid range",
+			query:                "?q=pizza&from=2024-04-20&to=2024-04-19",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?q=pizza&tz=Mars/Olympus",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: no terms",
+			query:                "?q=--",
+			expectedError:        record.ErrInvalidQuery,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "search query has no terms",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "?q=pizza",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "?q=pizza",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockSearcher := mocks.NewRecordSearcher(t)
+			mockSearcher.On(
+				"SearchRecordsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockResults, tc.expectedError).Maybe()
+
+			handler := search.New(slog.Default(), mockSearcher)
+
+			req, err := http.NewRequest(http.MethodGet, "/records/search"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var results []models.RecordSearchResult
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &results)
+				require.NoError(t, err)
+				assert.Equal(t, mockResults, results)
+			}
+
+		})
+	}
+}
+
+func TestRecordsSearchHandlerRange(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	mockSearcher := mocks.NewRecordSearcher(t)
+	mockSearcher.On(
+		"SearchRecordsForCurrentUser",
+		mock.Anything,
+		"greek yog",
+		time.Date(2024, 4, 1, 0, 0, 0, 0, loc),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, loc),
+	).Return(mockResults, nil).Once()
+	mockSearcher.On(
+		"SearchRecordsForCurrentUser",
+		mock.Anything,
+		"greek yog",
+		time.Time{},
+		time.Time{},
+	).Return(mockResults, nil).Once()
+
+	handler := search.New(slog.Default(), mockSearcher)
+
+	for _, query := range []string{
+		"?q=greek+yog&from=2024-04-01&to=2024-04-30&tz=Europe/Berlin",
+		"?q=greek+yog",
+	} {
+		req, err := http.NewRequest(http.MethodGet, "/records/search"+query, nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		handler(responseRecorder, req)
+
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RecordTagger
+type RecordTagger interface {
+	SetRecordTagsForCurrentUser(
+		ctx context.Context,
+		recordId int64,
+		version int64,
+		tags []string,
+	) (models.Record, error)
+}
+
+type PathParams struct {
+	RecordId int64 `validate:"required,gte=1"`
+}
+
+// Request replaces all tags of the record, empty Tags untag it.
+type Request struct {
+	Tags []string `json:"tags" validate:"max=10,dive,required,max=32"`
+}
+
+func New(
+	log *slog.Logger,
+	recordTagger RecordTagger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.records.tags.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		recordId, err := strconv.ParseInt(chi.URLParam(r, "recordId"), 10, 64)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid record id"))
+			return
+		}
+
+		pathParams := PathParams{RecordId: recordId}
+
+		if err := validator.New().Struct(pathParams); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			log.Info("invalid precondition", slog.String("err", err.Error()))
+			render.Status(r, etag.Status(err))
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		updated, err := recordTagger.SetRecordTagsForCurrentUser(
+			r.Context(),
+			pathParams.RecordId,
+			version,
+			req.Tags,
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, tag.ErrInvalidTagName) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid tag name"))
+				return
+			}
+
+			if errors.Is(err, record.ErrTagNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("tag not found"))
+				return
+			}
+
+			if errors.Is(err, record.ErrRecordNotFound) {
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, response.ErrorMessage("record not found"))
+				return
+			}
+
+			if errors.Is(err, record.ErrVersionMismatch) {
+				render.Status(r, http.StatusPreconditionFailed)
+				render.JSON(w, r, response.ErrorMessage("version mismatch"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		etag.Set(w, updated.Version)
+		render.JSON(w, r, updated)
+	}
+}
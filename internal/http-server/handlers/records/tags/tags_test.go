@@ -0,0 +1,230 @@
+package tags_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/tags"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/tags/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecord = models.Record{
+	Id:          10,
+	Version:     3,
+	AccountId:   1,
+	Value:       900,
+	Meal:        models.MealDinner,
+	Tags:        []string{"cheat-day", "restaurant"},
+	DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+	DateCreated: time.Date(2024, 4, 19, 21, 0, 0, 0, time.UTC),
+}
+
+func TestRecordTagsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		recordIdPathParam    string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant", "cheat-day"]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success untag",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": []}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect recordId param",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "invalid",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "invalid recordId param",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "0",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "empty tag",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": [""]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid tag name",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["eating out!"]}`,
+			expectedError:        tag.ErrInvalidTagName,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag name",
+		},
+		{
+			name:                 "service layer: tag not found",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["travel"]}`,
+			expectedError:        record.ErrTagNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "tag not found",
+		},
+		{
+			name:                 "service layer: record not found",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        record.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": [`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"tags": ["restaurant"]}`,
+			expectedError:        record.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockTagger := mocks.NewRecordTagger(t)
+			mockTagger.On(
+				"SetRecordTagsForCurrentUser",
+				mock.Anything,
+				int64(10),
+				mock.AnythingOfType("int64"),
+				mock.Anything,
+			).Return(mockRecord, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Put("/records/{recordId}/tags", tags.New(slog.Default(), mockTagger))
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/records/%s/tags", tc.recordIdPathParam),
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, etag.Format(mockRecord.Version), responseRecorder.Header().Get("ETag"))
+
+				var updated models.Record
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &updated)
+				require.NoError(t, err)
+				assert.Equal(t, mockRecord, updated)
+			}
+		})
+	}
+}
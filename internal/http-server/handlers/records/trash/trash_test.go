@@ -0,0 +1,107 @@
+package trash_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/trash"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/trash/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDeletedAt = time.Date(2024, 4, 3, 8, 0, 0, 0, time.UTC)
+
+	mockRecords []models.Record = []models.Record{
+		{
+			Id:          1,
+			AccountId:   1,
+			Value:       900,
+			Meal:        models.MealDinner,
+			Description: "Pizza",
+			DateRecord:  time.Date(2024, 4, 2, 19, 0, 0, 0, time.UTC),
+			DateCreated: time.Date(2024, 4, 2, 19, 5, 0, 0, time.UTC),
+			DeletedAt:   &mockDeletedAt,
+		},
+	}
+)
+
+func TestRecordsTrashHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		mockRecords          []models.Record
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			mockRecords:          mockRecords,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			mockRecords:          nil,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			mockRecords:          nil,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewTrashProvider(t)
+			mockProvider.On("GetTrashForCurrentUser", mock.Anything).
+				Return(tc.mockRecords, tc.expectedError).
+				Once()
+
+			handler := trash.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/records/trash", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var records []models.Record
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &records)
+				require.NoError(t, err)
+				assert.Equal(t, tc.mockRecords, records)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/update"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/records/update/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/etag"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRecord = models.Record{
+	Id:          10,
+	Version:     3,
+	AccountId:   1,
+	Value:       600,
+	Meal:        models.MealDinner,
+	Description: "Half a pizza",
+	DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+	DateCreated: time.Date(2024, 4, 19, 21, 0, 0, 0, time.UTC),
+}
+
+func TestUpdateRecordHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		ifMatch              string
+		recordIdPathParam    string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect recordId param",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "invalid",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid record id",
+		},
+		{
+			name:                 "invalid recordId param",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "0",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "missing value",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"dateRecord": "2024-04-19T19:00:00Z"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid meal",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "brunch"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "invalid decoded json",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": `,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+		{
+			name:                 "service layer: record not found",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        record.ErrRecordNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "record not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "missing If-Match",
+			ifMatch:              "",
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusPreconditionRequired,
+			expectedErrorMessage: "If-Match header required",
+		},
+		{
+			name:                 "invalid If-Match",
+			ifMatch:              `W/"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid If-Match header",
+		},
+		{
+			name:                 "service layer: version mismatch",
+			ifMatch:              `"3"`,
+			recordIdPathParam:    "10",
+			reqBody:              `{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`,
+			expectedError:        record.ErrVersionMismatch,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedErrorMessage: "version mismatch",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewRecordUpdater(t)
+			mockUpdater.On(
+				"UpdateRecordForCurrentUser",
+				mock.Anything,
+				int64(10),
+				mock.AnythingOfType("int64"),
+				mock.AnythingOfType("models.Record"),
+			).Return(mockRecord, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Put("/records/{recordId}", update.New(slog.Default(), mockUpdater))
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/records/%s", tc.recordIdPathParam),
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, etag.Format(mockRecord.Version), responseRecorder.Header().Get("ETag"))
+
+				var updated models.Record
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &updated)
+				require.NoError(t, err)
+				assert.Equal(t, mockRecord, updated)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordHandlerInput(t *testing.T) {
+	mockUpdater := mocks.NewRecordUpdater(t)
+	mockUpdater.On(
+		"UpdateRecordForCurrentUser",
+		mock.Anything,
+		int64(10),
+		int64(3),
+		models.Record{
+			Value:       600,
+			Meal:        models.MealDinner,
+			Description: "Half a pizza",
+			DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+		},
+	).Return(mockRecord, nil).Once()
+
+	router := chi.NewRouter()
+	router.Use(middleware.URLFormat)
+	router.Put("/records/{recordId}", update.New(slog.Default(), mockUpdater))
+
+	req, err := http.NewRequest(
+		http.MethodPut,
+		"/records/10",
+		bytes.NewReader([]byte(`{"value": 600, "dateRecord": "2024-04-19T19:00:00Z", "meal": "dinner", "description": "Half a pizza"}`)),
+	)
+	require.NoError(t, err)
+	req.Header.Set("If-Match", `"3"`)
+
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
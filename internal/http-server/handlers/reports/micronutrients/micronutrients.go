@@ -0,0 +1,89 @@
+package micronutrients
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/nutrition"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=MicronutrientReportProvider
+type MicronutrientReportProvider interface {
+	GetMicronutrientReportForCurrentUser(
+		ctx context.Context,
+		from time.Time,
+		to time.Time,
+	) (models.MicronutrientReport, error)
+}
+
+const (
+	defaultRangeDays = 7
+	maxRangeDays     = 366
+)
+
+func New(
+	log *slog.Logger,
+	reportProvider MicronutrientReportProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reports.micronutrients.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		from, to, err := query.DateRange(r, loc, defaultRangeDays, maxRangeDays)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		report, err := reportProvider.GetMicronutrientReportForCurrentUser(r.Context(), from, to)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, nutrition.ErrProfileIncomplete) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("sex and birth date are required in account profile"))
+				return
+			}
+
+			if errors.Is(err, nutrition.ErrNoReference) {
+				render.Status(r, http.StatusConflict)
+				render.JSON(w, r, response.ErrorMessage("no reference intakes for account age"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, report)
+	}
+}
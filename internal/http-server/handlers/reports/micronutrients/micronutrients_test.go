@@ -0,0 +1,162 @@
+package micronutrients_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/micronutrients"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/micronutrients/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/nutrition"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockReport models.MicronutrientReport = models.MicronutrientReport{
+	From:       time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	To:         time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC),
+	Sex:        models.SexFemale,
+	Age:        34,
+	DaysLogged: 5,
+	Nutrients: []models.NutrientIntake{
+		{
+			Nutrient:   models.NutrientIron,
+			Unit:       "mg",
+			Average:    9,
+			Target:     18,
+			UpperLimit: 45,
+			Percent:    50,
+			Status:     models.NutrientStatusDeficient,
+		},
+	},
+}
+
+func TestMicronutrientReportHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success with defaults",
+			query:                "",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with range and timezone",
+			query:                "?from=2024-04-01&to=2024-04-30&tz=Europe/Berlin",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?from=invalid",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "from after to",
+			query:                "?from=2024-05-01&to=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "range too long",
+			query:                "?from=2020-01-01&to=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "invalid timezone",
+			query:                "?tz=Mars/Olympus",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid timezone (IANA name expected)",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: incomplete profile",
+			query:                "",
+			expectedError:        nutrition.ErrProfileIncomplete,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "sex and birth date are required in account profile",
+		},
+		{
+			name:                 "service layer: no reference for age",
+			query:                "",
+			expectedError:        nutrition.ErrNoReference,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "no reference intakes for account age",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewMicronutrientReportProvider(t)
+			mockProvider.On(
+				"GetMicronutrientReportForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockReport, tc.expectedError).Maybe()
+
+			handler := micronutrients.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/reports/micronutrients"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var report models.MicronutrientReport
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &report)
+				require.NoError(t, err)
+				assert.Equal(t, mockReport, report)
+			}
+
+		})
+	}
+}
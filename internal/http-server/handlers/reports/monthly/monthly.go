@@ -0,0 +1,88 @@
+package monthly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/report"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=ReportProvider
+type ReportProvider interface {
+	GetMonthlyReportForCurrentUser(
+		ctx context.Context,
+		month time.Time,
+	) (models.MonthlyReport, error)
+}
+
+const (
+	expectedQueryMonthFormat = "2006-01"
+)
+
+func New(
+	log *slog.Logger,
+	reportProvider ReportProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.reports.monthly.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Default value for report month (current)
+		month := time.Now().UTC()
+
+		if monthQueryParam := r.URL.Query().Get("month"); monthQueryParam != "" {
+			parsedMonth, err := time.Parse(expectedQueryMonthFormat, monthQueryParam)
+			if err != nil {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r,
+					response.ErrorMessage("invalid month format (YYYY-MM format expected)"),
+				)
+				return
+			}
+
+			month = parsedMonth
+		}
+
+		monthlyReport, err := reportProvider.GetMonthlyReportForCurrentUser(r.Context(), month)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set(
+			"Content-Disposition",
+			fmt.Sprintf(
+				`attachment; filename="report-%s.pdf"`,
+				monthlyReport.Month.Format(expectedQueryMonthFormat),
+			),
+		)
+
+		if err := report.RenderMonthly(w, monthlyReport); err != nil {
+			log.Error("failed to render report", slog.String("err", err.Error()))
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package monthly_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/monthly"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/reports/monthly/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockMonth  time.Time            = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockReport models.MonthlyReport = models.MonthlyReport{
+		Month:  mockMonth,
+		Intake: models.IntakeStats{From: mockMonth, To: mockMonth.AddDate(0, 1, -1), DailyLimit: 2000},
+	}
+)
+
+func TestMonthlyReportHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		monthQueryParam      string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			monthQueryParam:      "2024-04",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with default month",
+			monthQueryParam:      "",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid month",
+			monthQueryParam:      "2024-13",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid month format (YYYY-MM format expected)",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			monthQueryParam:      "2024-04",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			monthQueryParam:      "2024-04",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewReportProvider(t)
+			mockProvider.On(
+				"GetMonthlyReportForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+			).Return(mockReport, tc.expectedError).Maybe()
+
+			handler := monthly.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(
+				http.MethodGet,
+				"/reports/monthly.pdf?month="+tc.monthQueryParam,
+				nil,
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, "application/pdf", responseRecorder.Header().Get("Content-Type"))
+				assert.Equal(
+					t,
+					`attachment; filename="report-2024-04.pdf"`,
+					responseRecorder.Header().Get("Content-Disposition"),
+				)
+				require.True(t, strings.HasPrefix(responseRecorder.Body.String(), "%PDF-"))
+			}
+
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package get_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/get"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/get/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRestrictions models.Restrictions = models.Restrictions{
+	Allergens: []models.Allergen{models.AllergenPeanuts},
+	Diets:     []models.Diet{models.DietVegan},
+	Mode:      models.RestrictionModeReject,
+}
+
+func TestGetRestrictionsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewRestrictionsProvider(t)
+			mockProvider.On("GetRestrictionsForCurrentUser", mock.Anything).
+				Return(mockRestrictions, tc.expectedError).
+				Once()
+
+			handler := get.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/accounts/me/restrictions", nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var restrictions models.Restrictions
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &restrictions)
+				require.NoError(t, err)
+				assert.Equal(t, mockRestrictions, restrictions)
+			}
+
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=RestrictionsUpdater
+type RestrictionsUpdater interface {
+	UpdateRestrictionsForCurrentUser(
+		ctx context.Context,
+		restrictions models.Restrictions,
+	) (models.Restrictions, error)
+}
+
+// Request with empty mode keeps warnings only.
+type Request struct {
+	Allergens []models.Allergen `json:"allergens"`
+	Diets     []models.Diet     `json:"diets"`
+	Mode      string            `json:"mode"      validate:"omitempty,oneof=warn reject"`
+}
+
+func New(
+	log *slog.Logger,
+	restrictionsUpdater RestrictionsUpdater,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.restrictions.update.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("failed to decode request body", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage("invalid request"))
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("invalid request", slog.String("err", err.Error()))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ValidationError(validateErr))
+			return
+		}
+
+		restrictions, err := restrictionsUpdater.UpdateRestrictionsForCurrentUser(
+			r.Context(),
+			models.Restrictions{
+				Allergens: req.Allergens,
+				Diets:     req.Diets,
+				Mode:      models.RestrictionMode(req.Mode),
+			},
+		)
+		if err != nil {
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, restriction.ErrInvalidAllergens) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid allergens"))
+				return
+			}
+
+			if errors.Is(err, restriction.ErrInvalidDiets) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid diets"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, restrictions)
+	}
+}
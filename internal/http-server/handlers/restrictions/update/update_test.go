@@ -0,0 +1,130 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/update"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/restrictions/update/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockRestrictions models.Restrictions = models.Restrictions{
+	Allergens: []models.Allergen{models.AllergenPeanuts},
+	Diets:     []models.Diet{models.DietVegan},
+	Mode:      models.RestrictionModeReject,
+}
+
+func TestUpdateRestrictionsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"allergens": ["peanuts"], "diets": ["vegan"], "mode": "reject"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "unknown mode",
+			reqBody:              `{"allergens": ["peanuts"], "mode": "block"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid allergens",
+			reqBody:              `{"allergens": ["cocoa"]}`,
+			expectedError:        restriction.ErrInvalidAllergens,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid allergens",
+		},
+		{
+			name:                 "service layer: invalid diets",
+			reqBody:              `{"diets": ["carnivore"]}`,
+			expectedError:        restriction.ErrInvalidDiets,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid diets",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"allergens": ["peanuts"]}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"allergens": ["peanuts"]}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"allergens": [`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewRestrictionsUpdater(t)
+			mockUpdater.On(
+				"UpdateRestrictionsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("models.Restrictions"),
+			).Return(mockRestrictions, tc.expectedError).Maybe()
+
+			handler := update.New(slog.Default(), mockUpdater)
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				"/accounts/me/restrictions",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var restrictions models.Restrictions
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &restrictions)
+				require.NoError(t, err)
+				assert.Equal(t, mockRestrictions, restrictions)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package check_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/check"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/check/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockCheck = models.ShoppingCheck{FoodId: 3, Checked: true}
+
+func TestShoppingCheckHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		path                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{"checked": true}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect food id",
+			path:                 "/shopping-list/items/rice",
+			reqBody:              `{"checked": true}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid food id",
+		},
+		{
+			name:                 "invalid food id",
+			path:                 "/shopping-list/items/0",
+			reqBody:              `{"checked": true}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "missing checked",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: food not found",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{"checked": true}`,
+			expectedError:        shopping.ErrFoodNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "food not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{"checked": true}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{"checked": true}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			path:                 "/shopping-list/items/3",
+			reqBody:              `{"checked": true`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockUpdater := mocks.NewShoppingCheckUpdater(t)
+			mockUpdater.On("UpdateShoppingCheckForCurrentUser", mock.Anything, mockCheck).
+				Return(mockCheck, tc.expectedError).
+				Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Put("/shopping-list/items/{foodId}", check.New(slog.Default(), mockUpdater))
+
+			req, err := http.NewRequest(http.MethodPut, tc.path, bytes.NewReader([]byte(tc.reqBody)))
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var updated models.ShoppingCheck
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &updated)
+				require.NoError(t, err)
+				assert.Equal(t, mockCheck, updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/query"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=ShoppingListProvider
+type ShoppingListProvider interface {
+	GetShoppingListForCurrentUser(
+		ctx context.Context,
+		from time.Time,
+		to time.Time,
+		recipeIds []int64,
+	) (models.ShoppingList, error)
+}
+
+const (
+	defaultRangeDays = 7
+	maxRangeDays     = 31
+)
+
+// New lists foods of meals planned from "from" (today by default) to "to"
+// (a week by default) and of whole recipes from repeated "recipeId" params.
+func New(
+	log *slog.Logger,
+	listProvider ShoppingListProvider,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.shopping.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		loc, err := query.Location(r)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		from, to, err := query.UpcomingRange(r, loc, defaultRangeDays, maxRangeDays)
+		if err != nil {
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorMessage(err.Error()))
+			return
+		}
+
+		var recipeIds []int64
+
+		for _, param := range r.URL.Query()["recipeId"] {
+			recipeId, err := strconv.ParseInt(param, 10, 64)
+			if err != nil || recipeId < 1 {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid recipe id"))
+				return
+			}
+
+			recipeIds = append(recipeIds, recipeId)
+		}
+
+		list, err := listProvider.GetShoppingListForCurrentUser(r.Context(), from, to, recipeIds)
+
+		if err != nil {
+
+			if errors.Is(err, account.ErrInvalidJWT) {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+				return
+			}
+
+			if errors.Is(err, shopping.ErrRecipeNotFound) {
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("recipe not found"))
+				return
+			}
+
+			log.Error("unexpected error", slog.String("err", err.Error()))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.ErrorMessage("unexpected error"))
+			return
+		}
+
+		render.JSON(w, r, list)
+	}
+}
@@ -0,0 +1,187 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/list"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/shopping/list/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockList models.ShoppingList = models.ShoppingList{
+	From:      time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	To:        time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC),
+	RecipeIds: []int64{1},
+	Categories: []models.ShoppingCategory{
+		{
+			Category: models.FoodCategoryGrains,
+			Items: []models.ShoppingItem{
+				{
+					FoodId:   1,
+					Name:     "Rice",
+					Category: models.FoodCategoryGrains,
+					Grams:    1234,
+					Quantity: 1.24,
+					Unit:     "kg",
+					Checked:  true,
+				},
+			},
+		},
+	},
+}
+
+func TestShoppingListHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "success with range and recipes",
+			query:                "?from=2024-04-01&to=2024-04-07&recipeId=1&recipeId=2&tz=Europe/Berlin",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid recipe id",
+			query:                "?recipeId=pilaf",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid recipe id",
+		},
+		{
+			name:                 "invalid range",
+			query:                "?from=2024-04-07&to=2024-04-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "range too long",
+			query:                "?from=2024-04-01&to=2024-06-01",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?from=monday",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "service layer: recipe not found",
+			query:                "?recipeId=9",
+			expectedError:        shopping.ErrRecipeNotFound,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "recipe not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewShoppingListProvider(t)
+			mockProvider.On(
+				"GetShoppingListForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("[]int64"),
+			).Return(mockList, tc.expectedError).Maybe()
+
+			handler := list.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/shopping-list"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var shoppingList models.ShoppingList
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &shoppingList)
+				require.NoError(t, err)
+				assert.Equal(t, mockList, shoppingList)
+			}
+
+		})
+	}
+}
+
+func TestShoppingListHandlerInput(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	mockProvider := mocks.NewShoppingListProvider(t)
+	mockProvider.On(
+		"GetShoppingListForCurrentUser",
+		mock.Anything,
+		time.Date(2024, 4, 1, 0, 0, 0, 0, berlin),
+		time.Date(2024, 4, 7, 0, 0, 0, 0, berlin),
+		[]int64{1, 2},
+	).Return(mockList, nil).Once()
+
+	handler := list.New(slog.Default(), mockProvider)
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"/shopping-list?from=2024-04-01&recipeId=1&recipeId=2&tz=Europe/Berlin",
+		nil,
+	)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
@@ -0,0 +1,121 @@
+package intake_test
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/stats/intake"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/stats/intake/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	mockDay   time.Time          = time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+	mockStats models.IntakeStats = models.IntakeStats{
+		From:         mockDay,
+		To:           mockDay,
+		DailyLimit:   2000,
+		Total:        1800,
+		Average:      1800,
+		DaysLogged:   1,
+		DaysOnTarget: 1,
+		Days:         []models.DailyTotal{{Day: mockDay, Value: 1800, RecordsCount: 3}},
+	}
+)
+
+func TestIntakeStatsHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			query:                "?from=2024-04-19&to=2024-04-19",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "invalid date",
+			query:                "?to=19.04.2024",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date format (YYYY-MM-DD format expected)",
+		},
+		{
+			name:                 "invalid range",
+			query:                "?from=2024-04-20&to=2024-04-19",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid date range",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			query:                "",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "service layer: unexpected error",
+			query:                "",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockProvider := mocks.NewIntakeStatsProvider(t)
+			mockProvider.On(
+				"GetIntakeStatsForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				mock.AnythingOfType("time.Time"),
+			).Return(mockStats, tc.expectedError).Maybe()
+
+			handler := intake.New(slog.Default(), mockProvider)
+
+			req, err := http.NewRequest(http.MethodGet, "/stats"+tc.query, nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var stats models.IntakeStats
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &stats)
+				require.NoError(t, err)
+				assert.Equal(t, mockStats, stats)
+			}
+
+		})
+	}
+}
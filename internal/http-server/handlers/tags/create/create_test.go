@@ -0,0 +1,133 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockTag = models.Tag{Id: 1, AccountId: 1, Name: "cheat-day"}
+
+func TestCreateTagHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			reqBody:              `{"name": "Cheat-Day"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "empty name",
+			reqBody:              `{"name": ""}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "too long name",
+			reqBody:              `{"name": "a-very-long-tag-name-for-one-record"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid name",
+			reqBody:              `{"name": "eating out!"}`,
+			expectedError:        tag.ErrInvalidTagName,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag name",
+		},
+		{
+			name:                 "service layer: tag exists",
+			reqBody:              `{"name": "cheat-day"}`,
+			expectedError:        tag.ErrTagExists,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "tag already exists",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			reqBody:              `{"name": "cheat-day"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			reqBody:              `{"name": "cheat-day"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			reqBody:              `{"name": "cheat-day"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewTagCreator(t)
+			mockCreator.On(
+				"CreateTagForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("string"),
+			).Return(mockTag, tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/tags",
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var created models.Tag
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &created)
+				require.NoError(t, err)
+				assert.Equal(t, mockTag, created)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	deleteHandler "github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/delete"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/delete/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestDeleteTagHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		tagIdPathParam       string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			tagIdPathParam:       "3",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusNoContent,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect tagId param",
+			tagIdPathParam:       "invalid",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag id",
+		},
+		{
+			name:                 "invalid tagId param",
+			tagIdPathParam:       "-3",
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: tag not found",
+			tagIdPathParam:       "3",
+			expectedError:        tag.ErrTagNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "tag not found",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			tagIdPathParam:       "3",
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			tagIdPathParam:       "3",
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockRemover := mocks.NewTagRemover(t)
+			mockRemover.On(
+				"DeleteTagForCurrentUser",
+				mock.Anything,
+				int64(3),
+			).Return(tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Delete("/tags/{tagId}", deleteHandler.New(slog.Default(), mockRemover))
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/tags/%s", tc.tagIdPathParam), nil)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/update"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/tags/update/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockTag = models.Tag{Id: 3, AccountId: 1, Name: "eating out"}
+
+func TestUpdateTagHandler(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		tagIdPathParam       string
+		reqBody              string
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+	}{
+		{
+			name:                 "success",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "Eating out"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusOK,
+			expectedErrorMessage: "",
+		},
+		{
+			name:                 "incorrect tagId param",
+			tagIdPathParam:       "invalid",
+			reqBody:              `{"name": "eating out"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag id",
+		},
+		{
+			name:                 "invalid tagId param",
+			tagIdPathParam:       "0",
+			reqBody:              `{"name": "eating out"}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "empty name",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": ""}`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+		},
+		{
+			name:                 "service layer: invalid name",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "eating out!"}`,
+			expectedError:        tag.ErrInvalidTagName,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid tag name",
+		},
+		{
+			name:                 "service layer: tag not found",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "eating out"}`,
+			expectedError:        tag.ErrTagNotFound,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedErrorMessage: "tag not found",
+		},
+		{
+			name:                 "service layer: tag exists",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "restaurant"}`,
+			expectedError:        tag.ErrTagExists,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "tag already exists",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "eating out"}`,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "eating out"}`,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+		{
+			name:                 "invalid decoded json",
+			tagIdPathParam:       "3",
+			reqBody:              `{"name": "eating out"`,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockRenamer := mocks.NewTagRenamer(t)
+			mockRenamer.On(
+				"RenameTagForCurrentUser",
+				mock.Anything,
+				int64(3),
+				mock.AnythingOfType("string"),
+			).Return(mockTag, tc.expectedError).Maybe()
+
+			router := chi.NewRouter()
+			router.Use(middleware.URLFormat)
+			router.Put("/tags/{tagId}", update.New(slog.Default(), mockRenamer))
+
+			req, err := http.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/tags/%s", tc.tagIdPathParam),
+				bytes.NewReader([]byte(tc.reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			router.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				var updated models.Tag
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &updated)
+				require.NoError(t, err)
+				assert.Equal(t, mockTag, updated)
+			}
+		})
+	}
+}
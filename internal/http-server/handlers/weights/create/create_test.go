@@ -0,0 +1,155 @@
+package create_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/weights/create"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/handlers/weights/create/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var (
+	emptyDate     = time.Time{}
+	validDate     = time.Now
+	emptyValue    = 0.0
+	negativeValue = -80.5
+	validValue    = 80.5
+)
+
+func TestCreateWeightHandler(t *testing.T) {
+
+	testCases := []struct {
+		name                 string
+		dateRecord           time.Time
+		value                float64
+		expectedError        error
+		expectedStatusCode   int
+		expectedErrorMessage string
+		invalidDecoding      bool
+	}{
+		{
+			name:                 "success",
+			dateRecord:           validDate(),
+			value:                validValue,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusCreated,
+			expectedErrorMessage: "",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "empty (default) date record",
+			dateRecord:           emptyDate,
+			value:                validValue,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "empty (default) value",
+			dateRecord:           validDate(),
+			value:                emptyValue,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "negative value",
+			dateRecord:           validDate(),
+			value:                negativeValue,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "validation failed",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			dateRecord:           validDate(),
+			value:                validValue,
+			expectedError:        account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "unexpected service error",
+			dateRecord:           validDate(),
+			value:                validValue,
+			expectedError:        errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+			invalidDecoding:      false,
+		},
+		{
+			name:                 "invalid decoded json",
+			dateRecord:           validDate(),
+			value:                validValue,
+			expectedError:        nil,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid request",
+			invalidDecoding:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockCreator := mocks.NewWeightCreator(t)
+			mockCreator.On(
+				"CreateWeightForCurrentUser",
+				mock.Anything,
+				mock.AnythingOfType("time.Time"),
+				tc.value,
+			).Return(tc.expectedError).Maybe()
+
+			handler := create.New(slog.Default(), mockCreator)
+
+			reqBody := fmt.Sprintf(
+				`{"value": %f, "dateRecord": "%s"}`,
+				tc.value,
+				tc.dateRecord.Format("2006-01-02T15:04:05Z"),
+			)
+
+			if tc.invalidDecoding {
+				reqBody = reqBody[:len(reqBody)-1]
+			}
+
+			req, err := http.NewRequest(
+				http.MethodPost,
+				"/weights",
+				bytes.NewReader([]byte(reqBody)),
+			)
+			require.NoError(t, err)
+
+			responseRecorder := httptest.NewRecorder()
+			handler(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			}
+		})
+	}
+}
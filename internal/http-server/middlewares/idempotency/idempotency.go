@@ -0,0 +1,250 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/idempotency"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=Keeper
+type Keeper interface {
+	BeginForCurrentUser(
+		ctx context.Context,
+		key string,
+		fingerprint string,
+	) (models.IdempotencyKey, error)
+	Complete(ctx context.Context, key models.IdempotencyKey) error
+	Release(ctx context.Context, key models.IdempotencyKey) error
+}
+
+const (
+	KeyHeader = "Idempotency-Key"
+	// ReplayedHeader marks a response replayed from the first request with the key
+	ReplayedHeader = "Idempotent-Replayed"
+	// maxBodySize limits request bodies read to fingerprint the request,
+	// it fits photo and import uploads which apply lower limits of their own
+	maxBodySize = 32 << 20
+	// maxMemoryBodySize is kept in memory, larger bodies are spooled to a temp file
+	maxMemoryBodySize = 1 << 20
+)
+
+// storedHeaders are response headers replayed along with status and body.
+var storedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// New makes requests carrying the Idempotency-Key header safe to retry:
+// the first response is stored and replayed to retries with the same key.
+// Requests without the header and safe methods are passed through.
+// Server errors are not stored, a retry runs the request again.
+func New(log *slog.Logger, keeper Keeper) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/idempotency"),
+		)
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(KeyHeader)
+
+			if value == "" || !mutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := log.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+
+			h := fingerprint(r)
+
+			body, err := spoolBody(io.TeeReader(http.MaxBytesReader(w, r.Body, maxBodySize), h))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					render.Status(r, http.StatusRequestEntityTooLarge)
+					render.JSON(w, r, response.ErrorMessage("request is too large"))
+					return
+				}
+
+				log.Error("failed to read request body", slog.String("err", err.Error()))
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, response.ErrorMessage("invalid request"))
+				return
+			}
+			defer body.Close()
+			r.Body = body
+
+			key, err := keeper.BeginForCurrentUser(r.Context(), value, hex.EncodeToString(h.Sum(nil)))
+			if err != nil {
+				if errors.Is(err, idempotency.ErrInvalidKey) {
+					render.Status(r, http.StatusBadRequest)
+					render.JSON(w, r, response.ErrorMessage("invalid Idempotency-Key header"))
+					return
+				}
+
+				if errors.Is(err, account.ErrInvalidJWT) {
+					render.Status(r, http.StatusUnauthorized)
+					render.JSON(w, r, response.ErrorMessage("invalid credentials"))
+					return
+				}
+
+				if errors.Is(err, idempotency.ErrKeyInProgress) {
+					render.Status(r, http.StatusConflict)
+					render.JSON(w, r, response.ErrorMessage("request with the same idempotency key is in progress"))
+					return
+				}
+
+				if errors.Is(err, idempotency.ErrKeyReused) {
+					render.Status(r, http.StatusUnprocessableEntity)
+					render.JSON(w, r, response.ErrorMessage("idempotency key reused with another request"))
+					return
+				}
+
+				log.Error("unexpected error", slog.String("err", err.Error()))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, response.ErrorMessage("unexpected error"))
+				return
+			}
+
+			if key.Status != 0 {
+				log.Info("replaying response", slog.String("key", key.Key))
+				replay(w, key)
+				return
+			}
+
+			var buf bytes.Buffer
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			// The request context may be canceled once the response is written
+			ctx := context.WithoutCancel(r.Context())
+
+			completed := false
+			defer func() {
+				if !completed {
+					// Failures are logged by the keeper, the key expires anyway
+					_ = keeper.Release(ctx, key)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			key.Status = status
+			key.Header = make(map[string]string, len(storedHeaders))
+			key.Body = buf.Bytes()
+
+			for _, name := range storedHeaders {
+				if v := ww.Header().Get(name); v != "" {
+					key.Header[name] = v
+				}
+			}
+
+			if err := keeper.Complete(ctx, key); err == nil {
+				completed = true
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func mutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	return true
+}
+
+// fingerprint starts the hash identifying the request by method, path, query
+// and If-Match, the body is written to it while being read.
+func fingerprint(r *http.Request) hash.Hash {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write([]byte("If-Match: " + r.Header.Get("If-Match") + "\n"))
+
+	return h
+}
+
+// spoolBody reads the body to replay it to the handler. Bodies up to
+// maxMemoryBodySize are kept in memory, the rest is spooled to a temp file
+// removed on Close.
+func spoolBody(body io.Reader) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+
+	if _, err := io.CopyN(&buf, body, maxMemoryBodySize); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.NopCloser(&buf), nil
+		}
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "idempotency-body-*")
+	if err != nil {
+		return nil, err
+	}
+
+	spooled := &spooledBody{Reader: io.MultiReader(&buf, file), file: file}
+
+	if _, err := io.Copy(file, body); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+
+	return spooled, nil
+}
+
+// spooledBody replays a body spooled to a temp file.
+type spooledBody struct {
+	io.Reader
+	file *os.File
+}
+
+// Close removes the temp file, it is safe to call more than once.
+func (b *spooledBody) Close() error {
+	if b.file == nil {
+		return nil
+	}
+
+	file := b.file
+	b.file = nil
+	file.Close()
+
+	return os.Remove(file.Name())
+}
+
+func replay(w http.ResponseWriter, key models.IdempotencyKey) {
+	for name, value := range key.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(ReplayedHeader, "true")
+
+	w.WriteHeader(key.Status)
+	w.Write(key.Body)
+}
@@ -0,0 +1,297 @@
+package idempotency_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/render"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/middlewares/idempotency"
+	"github.com/karmaplush/simple-diet-tracker/internal/http-server/middlewares/idempotency/mocks"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/api/response"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/account"
+	idempotencyservice "github.com/karmaplush/simple-diet-tracker/internal/services/idempotency"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+var mockReserved = models.IdempotencyKey{
+	AccountId:   1,
+	Key:         "f3c1a6c2-key",
+	Fingerprint: "fingerprint",
+}
+
+// created responds like a create handler and counts calls.
+func created(calls *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("ETag", `"1"`)
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, map[string]int{"id": 10})
+	}
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		method               string
+		key                  string
+		mockStored           models.IdempotencyKey
+		mockError            error
+		expectedCalls        int
+		expectedStatusCode   int
+		expectedErrorMessage string
+		expectedReplayed     bool
+	}{
+		{
+			name:               "first request",
+			method:             http.MethodPost,
+			key:                mockReserved.Key,
+			mockStored:         mockReserved,
+			expectedCalls:      1,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:   "retried request",
+			method: http.MethodPost,
+			key:    mockReserved.Key,
+			mockStored: models.IdempotencyKey{
+				AccountId: 1,
+				Key:       mockReserved.Key,
+				Status:    http.StatusCreated,
+				Header:    map[string]string{"Content-Type": "application/json", "ETag": `"1"`},
+				Body:      []byte(`{"id":10}` + "\n"),
+			},
+			expectedCalls:      0,
+			expectedStatusCode: http.StatusCreated,
+			expectedReplayed:   true,
+		},
+		{
+			name:               "no key",
+			method:             http.MethodPost,
+			key:                "",
+			expectedCalls:      1,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "safe method",
+			method:             http.MethodGet,
+			key:                mockReserved.Key,
+			expectedCalls:      1,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:                 "service layer: invalid key",
+			method:               http.MethodPost,
+			key:                  "not a key",
+			mockError:            idempotencyservice.ErrInvalidKey,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedErrorMessage: "invalid Idempotency-Key header",
+		},
+		{
+			name:                 "service layer: key in progress",
+			method:               http.MethodPost,
+			key:                  mockReserved.Key,
+			mockError:            idempotencyservice.ErrKeyInProgress,
+			expectedStatusCode:   http.StatusConflict,
+			expectedErrorMessage: "request with the same idempotency key is in progress",
+		},
+		{
+			name:                 "service layer: key reused",
+			method:               http.MethodPost,
+			key:                  mockReserved.Key,
+			mockError:            idempotencyservice.ErrKeyReused,
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedErrorMessage: "idempotency key reused with another request",
+		},
+		{
+			name:                 "service layer: invalid jwt",
+			method:               http.MethodPost,
+			key:                  mockReserved.Key,
+			mockError:            account.ErrInvalidJWT,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedErrorMessage: "invalid credentials",
+		},
+		{
+			name:                 "unexpected service error",
+			method:               http.MethodPost,
+			key:                  mockReserved.Key,
+			mockError:            errors.New("some unexpected service layer error was occured"),
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedErrorMessage: "unexpected error",
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			mockKeeper := mocks.NewKeeper(t)
+			mockKeeper.On("BeginForCurrentUser", mock.Anything, tc.key, mock.AnythingOfType("string")).
+				Return(tc.mockStored, tc.mockError).Maybe()
+			mockKeeper.On("Complete", mock.Anything, mock.AnythingOfType("models.IdempotencyKey")).
+				Return(nil).Maybe()
+
+			calls := 0
+			handler := idempotency.New(slog.Default(), mockKeeper)(created(&calls))
+
+			req, err := http.NewRequest(tc.method, "/records", bytes.NewReader([]byte(`{"value": 300}`)))
+			require.NoError(t, err)
+
+			if tc.key != "" {
+				req.Header.Set(idempotency.KeyHeader, tc.key)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			handler.ServeHTTP(responseRecorder, req)
+
+			assert.Equal(t, tc.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, tc.expectedCalls, calls)
+			assert.Equal(t, tc.expectedReplayed, responseRecorder.Header().Get(idempotency.ReplayedHeader) == "true")
+
+			if tc.expectedErrorMessage != "" {
+				var errorResponse response.ErrorResponse
+				err = json.Unmarshal(responseRecorder.Body.Bytes(), &errorResponse)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedErrorMessage, errorResponse.Message)
+			} else {
+				assert.Equal(t, `"1"`, responseRecorder.Header().Get("ETag"))
+				assert.Equal(t, `{"id":10}`+"\n", responseRecorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareStoresResponse(t *testing.T) {
+	mockKeeper := mocks.NewKeeper(t)
+	mockKeeper.On("BeginForCurrentUser", mock.Anything, mockReserved.Key, mock.AnythingOfType("string")).
+		Return(mockReserved, nil).Once()
+	mockKeeper.On("Complete", mock.Anything, mock.MatchedBy(func(key models.IdempotencyKey) bool {
+		return key.Key == mockReserved.Key &&
+			key.Status == http.StatusCreated &&
+			key.Header["ETag"] == `"1"` &&
+			string(key.Body) == `{"id":10}`+"\n"
+	})).Return(nil).Once()
+
+	calls := 0
+	handler := idempotency.New(slog.Default(), mockKeeper)(created(&calls))
+
+	req, err := http.NewRequest(http.MethodPost, "/records", bytes.NewReader([]byte(`{"value": 300}`)))
+	require.NoError(t, err)
+	req.Header.Set(idempotency.KeyHeader, mockReserved.Key)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddlewareReleasesOnServerError(t *testing.T) {
+	mockKeeper := mocks.NewKeeper(t)
+	mockKeeper.On("BeginForCurrentUser", mock.Anything, mockReserved.Key, mock.AnythingOfType("string")).
+		Return(mockReserved, nil).Once()
+	mockKeeper.On("Release", mock.Anything, mockReserved).Return(nil).Once()
+
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, response.ErrorMessage("unexpected error"))
+	})
+	handler := idempotency.New(slog.Default(), mockKeeper)(failing)
+
+	req, err := http.NewRequest(http.MethodPost, "/records", bytes.NewReader([]byte(`{"value": 300}`)))
+	require.NoError(t, err)
+	req.Header.Set(idempotency.KeyHeader, mockReserved.Key)
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+}
+
+func TestIdempotencyMiddlewareLargeBody(t *testing.T) {
+	// Over the in-memory part, the rest is spooled to a temp file
+	body := bytes.Repeat([]byte("photo"), 3<<20/5)
+
+	h := sha256.New()
+	h.Write([]byte(http.MethodPost + " /records/10/photos?\n"))
+	h.Write([]byte("If-Match: \n"))
+	h.Write(body)
+	expectedFingerprint := hex.EncodeToString(h.Sum(nil))
+
+	mockKeeper := mocks.NewKeeper(t)
+	mockKeeper.On("BeginForCurrentUser", mock.Anything, mockReserved.Key, expectedFingerprint).
+		Return(mockReserved, nil).Once()
+	mockKeeper.On("Complete", mock.Anything, mock.Anything).Return(nil).Once()
+
+	var received []byte
+	upload := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		received, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, map[string]int{"id": 10})
+	})
+	handler := idempotency.New(slog.Default(), mockKeeper)(upload)
+
+	req, err := http.NewRequest(http.MethodPost, "/records/10/photos", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set(idempotency.KeyHeader, mockReserved.Key)
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	require.Equal(t, body, received)
+}
+
+func TestIdempotencyMiddlewareFingerprint(t *testing.T) {
+	var fingerprints []string
+
+	mockKeeper := mocks.NewKeeper(t)
+	mockKeeper.On("BeginForCurrentUser", mock.Anything, mockReserved.Key, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			fingerprints = append(fingerprints, args.String(2))
+		}).
+		Return(mockReserved, nil)
+	mockKeeper.On("Complete", mock.Anything, mock.Anything).Return(nil)
+
+	calls := 0
+	handler := idempotency.New(slog.Default(), mockKeeper)(created(&calls))
+
+	send := func(target string, ifMatch string) {
+		req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader([]byte("file")))
+		require.NoError(t, err)
+		req.Header.Set(idempotency.KeyHeader, mockReserved.Key)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	send("/records/import?source=cronometer&dryRun=true", "")
+	send("/records/import?source=cronometer&dryRun=true", "")
+	send("/records/import?source=cronometer&dryRun=false", "")
+	send("/records/import?source=myfitnesspal&dryRun=true", "")
+	send("/records/import?source=cronometer&dryRun=true", `"2"`)
+
+	require.Len(t, fingerprints, 5)
+	assert.Equal(t, fingerprints[0], fingerprints[1])
+
+	// Another query or If-Match is another request, reusing the key fails
+	for _, other := range fingerprints[2:] {
+		require.NotEqual(t, fingerprints[0], other)
+	}
+}
@@ -0,0 +1,57 @@
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingIfMatch = errors.New("If-Match header required")
+	ErrInvalidIfMatch = errors.New("invalid If-Match header")
+)
+
+// Set sets the ETag header to the quoted resource version.
+func Set(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", Format(version))
+}
+
+// Format returns the strong entity tag of the version.
+func Format(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// IfMatch parses the required If-Match header into the expected version,
+// "*" matches any version and is returned as zero.
+func IfMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+
+	if value == "" {
+		return 0, ErrMissingIfMatch
+	}
+
+	if value == "*" {
+		return 0, nil
+	}
+
+	if len(value) < 3 || value[0] != '"' || value[len(value)-1] != '"' {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(value[1:len(value)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
+// Status returns the response status of an IfMatch error.
+func Status(err error) int {
+	if errors.Is(err, ErrMissingIfMatch) {
+		return http.StatusPreconditionRequired
+	}
+
+	return http.StatusBadRequest
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	DateFormat = "2006-01-02"
+)
+
+var (
+	ErrInvalidDate     = errors.New("invalid date format (YYYY-MM-DD format expected)")
+	ErrInvalidTimezone = errors.New("invalid timezone (IANA name expected)")
+	ErrInvalidRange    = errors.New("invalid date range")
+)
+
+// Location parses optional "tz" query param, UTC by default.
+func Location(r *http.Request) (*time.Location, error) {
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	return loc, nil
+}
+
+// DateRange parses optional "from" and "to" query params (inclusive days) in loc.
+// Missing "to" defaults to today, missing "from" to defaultDays back from "to".
+// Ranges longer than maxDays are rejected.
+func DateRange(
+	r *http.Request,
+	loc *time.Location,
+	defaultDays int,
+	maxDays int,
+) (from time.Time, to time.Time, err error) {
+	now := time.Now().In(loc)
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		to, err = time.ParseInLocation(DateFormat, toParam, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDate
+		}
+	}
+
+	from = to.AddDate(0, 0, -(defaultDays - 1))
+
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		from, err = time.ParseInLocation(DateFormat, fromParam, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDate
+		}
+	}
+
+	if from.After(to) || to.After(from.AddDate(0, 0, maxDays-1)) {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+
+	return from, to, nil
+}
+
+// UpcomingRange parses optional "from" and "to" query params (inclusive days)
+// in loc for planning ahead. Missing "from" defaults to today, missing "to"
+// to defaultDays ahead of "from". Ranges longer than maxDays are rejected.
+func UpcomingRange(
+	r *http.Request,
+	loc *time.Location,
+	defaultDays int,
+	maxDays int,
+) (from time.Time, to time.Time, err error) {
+	from, err = ParseDay(r.URL.Query().Get("from"), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to = from.AddDate(0, 0, defaultDays-1)
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		to, err = time.ParseInLocation(DateFormat, toParam, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDate
+		}
+	}
+
+	if from.After(to) || to.After(from.AddDate(0, 0, maxDays-1)) {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+
+	return from, to, nil
+}
+
+// Day parses optional "date" query param in loc, today by default.
+func Day(r *http.Request, loc *time.Location) (time.Time, error) {
+	return ParseDay(r.URL.Query().Get("date"), loc)
+}
+
+// ParseDay parses a YYYY-MM-DD day in loc, empty value means today.
+func ParseDay(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		now := time.Now().In(loc)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc), nil
+	}
+
+	day, err := time.ParseInLocation(DateFormat, value, loc)
+	if err != nil {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	return day, nil
+}
+
+// OpenRange parses optional "from" and "to" query params (inclusive days) in loc,
+// missing bounds are returned as zero times.
+func OpenRange(r *http.Request, loc *time.Location) (from time.Time, to time.Time, err error) {
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		from, err = time.ParseInLocation(DateFormat, fromParam, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDate
+		}
+	}
+
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		to, err = time.ParseInLocation(DateFormat, toParam, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDate
+		}
+	}
+
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return time.Time{}, time.Time{}, ErrInvalidRange
+	}
+
+	return from, to, nil
+}
@@ -44,6 +44,16 @@ func ValidationError(errs validator.ValidationErrors) ValidationErrorResponse {
 				err.Field(),
 				err.Param(),
 			)
+		case "gt":
+			message = fmt.Sprintf("%s should be greater than %s", err.Field(), err.Param())
+		case "max":
+			message = fmt.Sprintf("%s should be at most %s", err.Field(), err.Param())
+		case "required_with":
+			message = fmt.Sprintf("%s is required with %s", err.Field(), err.Param())
+		case "required_without", "required_without_all":
+			message = fmt.Sprintf("%s is required without %s", err.Field(), err.Param())
+		case "oneof":
+			message = fmt.Sprintf("%s should be one of: %s", err.Field(), err.Param())
 		}
 
 		validationErrors = append(validationErrors, FieldValidationError{
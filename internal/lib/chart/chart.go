@@ -0,0 +1,331 @@
+package chart
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Point struct {
+	Label string
+	Value float64
+}
+
+type Chart struct {
+	Title  string
+	Width  int
+	Height int
+	Points []Point
+	// Limit draws a horizontal reference line when set
+	Limit      *float64
+	LimitLabel string
+}
+
+const (
+	marginLeft   = 56
+	marginRight  = 16
+	marginTop    = 40
+	marginBottom = 48
+
+	maxXLabels = 10
+	yTicks     = 5
+
+	colorAxis     = "#9e9e9e"
+	colorGrid     = "#e0e0e0"
+	colorText     = "#424242"
+	colorBar      = "#66bb6a"
+	colorOver     = "#ef5350"
+	colorLine     = "#42a5f5"
+	colorLimit    = "#e53935"
+	fontFamily    = "sans-serif"
+	fontSize      = 11
+	titleSize     = 14
+	defaultWidth  = 800
+	defaultHeight = 400
+)
+
+// Bars renders a bar chart, bars above the limit are highlighted.
+func Bars(w io.Writer, c Chart) error {
+	c = withDefaults(c)
+
+	lo, hi := 0.0, maxValue(c)
+	scale := newScale(c, lo, hi)
+
+	sw := newSVGWriter(w, c)
+	sw.frame(c, scale)
+
+	if len(c.Points) > 0 {
+		slot := scale.plotWidth / float64(len(c.Points))
+		barWidth := math.Max(slot*0.7, 1)
+
+		for i, p := range c.Points {
+			color := colorBar
+			if c.Limit != nil && p.Value > *c.Limit {
+				color = colorOver
+			}
+
+			x := scale.left + slot*float64(i) + (slot-barWidth)/2
+			y := scale.y(p.Value)
+
+			sw.printf(
+				`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"><title>%s: %s</title></rect>`+"\n",
+				num(x), num(y), num(barWidth), num(scale.bottom-y), color,
+				escape(p.Label), num(p.Value),
+			)
+		}
+
+		sw.xLabels(c, scale, func(i int) float64 { return scale.left + slot*(float64(i)+0.5) })
+	}
+
+	sw.limit(c, scale)
+	sw.close()
+
+	return sw.err
+}
+
+// Line renders a line chart with point markers, the value axis is fitted to the data.
+func Line(w io.Writer, c Chart) error {
+	c = withDefaults(c)
+
+	lo, hi := minValue(c), maxValue(c)
+	padding := math.Max((hi-lo)*0.1, 1)
+	lo, hi = math.Max(lo-padding, 0), hi+padding
+
+	scale := newScale(c, lo, hi)
+
+	sw := newSVGWriter(w, c)
+	sw.frame(c, scale)
+
+	if len(c.Points) > 0 {
+		x := func(i int) float64 {
+			if len(c.Points) == 1 {
+				return scale.left + scale.plotWidth/2
+			}
+			return scale.left + scale.plotWidth*float64(i)/float64(len(c.Points)-1)
+		}
+
+		coords := make([]string, 0, len(c.Points))
+		for i, p := range c.Points {
+			coords = append(coords, num(x(i))+","+num(scale.y(p.Value)))
+		}
+
+		sw.printf(
+			`<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n",
+			strings.Join(coords, " "), colorLine,
+		)
+
+		for i, p := range c.Points {
+			sw.printf(
+				`<circle cx="%s" cy="%s" r="3" fill="%s"><title>%s: %s</title></circle>`+"\n",
+				num(x(i)), num(scale.y(p.Value)), colorLine, escape(p.Label), num(p.Value),
+			)
+		}
+
+		sw.xLabels(c, scale, x)
+	}
+
+	sw.limit(c, scale)
+	sw.close()
+
+	return sw.err
+}
+
+func withDefaults(c Chart) Chart {
+	if c.Width <= 0 {
+		c.Width = defaultWidth
+	}
+	if c.Height <= 0 {
+		c.Height = defaultHeight
+	}
+	return c
+}
+
+func maxValue(c Chart) float64 {
+	hi := 0.0
+	for _, p := range c.Points {
+		hi = math.Max(hi, p.Value)
+	}
+	if c.Limit != nil {
+		hi = math.Max(hi, *c.Limit)
+	}
+	return hi
+}
+
+func minValue(c Chart) float64 {
+	if len(c.Points) == 0 {
+		return 0
+	}
+	lo := c.Points[0].Value
+	for _, p := range c.Points {
+		lo = math.Min(lo, p.Value)
+	}
+	if c.Limit != nil {
+		lo = math.Min(lo, *c.Limit)
+	}
+	return lo
+}
+
+type scale struct {
+	left, right, top, bottom float64
+	plotWidth, plotHeight    float64
+	lo, hi, step             float64
+}
+
+func newScale(c Chart, lo float64, hi float64) scale {
+	s := scale{
+		left:   marginLeft,
+		right:  float64(c.Width - marginRight),
+		top:    marginTop,
+		bottom: float64(c.Height - marginBottom),
+	}
+	s.plotWidth = s.right - s.left
+	s.plotHeight = s.bottom - s.top
+
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	s.step = niceStep((hi - lo) / yTicks)
+	s.lo = math.Floor(lo/s.step) * s.step
+	s.hi = math.Ceil(hi/s.step) * s.step
+
+	return s
+}
+
+func (s scale) y(value float64) float64 {
+	return s.bottom - (value-s.lo)/(s.hi-s.lo)*s.plotHeight
+}
+
+// niceStep rounds a raw tick step to 1, 2 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+
+	switch fraction := raw / magnitude; {
+	case fraction <= 1:
+		return magnitude
+	case fraction <= 2:
+		return 2 * magnitude
+	case fraction <= 5:
+		return 5 * magnitude
+	default:
+		return 10 * magnitude
+	}
+}
+
+// svgWriter remembers the first write error so drawing code stays linear.
+type svgWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func newSVGWriter(w io.Writer, c Chart) *svgWriter {
+	sw := &svgWriter{w: bufio.NewWriter(w)}
+
+	sw.printf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s" font-size="%d">`+"\n",
+		c.Width, c.Height, c.Width, c.Height, fontFamily, fontSize,
+	)
+	sw.printf(`<rect width="100%%" height="100%%" fill="#ffffff"/>` + "\n")
+
+	return sw
+}
+
+func (sw *svgWriter) printf(format string, args ...any) {
+	if sw.err != nil {
+		return
+	}
+	_, sw.err = fmt.Fprintf(sw.w, format, args...)
+}
+
+func (sw *svgWriter) frame(c Chart, s scale) {
+	if c.Title != "" {
+		sw.printf(
+			`<text x="%d" y="%d" text-anchor="middle" font-size="%d" fill="%s">%s</text>`+"\n",
+			c.Width/2, marginTop/2+4, titleSize, colorText, escape(c.Title),
+		)
+	}
+
+	for v := s.lo; v <= s.hi+s.step/2; v += s.step {
+		y := s.y(v)
+		sw.printf(
+			`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			num(s.left), num(y), num(s.right), num(y), colorGrid,
+		)
+		sw.printf(
+			`<text x="%s" y="%s" text-anchor="end" fill="%s">%s</text>`+"\n",
+			num(s.left-6), num(y+4), colorText, num(v),
+		)
+	}
+
+	sw.printf(
+		`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+		num(s.left), num(s.bottom), num(s.right), num(s.bottom), colorAxis,
+	)
+	sw.printf(
+		`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+		num(s.left), num(s.top), num(s.left), num(s.bottom), colorAxis,
+	)
+
+	if len(c.Points) == 0 {
+		sw.printf(
+			`<text x="%s" y="%s" text-anchor="middle" fill="%s">no data</text>`+"\n",
+			num(s.left+s.plotWidth/2), num(s.top+s.plotHeight/2), colorAxis,
+		)
+	}
+}
+
+func (sw *svgWriter) xLabels(c Chart, s scale, x func(i int) float64) {
+	every := (len(c.Points) + maxXLabels - 1) / maxXLabels
+
+	for i, p := range c.Points {
+		if i%every != 0 {
+			continue
+		}
+		sw.printf(
+			`<text x="%s" y="%s" text-anchor="middle" fill="%s">%s</text>`+"\n",
+			num(x(i)), num(s.bottom+18), colorText, escape(p.Label),
+		)
+	}
+}
+
+func (sw *svgWriter) limit(c Chart, s scale) {
+	if c.Limit == nil {
+		return
+	}
+
+	y := s.y(*c.Limit)
+
+	sw.printf(
+		`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="2" stroke-dasharray="6 4"/>`+"\n",
+		num(s.left), num(y), num(s.right), num(y), colorLimit,
+	)
+
+	if c.LimitLabel != "" {
+		sw.printf(
+			`<text x="%s" y="%s" text-anchor="end" fill="%s">%s</text>`+"\n",
+			num(s.right), num(y-6), colorLimit, escape(c.LimitLabel),
+		)
+	}
+}
+
+func (sw *svgWriter) close() {
+	sw.printf("</svg>\n")
+	if sw.err != nil {
+		return
+	}
+	sw.err = sw.w.Flush()
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
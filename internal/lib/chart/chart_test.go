@@ -0,0 +1,79 @@
+package chart_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/chart"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharts(t *testing.T) {
+	limit := 2000.0
+
+	testCases := []struct {
+		name     string
+		render   func(buf *bytes.Buffer, c chart.Chart) error
+		chart    chart.Chart
+		contains []string
+	}{
+		{
+			name:   "bars with limit",
+			render: func(buf *bytes.Buffer, c chart.Chart) error { return chart.Bars(buf, c) },
+			chart: chart.Chart{
+				Title:      "Intake <kcal>",
+				Points:     []chart.Point{{Label: "04-01", Value: 1800}, {Label: "04-02", Value: 2400}},
+				Limit:      &limit,
+				LimitLabel: "limit 2000",
+			},
+			contains: []string{"<rect", "#ef5350", "stroke-dasharray", "Intake &lt;kcal&gt;", "limit 2000"},
+		},
+		{
+			name:   "line",
+			render: func(buf *bytes.Buffer, c chart.Chart) error { return chart.Line(buf, c) },
+			chart: chart.Chart{
+				Title:  "Weight",
+				Points: []chart.Point{{Label: "04-01", Value: 80.5}, {Label: "04-08", Value: 79.9}},
+			},
+			contains: []string{"<polyline", "<circle"},
+		},
+		{
+			name:     "empty",
+			render:   func(buf *bytes.Buffer, c chart.Chart) error { return chart.Line(buf, c) },
+			chart:    chart.Chart{},
+			contains: []string{"no data"},
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, tc.render(&buf, tc.chart))
+
+			svg := buf.String()
+			require.True(t, strings.HasPrefix(svg, "<svg"))
+
+			for _, s := range tc.contains {
+				require.Contains(t, svg, s)
+			}
+
+			// Output has to be well-formed XML
+			decoder := xml.NewDecoder(strings.NewReader(svg))
+			for {
+				_, err := decoder.Token()
+				if err != nil {
+					require.ErrorIs(t, err, io.EOF)
+					break
+				}
+			}
+		})
+	}
+}
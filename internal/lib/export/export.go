@@ -0,0 +1,267 @@
+// Package export encodes account data for downloads. Items are written as
+// they come, so exports of any size stream in constant memory.
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// Format is an export file format.
+type Format string
+
+const (
+	// FormatCSV has a row per record for spreadsheets, other sections are
+	// skipped.
+	FormatCSV Format = "csv"
+	// FormatJSON is a single object with the account and a list per section.
+	FormatJSON Format = "json"
+	// FormatNDJSON has a {"type", "data"} line per account and item.
+	FormatNDJSON Format = "ndjson"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format (csv, json or ndjson expected)")
+
+// Encoder writes the account, then sections with their items.
+type Encoder interface {
+	Begin(acc models.Account) error
+	Section(section models.ExportSection) error
+	Item(item any) error
+	End() error
+}
+
+// New returns an encoder of the format writing to w.
+func New(format Format, w io.Writer) (Encoder, error) {
+	buf := bufio.NewWriter(w)
+
+	switch format {
+	case FormatCSV:
+		return &csvEncoder{buf: buf, w: csv.NewWriter(buf)}, nil
+	case FormatJSON:
+		return &jsonEncoder{buf: buf, enc: json.NewEncoder(buf)}, nil
+	case FormatNDJSON:
+		return &ndjsonEncoder{buf: buf, enc: json.NewEncoder(buf)}, nil
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// ContentType returns the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	}
+
+	return "application/json"
+}
+
+type jsonEncoder struct {
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	section bool
+	items   int
+}
+
+func (e *jsonEncoder) Begin(acc models.Account) error {
+	if _, err := e.buf.WriteString(`{"account":`); err != nil {
+		return err
+	}
+
+	return e.enc.Encode(acc)
+}
+
+func (e *jsonEncoder) Section(section models.ExportSection) error {
+	if err := e.closeSection(); err != nil {
+		return err
+	}
+
+	key, err := json.Marshal(section)
+	if err != nil {
+		return err
+	}
+
+	if _, err := e.buf.WriteString("," + string(key) + ":["); err != nil {
+		return err
+	}
+
+	e.section = true
+	e.items = 0
+
+	return nil
+}
+
+func (e *jsonEncoder) Item(item any) error {
+	if e.items > 0 {
+		if err := e.buf.WriteByte(','); err != nil {
+			return err
+		}
+	}
+
+	e.items++
+
+	return e.enc.Encode(item)
+}
+
+func (e *jsonEncoder) End() error {
+	if err := e.closeSection(); err != nil {
+		return err
+	}
+
+	if _, err := e.buf.WriteString("}\n"); err != nil {
+		return err
+	}
+
+	return e.buf.Flush()
+}
+
+func (e *jsonEncoder) closeSection() error {
+	if !e.section {
+		return nil
+	}
+
+	e.section = false
+
+	return e.buf.WriteByte(']')
+}
+
+type ndjsonEncoder struct {
+	buf     *bufio.Writer
+	enc     *json.Encoder
+	section models.ExportSection
+}
+
+type line struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+func (e *ndjsonEncoder) Begin(acc models.Account) error {
+	return e.enc.Encode(line{Type: "account", Data: acc})
+}
+
+func (e *ndjsonEncoder) Section(section models.ExportSection) error {
+	e.section = section
+	return nil
+}
+
+func (e *ndjsonEncoder) Item(item any) error {
+	return e.enc.Encode(line{Type: string(e.section), Data: item})
+}
+
+func (e *ndjsonEncoder) End() error {
+	return e.buf.Flush()
+}
+
+// nutrientColumns are macronutrients followed by micronutrients by name.
+var nutrientColumns = func() []models.Nutrient {
+	columns := []models.Nutrient{models.NutrientCarbs, models.NutrientProtein, models.NutrientFat}
+
+	micronutrients := make([]models.Nutrient, 0, len(models.NutrientUnits))
+	for nutrient := range models.NutrientUnits {
+		micronutrients = append(micronutrients, nutrient)
+	}
+	sort.Slice(micronutrients, func(i, j int) bool { return micronutrients[i] < micronutrients[j] })
+
+	return append(columns, micronutrients...)
+}()
+
+type csvEncoder struct {
+	buf     *bufio.Writer
+	w       *csv.Writer
+	unit    models.EnergyUnit
+	records bool
+}
+
+func (e *csvEncoder) Begin(acc models.Account) error {
+	e.unit = acc.EnergyUnit
+	return nil
+}
+
+func (e *csvEncoder) Section(section models.ExportSection) error {
+	e.records = section == models.ExportRecords
+	if !e.records {
+		return nil
+	}
+
+	header := []string{
+		"id", "date", "meal", "value", "unit", "description",
+		"foodId", "grams", "recipeId", "servings", "tags",
+	}
+
+	for _, nutrient := range nutrientColumns {
+		header = append(header, string(nutrient))
+	}
+
+	return e.w.Write(header)
+}
+
+func (e *csvEncoder) Item(item any) error {
+	record, ok := item.(models.Record)
+	if !e.records || !ok {
+		return nil
+	}
+
+	row := []string{
+		strconv.FormatInt(record.Id, 10),
+		record.DateRecord.Format(time.RFC3339),
+		string(record.Meal),
+		strconv.Itoa(record.Value),
+		string(e.unit),
+		record.Description,
+		optionalId(record.FoodId),
+		optionalAmount(record.Grams),
+		optionalId(record.RecipeId),
+		optionalAmount(record.Servings),
+		strings.Join(record.Tags, ";"),
+	}
+
+	for _, nutrient := range nutrientColumns {
+		amount, ok := record.Nutrients[nutrient]
+		if !ok {
+			row = append(row, "")
+			continue
+		}
+
+		row = append(row, strconv.FormatFloat(amount, 'f', -1, 64))
+	}
+
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) End() error {
+	e.w.Flush()
+
+	if err := e.w.Error(); err != nil {
+		return err
+	}
+
+	return e.buf.Flush()
+}
+
+func optionalId(id *int64) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*id, 10)
+}
+
+func optionalAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,115 @@
+package export_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/export"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	acc = models.Account{Id: 1, UserId: 7, DailyLimit: 2000, EnergyUnit: models.EnergyUnitKcal}
+
+	foodId = int64(3)
+	weight = models.Weight{Id: 1, AccountId: 1, Value: 80.5, DateRecord: time.Date(2024, 4, 19, 7, 0, 0, 0, time.UTC)}
+	record = models.Record{
+		Id:          10,
+		AccountId:   1,
+		Value:       185,
+		Meal:        models.MealBreakfast,
+		Description: "Oatmeal, \"rolled\"",
+		FoodId:      &foodId,
+		Grams:       50,
+		Nutrients:   models.Nutrients{models.NutrientProtein: 6.5, models.NutrientIron: 2},
+		Tags:        []string{"home", "quick"},
+		DateRecord:  time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+	}
+)
+
+func write(t *testing.T, format export.Format) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	encoder, err := export.New(format, &buf)
+	require.NoError(t, err)
+
+	require.NoError(t, encoder.Begin(acc))
+	require.NoError(t, encoder.Section(models.ExportFoods))
+	require.NoError(t, encoder.Section(models.ExportWeights))
+	require.NoError(t, encoder.Item(weight))
+	require.NoError(t, encoder.Section(models.ExportRecords))
+	require.NoError(t, encoder.Item(record))
+	require.NoError(t, encoder.Item(record))
+	require.NoError(t, encoder.End())
+
+	return buf.Bytes()
+}
+
+func TestJSON(t *testing.T) {
+	var decoded struct {
+		Account models.Account  `json:"account"`
+		Foods   []models.Food   `json:"foods"`
+		Weights []models.Weight `json:"weights"`
+		Records []models.Record `json:"records"`
+	}
+
+	require.NoError(t, json.Unmarshal(write(t, export.FormatJSON), &decoded))
+	require.Equal(t, acc, decoded.Account)
+	require.Equal(t, []models.Food{}, decoded.Foods)
+	require.Equal(t, []models.Weight{weight}, decoded.Weights)
+	require.Equal(t, []models.Record{record, record}, decoded.Records)
+}
+
+func TestNDJSON(t *testing.T) {
+	var types []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(write(t, export.FormatNDJSON)))
+	for scanner.Scan() {
+		var line struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		types = append(types, line.Type)
+	}
+
+	require.Equal(t, []string{"account", "weights", "records", "records"}, types)
+}
+
+func TestCSV(t *testing.T) {
+	rows, err := csv.NewReader(bytes.NewReader(write(t, export.FormatCSV))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	header := rows[0]
+	require.Equal(t, []string{"id", "date", "meal", "value", "unit", "description"}, header[:6])
+
+	row := make(map[string]string, len(header))
+	for i, column := range header {
+		row[column] = rows[1][i]
+	}
+
+	require.Equal(t, "2024-04-19T08:00:00Z", row["date"])
+	require.Equal(t, "185", row["value"])
+	require.Equal(t, "kcal", row["unit"])
+	require.Equal(t, `Oatmeal, "rolled"`, row["description"])
+	require.Equal(t, "3", row["foodId"])
+	require.Equal(t, "50", row["grams"])
+	require.Equal(t, "", row["recipeId"])
+	require.Equal(t, "home;quick", row["tags"])
+	require.Equal(t, "6.5", row["protein"])
+	require.Equal(t, "2", row["iron"])
+	require.Equal(t, "", row["fat"])
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := export.New("xlsx", &bytes.Buffer{})
+	require.ErrorIs(t, err, export.ErrUnknownFormat)
+}
@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// cronometerNutrients are columns of the Servings export, Vitamin D is
+// converted from IU to mcg.
+var cronometerNutrients = []nutrientColumn{
+	{name: "Carbs (g)", nutrient: models.NutrientCarbs, factor: 1},
+	{name: "Protein (g)", nutrient: models.NutrientProtein, factor: 1},
+	{name: "Fat (g)", nutrient: models.NutrientFat, factor: 1},
+	{name: "Saturated (g)", nutrient: models.NutrientSaturatedFat, factor: 1},
+	{name: "Sodium (mg)", nutrient: models.NutrientSodium, factor: 1},
+	{name: "Potassium (mg)", nutrient: models.NutrientPotassium, factor: 1},
+	{name: "Fiber (g)", nutrient: models.NutrientFiber, factor: 1},
+	{name: "Sugars (g)", nutrient: models.NutrientSugar, factor: 1},
+	{name: "Vitamin A (µg)", nutrient: models.NutrientVitaminA, factor: 1},
+	{name: "Vitamin C (mg)", nutrient: models.NutrientVitaminC, factor: 1},
+	{name: "Vitamin D (IU)", nutrient: models.NutrientVitaminD, factor: 0.025},
+	{name: "Calcium (mg)", nutrient: models.NutrientCalcium, factor: 1},
+	{name: "Iron (mg)", nutrient: models.NutrientIron, factor: 1},
+	{name: "Magnesium (mg)", nutrient: models.NutrientMagnesium, factor: 1},
+}
+
+// cronometerTimeLayouts are accepted "Time" column values, which are empty
+// for foods logged without a time.
+var cronometerTimeLayouts = []string{"3:04 PM", "3:04:05 PM", "15:04", "15:04:05"}
+
+// cronometer parses the Servings export. Foods without a time are dated
+// at the usual hour of their group, uncategorized ones are meals of their
+// time or snacks.
+type cronometer struct{}
+
+func (cronometer) Parse(r io.Reader, loc *time.Location) ([]models.ImportRow, error) {
+	t, err := readTable(r, "Day", "Group", "Food Name", "Energy (kcal)")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportRow
+
+	for {
+		row, line, err := t.next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+
+			return nil, err
+		}
+
+		var p rowParser
+
+		name := t.get(row, "Food Name")
+		if name == "" {
+			p.fail("Food Name is required")
+		}
+
+		if amount := t.get(row, "Amount"); amount != "" {
+			name += " (" + amount + ")"
+		}
+
+		record := models.Record{
+			Meal:        meal(t.get(row, "Group")),
+			Value:       p.energy(t.get(row, "Energy (kcal)"), "Energy (kcal)"),
+			Description: description(name),
+			Nutrients:   p.nutrients(t, row, cronometerNutrients),
+		}
+
+		day := p.day(t.get(row, "Day"), "Day", loc)
+
+		clock, ok := p.clock(t.get(row, "Time"))
+		if ok {
+			record.DateRecord = at(day, clock.Hour(), clock.Minute(), clock.Second())
+		}
+
+		if record.Meal == "" {
+			record.Meal = models.MealSnack
+			if ok {
+				record.Meal = models.MealByHour(clock.Hour())
+			}
+		}
+
+		if !ok {
+			record.DateRecord = at(day, record.Meal.Hour(), 0, 0)
+		}
+
+		rows = append(rows, p.row(line, record))
+	}
+}
+
+// clock parses an optional time of the day.
+func (p *rowParser) clock(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range cronometerTimeLayouts {
+		clock, err := time.Parse(layout, strings.ToUpper(value))
+		if err == nil {
+			return clock, true
+		}
+	}
+
+	p.fail("invalid Time %q", value)
+
+	return time.Time{}, false
+}
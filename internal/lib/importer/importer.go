@@ -0,0 +1,258 @@
+// Package importer parses food diaries exported by other trackers into
+// records. Rows are parsed independently: a malformed row is returned with
+// its errors and does not stop the rest of the file.
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// Source is a tracker whose exports are imported.
+type Source string
+
+const (
+	// SourceMyFitnessPal is the "Nutrition Summary" export, a row per meal
+	// of a day.
+	SourceMyFitnessPal Source = "myfitnesspal"
+	// SourceCronometer is the "Servings" export, a row per logged food.
+	SourceCronometer Source = "cronometer"
+)
+
+// maxDescription is the record description length limit.
+const maxDescription = 255
+
+var (
+	ErrUnknownSource = errors.New("unknown import source (myfitnesspal or cronometer expected)")
+	ErrInvalidFile   = errors.New("invalid import file")
+	ErrMissingColumn = errors.New("import file misses a column")
+)
+
+// Parser reads an export into rows. Records of valid rows have value in
+// kcal and dates in loc, they are not attached to an account.
+type Parser interface {
+	Parse(r io.Reader, loc *time.Location) ([]models.ImportRow, error)
+}
+
+// New returns the parser of the source.
+func New(source Source) (Parser, error) {
+	switch source {
+	case SourceMyFitnessPal:
+		return myFitnessPal{}, nil
+	case SourceCronometer:
+		return cronometer{}, nil
+	}
+
+	return nil, ErrUnknownSource
+}
+
+// table reads CSV rows by header names.
+type table struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+// readTable reads the header, column names are matched case-insensitively.
+func readTable(r io.Reader, required ...string) (*table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: empty file", ErrInvalidFile)
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, name)
+		}
+	}
+
+	return &table{r: reader, columns: columns}, nil
+}
+
+// next returns the following non-empty row with its line, io.EOF at the end.
+func (t *table) next() ([]string, int, error) {
+	for {
+		row, err := t.r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, 0, err
+			}
+
+			return nil, 0, fmt.Errorf("%w: %s", ErrInvalidFile, err)
+		}
+
+		line, _ := t.r.FieldPos(0)
+
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+
+		return row, line, nil
+	}
+}
+
+// get returns the trimmed cell of the column, empty when the row is short
+// or the file has no such column.
+func (t *table) get(row []string, column string) string {
+	i, ok := t.columns[strings.ToLower(column)]
+	if !ok || i >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[i])
+}
+
+// rowParser collects errors of a single row.
+type rowParser struct {
+	errors []string
+}
+
+func (p *rowParser) fail(format string, args ...any) {
+	p.errors = append(p.errors, fmt.Sprintf(format, args...))
+}
+
+// day parses a YYYY-MM-DD day in loc.
+func (p *rowParser) day(value string, column string, loc *time.Location) time.Time {
+	day, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		p.fail("invalid %s %q (YYYY-MM-DD expected)", column, value)
+		return time.Time{}
+	}
+
+	return day
+}
+
+// at returns the wall time of the day.
+func at(day time.Time, hour int, min int, sec int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, min, sec, 0, day.Location())
+}
+
+// amount parses a non-negative number, ok is false for empty values.
+func (p *rowParser) amount(value string, column string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount < 0 {
+		p.fail("invalid %s %q", column, value)
+		return 0, false
+	}
+
+	return amount, true
+}
+
+// energy parses a required energy in kcal rounded to a whole number,
+// records have at least 1 kcal.
+func (p *rowParser) energy(value string, column string) int {
+	if value == "" {
+		p.fail("%s is required", column)
+		return 0
+	}
+
+	kcal, ok := p.amount(value, column)
+	if !ok {
+		return 0
+	}
+
+	if kcal > math.MaxInt32 {
+		p.fail("invalid %s %q", column, value)
+		return 0
+	}
+
+	energy := int(math.Round(kcal))
+	if energy < 1 {
+		p.fail("%s should be at least 1 kcal", column)
+	}
+
+	return energy
+}
+
+// nutrientColumn is a column with amounts of the nutrient, factor converts
+// them to the nutrient unit.
+type nutrientColumn struct {
+	name     string
+	nutrient models.Nutrient
+	factor   float64
+}
+
+func (p *rowParser) nutrients(t *table, row []string, columns []nutrientColumn) models.Nutrients {
+	var nutrients models.Nutrients
+
+	for _, column := range columns {
+		amount, ok := p.amount(t.get(row, column.name), column.name)
+		if !ok {
+			continue
+		}
+
+		if nutrients == nil {
+			nutrients = make(models.Nutrients, len(columns))
+		}
+
+		nutrients[column.nutrient] = amount * column.factor
+	}
+
+	return nutrients
+}
+
+// row returns the parsed record of the line, or the line errors.
+func (p *rowParser) row(line int, record models.Record) models.ImportRow {
+	if len(p.errors) > 0 {
+		return models.ImportRow{Line: line, Status: models.ImportRowInvalid, Errors: p.errors}
+	}
+
+	return models.ImportRow{Line: line, Status: models.ImportRowNew, Record: &record}
+}
+
+// meal maps meal names of other trackers, empty for custom meals.
+func meal(name string) models.Meal {
+	switch strings.ToLower(name) {
+	case "breakfast":
+		return models.MealBreakfast
+	case "lunch":
+		return models.MealLunch
+	case "dinner", "supper":
+		return models.MealDinner
+	case "snack", "snacks":
+		return models.MealSnack
+	}
+
+	return ""
+}
+
+// description truncates the text to the record description limit.
+func description(text string) string {
+	text = strings.TrimSpace(text)
+
+	if utf8.RuneCountInString(text) <= maxDescription {
+		return text
+	}
+
+	return strings.TrimSpace(string([]rune(text)[:maxDescription]))
+}
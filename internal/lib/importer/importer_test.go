@@ -0,0 +1,166 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/importer"
+	"github.com/stretchr/testify/require"
+)
+
+var loc = time.FixedZone("UTC+3", 3*60*60)
+
+func parse(t *testing.T, source importer.Source, file string) ([]models.ImportRow, error) {
+	t.Helper()
+
+	parser, err := importer.New(source)
+	require.NoError(t, err)
+
+	return parser.Parse(strings.NewReader(file), loc)
+}
+
+func TestMyFitnessPal(t *testing.T) {
+	file := "\ufeffDate,Meal,Calories,Fat (g),Saturated Fat,Cholesterol,Sodium (mg),Carbohydrates (g),Sugar,Protein (g),Vitamin A,Note\n" +
+		"2024-04-19,Breakfast,412.6,12,3.5,30,420,55,10,18,15,\n" +
+		"2024-04-19,Pre-workout,150,,,,,30,,5,,banana\n" +
+		"2024-04-20,Dinner,abc,10,,,,,,,,\n" +
+		"04/21/2024,,300,-1,,,,,,,,\n"
+
+	rows, err := parse(t, importer.SourceMyFitnessPal, file)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	require.Equal(t, models.ImportRow{
+		Line:   2,
+		Status: models.ImportRowNew,
+		Record: &models.Record{
+			Value:       413,
+			Meal:        models.MealBreakfast,
+			Description: "Breakfast",
+			Nutrients: models.Nutrients{
+				models.NutrientFat:          12,
+				models.NutrientSaturatedFat: 3.5,
+				models.NutrientSodium:       420,
+				models.NutrientCarbs:        55,
+				models.NutrientSugar:        10,
+				models.NutrientProtein:      18,
+			},
+			DateRecord: time.Date(2024, 4, 19, 8, 0, 0, 0, loc),
+		},
+	}, rows[0])
+
+	require.Equal(t, models.MealSnack, rows[1].Record.Meal)
+	require.Equal(t, "Pre-workout: banana", rows[1].Record.Description)
+	require.Equal(t, time.Date(2024, 4, 19, 16, 0, 0, 0, loc), rows[1].Record.DateRecord)
+
+	require.Equal(t, models.ImportRowInvalid, rows[2].Status)
+	require.Nil(t, rows[2].Record)
+	require.Equal(t, []string{`invalid Calories "abc"`}, rows[2].Errors)
+
+	require.Equal(t, 5, rows[3].Line)
+	require.Equal(t, []string{
+		"Meal is required",
+		`invalid Fat (g) "-1"`,
+		`invalid Date "04/21/2024" (YYYY-MM-DD expected)`,
+	}, rows[3].Errors)
+}
+
+func TestCronometer(t *testing.T) {
+	file := "Day,Time,Group,Food Name,Amount,Energy (kcal),Carbs (g),Protein (g),Vitamin D (IU),Category\n" +
+		"2024-04-19,7:45 AM,Breakfast,\"Eggs, boiled\",2.00 large,155.4,1.1,12.6,80,Eggs\n" +
+		"2024-04-19,,Lunch,Rice,1.00 cup,205,45,4.3,,Grains\n" +
+		"2024-04-19,21:10,Uncategorized,Tea,1.00 cup,2,,,,Drinks\n" +
+		"2024-04-19,,Uncategorized,Apple,,95,,,,Fruits\n" +
+		"2024-04-19,25 PM,Dinner,,,,,,,\n" +
+		"2024-04-19,,Snacks,Water,1.00 l,0.2,,,,Drinks\n"
+
+	rows, err := parse(t, importer.SourceCronometer, file)
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+
+	require.Equal(t, models.ImportRow{
+		Line:   2,
+		Status: models.ImportRowNew,
+		Record: &models.Record{
+			Value:       155,
+			Meal:        models.MealBreakfast,
+			Description: "Eggs, boiled (2.00 large)",
+			Nutrients: models.Nutrients{
+				models.NutrientCarbs:    1.1,
+				models.NutrientProtein:  12.6,
+				models.NutrientVitaminD: 2,
+			},
+			DateRecord: time.Date(2024, 4, 19, 7, 45, 0, 0, loc),
+		},
+	}, rows[0])
+
+	require.Equal(t, time.Date(2024, 4, 19, 13, 0, 0, 0, loc), rows[1].Record.DateRecord)
+
+	require.Equal(t, models.MealSnack, rows[2].Record.Meal)
+	require.Equal(t, time.Date(2024, 4, 19, 21, 10, 0, 0, loc), rows[2].Record.DateRecord)
+	require.Nil(t, rows[2].Record.Nutrients)
+
+	require.Equal(t, models.MealSnack, rows[3].Record.Meal)
+	require.Equal(t, "Apple", rows[3].Record.Description)
+	require.Equal(t, time.Date(2024, 4, 19, 16, 0, 0, 0, loc), rows[3].Record.DateRecord)
+
+	require.Equal(t, models.ImportRowInvalid, rows[4].Status)
+	require.Equal(t, []string{
+		"Food Name is required",
+		"Energy (kcal) is required",
+		`invalid Time "25 PM"`,
+	}, rows[4].Errors)
+
+	require.Equal(t, []string{"Energy (kcal) should be at least 1 kcal"}, rows[5].Errors)
+}
+
+func TestLongDescription(t *testing.T) {
+	file := "Day,Group,Food Name,Energy (kcal)\n" +
+		"2024-04-19,Lunch," + strings.Repeat("é", 300) + ",100\n"
+
+	rows, err := parse(t, importer.SourceCronometer, file)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("é", 255), rows[0].Record.Description)
+}
+
+func TestInvalidFile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		source        importer.Source
+		file          string
+		expectedError error
+	}{
+		{
+			name:          "empty file",
+			source:        importer.SourceMyFitnessPal,
+			file:          "",
+			expectedError: importer.ErrInvalidFile,
+		},
+		{
+			name:          "missing column",
+			source:        importer.SourceMyFitnessPal,
+			file:          "Date,Meal,Energy (kcal)\n2024-04-19,Lunch,100\n",
+			expectedError: importer.ErrMissingColumn,
+		},
+		{
+			name:          "other source",
+			source:        importer.SourceCronometer,
+			file:          "Date,Meal,Calories\n2024-04-19,Lunch,100\n",
+			expectedError: importer.ErrMissingColumn,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parse(t, tc.source, tc.file)
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestUnknownSource(t *testing.T) {
+	_, err := importer.New("loseit")
+	require.ErrorIs(t, err, importer.ErrUnknownSource)
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// myFitnessPalNutrients are columns of the Nutrition Summary with amounts
+// in supported units, vitamins and minerals given as daily value percents
+// are skipped.
+var myFitnessPalNutrients = []nutrientColumn{
+	{name: "Carbohydrates (g)", nutrient: models.NutrientCarbs, factor: 1},
+	{name: "Protein (g)", nutrient: models.NutrientProtein, factor: 1},
+	{name: "Fat (g)", nutrient: models.NutrientFat, factor: 1},
+	{name: "Saturated Fat", nutrient: models.NutrientSaturatedFat, factor: 1},
+	{name: "Sodium (mg)", nutrient: models.NutrientSodium, factor: 1},
+	{name: "Potassium", nutrient: models.NutrientPotassium, factor: 1},
+	{name: "Fiber", nutrient: models.NutrientFiber, factor: 1},
+	{name: "Sugar", nutrient: models.NutrientSugar, factor: 1},
+}
+
+// myFitnessPal parses the Nutrition Summary export. Its rows are meal
+// totals known by day only, so records are dated at the usual meal hour
+// and described by the meal name with the note.
+type myFitnessPal struct{}
+
+func (myFitnessPal) Parse(r io.Reader, loc *time.Location) ([]models.ImportRow, error) {
+	t, err := readTable(r, "Date", "Meal", "Calories")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.ImportRow
+
+	for {
+		row, line, err := t.next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+
+			return nil, err
+		}
+
+		var p rowParser
+
+		name := t.get(row, "Meal")
+		if name == "" {
+			p.fail("Meal is required")
+		}
+
+		record := models.Record{
+			Meal:        meal(name),
+			Value:       p.energy(t.get(row, "Calories"), "Calories"),
+			Description: name,
+			Nutrients:   p.nutrients(t, row, myFitnessPalNutrients),
+		}
+
+		if record.Meal == "" {
+			record.Meal = models.MealSnack
+		}
+
+		if note := t.get(row, "Note"); note != "" {
+			record.Description += ": " + note
+		}
+		record.Description = description(record.Description)
+
+		day := p.day(t.get(row, "Date"), "Date", loc)
+		record.DateRecord = at(day, record.Meal.Hour(), 0, 0)
+
+		rows = append(rows, p.row(line, record))
+	}
+}
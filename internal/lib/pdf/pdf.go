@@ -0,0 +1,235 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Font is one of the standard PDF fonts, they need no embedding.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+type Color struct {
+	R, G, B float64
+}
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{0.6, 0.6, 0.6}
+)
+
+const (
+	// A4 in points
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+type Document struct {
+	width  float64
+	height float64
+	pages  []*Page
+}
+
+// Page drawing API uses top-left origin with y growing down,
+// coordinates are converted to PDF user space on write.
+type Page struct {
+	height  float64
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{width: A4Width, height: A4Height}
+}
+
+func (d *Document) AddPage() *Page {
+	page := &Page{height: d.height}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (p *Page) Text(x float64, y float64, size float64, font Font, color Color, text string) {
+	fmt.Fprintf(
+		&p.content,
+		"BT /F%d %s Tf %s %s %s rg %s %s Td (%s) Tj ET\n",
+		font+1, num(size),
+		num(color.R), num(color.G), num(color.B),
+		num(x), num(p.height-y),
+		escape(text),
+	)
+}
+
+// TextRight draws text ending at x.
+func (p *Page) TextRight(x float64, y float64, size float64, font Font, color Color, text string) {
+	p.Text(x-TextWidth(text, size, font), y, size, font, color, text)
+}
+
+func (p *Page) Line(x1 float64, y1 float64, x2 float64, y2 float64, width float64, color Color) {
+	fmt.Fprintf(
+		&p.content,
+		"%s w %s %s %s RG %s %s m %s %s l S\n",
+		num(width),
+		num(color.R), num(color.G), num(color.B),
+		num(x1), num(p.height-y1),
+		num(x2), num(p.height-y2),
+	)
+}
+
+// Rect draws a filled rectangle with top-left corner at x, y.
+func (p *Page) Rect(x float64, y float64, w float64, h float64, color Color) {
+	fmt.Fprintf(
+		&p.content,
+		"%s %s %s rg %s %s %s %s re f\n",
+		num(color.R), num(color.G), num(color.B),
+		num(x), num(p.height-y-h), num(w), num(h),
+	)
+}
+
+func (p *Page) Polyline(points [][2]float64, width float64, color Color) {
+	if len(points) < 2 {
+		return
+	}
+
+	fmt.Fprintf(
+		&p.content,
+		"%s w %s %s %s RG %s %s m",
+		num(width),
+		num(color.R), num(color.G), num(color.B),
+		num(points[0][0]), num(p.height-points[0][1]),
+	)
+
+	for _, point := range points[1:] {
+		fmt.Fprintf(&p.content, " %s %s l", num(point[0]), num(p.height-point[1]))
+	}
+
+	p.content.WriteString(" S\n")
+}
+
+// WriteTo serializes the document: catalog, page tree, fonts,
+// then a page object and a compressed content stream per page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	const firstPageObject = 5
+
+	kids := make([]string, 0, len(d.pages))
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPageObject+i*2))
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf(
+		"<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(d.pages),
+	))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), firstPageObject+i*2+1,
+		))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.content.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+
+		object(fmt.Sprintf(
+			"<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			compressed.Len(), compressed.String(),
+		))
+	}
+
+	xref := buf.Len()
+
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(
+		&buf,
+		"trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xref,
+	)
+
+	return buf.WriteTo(w)
+}
+
+// TextWidth approximates Helvetica glyph widths, exact for digits
+// and punctuation used in numbers, which is what gets right-aligned.
+func TextWidth(text string, size float64, font Font) float64 {
+	var units float64
+
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r == ' ', r == '.', r == ',', r == ':', r == '/':
+			units += 278
+		case r == '-':
+			units += 333
+		case r == '%':
+			units += 889
+		case r >= 'A' && r <= 'Z':
+			units += 667
+		default:
+			units += 530
+		}
+	}
+
+	if font == HelveticaBold {
+		units *= 1.05
+	}
+
+	return units * size / 1000
+}
+
+// escape encodes text for a PDF literal string in WinAnsi encoding,
+// characters outside Latin-1 are replaced.
+func escape(text string) string {
+	var b strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r >= 32 && r <= 126:
+			b.WriteByte(byte(r))
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
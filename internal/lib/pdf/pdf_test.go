@@ -0,0 +1,57 @@
+package pdf_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/pdf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	doc := pdf.New()
+
+	page := doc.AddPage()
+	page.Text(40, 40, 12, pdf.HelveticaBold, pdf.Black, "Report (April)")
+	page.Line(40, 50, 200, 50, 1, pdf.Gray)
+	page.Rect(40, 60, 100, 20, pdf.Color{R: 0.4, G: 0.7, B: 0.4})
+	page.Polyline([][2]float64{{40, 100}, {80, 90}, {120, 110}}, 1, pdf.Black)
+
+	doc.AddPage().Text(40, 40, 12, pdf.Helvetica, pdf.Black, "Page 2")
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+
+	out := buf.String()
+
+	require.True(t, strings.HasPrefix(out, "%PDF-1.4"))
+	require.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	require.Contains(t, out, "/Count 2")
+
+	// startxref has to point at the xref table
+	matches := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	require.Len(t, matches, 2)
+	offset, err := strconv.Atoi(matches[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out[offset:], "xref\n"))
+
+	// First content stream has escaped text
+	start := strings.Index(out, "stream\n") + len("stream\n")
+	end := strings.Index(out, "\nendstream")
+	zr, err := zlib.NewReader(strings.NewReader(out[start:end]))
+	require.NoError(t, err)
+	content, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `(Report \(April\)) Tj`)
+}
+
+func TestTextWidth(t *testing.T) {
+	require.InDelta(t, 5.56*4, pdf.TextWidth("2024", 10, pdf.Helvetica), 0.001)
+	require.Greater(t, pdf.TextWidth("Abc", 10, pdf.HelveticaBold), pdf.TextWidth("Abc", 10, pdf.Helvetica))
+}
@@ -0,0 +1,493 @@
+// Package quickadd parses free text like "2 eggs and toast 350kcal at 8am
+// yesterday" into a record draft. Parsing is rule based and deterministic:
+// the same text, time and catalog always give the same result.
+package quickadd
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// PortionGrams is the weight of a counted food portion ("2 eggs", "toast"),
+// catalog food values are per 100 g.
+const PortionGrams = 100
+
+var (
+	ErrEmptyText   = errors.New("empty text")
+	ErrNoItems     = errors.New("no food or energy found in text")
+	ErrInvalidTime = errors.New("invalid time")
+	ErrInvalidDate = errors.New("invalid date")
+)
+
+var (
+	dateRe     = regexp.MustCompile(`\b(?:on\s+)?(\d{4}-\d{2}-\d{2})\b`)
+	dayRe      = regexp.MustCompile(`\b(today|yesterday)\b`)
+	clock12Re  = regexp.MustCompile(`\b(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)\b`)
+	clock24Re  = regexp.MustCompile(`\b(?:at\s+)?(\d{1,2}):(\d{2})\b`)
+	atHourRe   = regexp.MustCompile(`\bat\s+(\d{1,2})\b`)
+	mealRe     = regexp.MustCompile(`\b(?:for\s+|at\s+)?(breakfast|lunch|dinner|supper|snack)\b`)
+	energyRe   = regexp.MustCompile(`(?:~|\b(?:about|around)\s+)?(\d+(?:[.,]\d+)?)\s*(kcals?|calories|calorie|cals?|kj)\b`)
+	separatorR = regexp.MustCompile(`\s*(?:[,;+&]|\band\b|\bwith\b)\s*`)
+	leadQtyRe  = regexp.MustCompile(
+		`^(\d+(?:[.,]\d+)?|½|half|an?|one|two|three|four|five|six|seven|eight|nine|ten)` +
+			`(?:\s*(kg|g|gr|grams?|servings?|portions?|pieces?|pcs|slices?|x)\b|\s+|$)\s*` +
+			`(?:(?:of|an?)\s+)*(.*)$`,
+	)
+	trailQtyRe = regexp.MustCompile(`^(.+?)\s+(\d+(?:[.,]\d+)?)\s*(kg|g|gr|grams?)$`)
+	plainRe    = regexp.MustCompile(`^[\p{L}\p{N}]+$`)
+)
+
+var numberWords = map[string]float64{
+	"½":     0.5,
+	"half":  0.5,
+	"a":     1,
+	"an":    1,
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"ten":   10,
+}
+
+var fillerWords = map[string]bool{
+	"i":      true,
+	"had":    true,
+	"have":   true,
+	"ate":    true,
+	"eaten":  true,
+	"just":   true,
+	"some":   true,
+	"about":  true,
+	"around": true,
+	"also":   true,
+}
+
+// Parse extracts the record time, meal, energy and catalog references
+// from text. now is the current time in the account location.
+//
+// A single energy mention is the total for the whole text, several mentions
+// belong to their items. Items are split on commas, "and", "with", "+" and
+// "&" unless the separator is part of a catalog name. Counted foods are
+// PortionGrams each, counted recipes are servings.
+func Parse(
+	text string,
+	now time.Time,
+	foods []models.Food,
+	recipes []models.Recipe,
+) (models.QuickAdd, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	if s == "" {
+		return models.QuickAdd{}, ErrEmptyText
+	}
+
+	s, names := protect(s, foods, recipes)
+
+	day, s, err := parseDay(s, now)
+	if err != nil {
+		return models.QuickAdd{}, err
+	}
+
+	clock, s, err := parseClock(s)
+	if err != nil {
+		return models.QuickAdd{}, err
+	}
+
+	var result models.QuickAdd
+
+	if m := mealRe.FindStringSubmatch(s); m != nil {
+		result.Meal = models.Meal(m[1])
+		if m[1] == "supper" {
+			result.Meal = models.MealDinner
+		}
+		s = mealRe.ReplaceAllString(s, " ")
+	}
+
+	hour, minute := now.Hour(), now.Minute()
+	switch {
+	case clock != nil:
+		hour, minute = clock[0], clock[1]
+	case result.Meal != "":
+		hour, minute = result.Meal.Hour(), 0
+	}
+	result.DateRecord = time.Date(
+		day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location(),
+	)
+
+	var mentions int
+
+	for _, part := range separatorR.Split(s, -1) {
+		part = restore(strings.TrimSpace(part), names)
+
+		var value *int
+		if m := energyRe.FindStringSubmatch(part); m != nil {
+			value = energy(m[1], m[2])
+			mentions += len(energyRe.FindAllString(part, -1))
+			part = strings.TrimSpace(energyRe.ReplaceAllString(part, " "))
+		}
+
+		part = trimFiller(part)
+		if part == "" && value == nil {
+			continue
+		}
+
+		item := models.QuickAddItem{Text: part, Value: value}
+		if part != "" {
+			item = match(item, foods, recipes)
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	if mentions == 1 {
+		var items []models.QuickAddItem
+		for _, item := range result.Items {
+			if item.Value != nil {
+				result.Value = item.Value
+				item.Value = nil
+			}
+			if item.Text != "" {
+				items = append(items, item)
+			}
+		}
+		result.Items = items
+	}
+
+	if len(result.Items) == 0 && result.Value == nil {
+		return models.QuickAdd{}, ErrNoItems
+	}
+
+	return result, nil
+}
+
+// protect replaces catalog names that are not a single plain word with
+// placeholders, so their separators and keywords survive extraction.
+func protect(s string, foods []models.Food, recipes []models.Recipe) (string, []string) {
+	var candidates []string
+	for _, f := range foods {
+		candidates = append(candidates, f.Name)
+	}
+	for _, r := range recipes {
+		candidates = append(candidates, r.Name)
+	}
+
+	for i, name := range candidates {
+		candidates[i] = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	}
+
+	// Longest first, so "peanut butter and jelly" wins over "peanut butter"
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i]) > len(candidates[j])
+	})
+
+	var names []string
+	for _, name := range candidates {
+		if name == "" || plainRe.MatchString(name) {
+			continue
+		}
+
+		re := regexp.MustCompile(`(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(name) + `($|[^\p{L}\p{N}])`)
+		if !re.MatchString(s) {
+			continue
+		}
+
+		s = re.ReplaceAllString(s, "${1}"+placeholder(len(names))+"${2}")
+		names = append(names, name)
+	}
+
+	return s, names
+}
+
+// placeholder is delimited by private use runes, they never occur in text.
+func placeholder(i int) string {
+	return "\uE000" + strconv.Itoa(i) + "\uE001"
+}
+
+func restore(s string, names []string) string {
+	for i, name := range names {
+		s = strings.ReplaceAll(s, placeholder(i), name)
+	}
+
+	return s
+}
+
+// parseDay returns the day mentioned in s (today by default) and s without it.
+func parseDay(s string, now time.Time) (time.Time, string, error) {
+	day := now
+
+	if m := dateRe.FindStringSubmatch(s); m != nil {
+		parsed, err := time.ParseInLocation(time.DateOnly, m[1], now.Location())
+		if err != nil {
+			return time.Time{}, "", ErrInvalidDate
+		}
+		return parsed, dateRe.ReplaceAllString(s, " "), nil
+	}
+
+	if m := dayRe.FindStringSubmatch(s); m != nil {
+		if m[1] == "yesterday" {
+			day = now.AddDate(0, 0, -1)
+		}
+		s = dayRe.ReplaceAllString(s, " ")
+	}
+
+	return day, s, nil
+}
+
+// parseClock returns hour and minute mentioned in s, nil if there is none.
+func parseClock(s string) ([]int, string, error) {
+	if m := clock12Re.FindStringSubmatch(s); m != nil {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour < 1 || hour > 12 || minute > 59 {
+			return nil, "", ErrInvalidTime
+		}
+
+		hour %= 12
+		if m[3] == "pm" {
+			hour += 12
+		}
+
+		return []int{hour, minute}, clock12Re.ReplaceAllString(s, " "), nil
+	}
+
+	for _, re := range []*regexp.Regexp{clock24Re, atHourRe} {
+		m := re.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+
+		hour, _ := strconv.Atoi(m[1])
+		var minute int
+		if len(m) > 2 {
+			minute, _ = strconv.Atoi(m[2])
+		}
+		if hour > 23 || minute > 59 {
+			return nil, "", ErrInvalidTime
+		}
+
+		return []int{hour, minute}, re.ReplaceAllString(s, " "), nil
+	}
+
+	return nil, s, nil
+}
+
+// energy converts an energy mention to kcal.
+func energy(amount string, unit string) *int {
+	value := math.Round(number(amount))
+	if unit == "kj" {
+		value = math.Round(value / models.KjPerKcal)
+	}
+
+	kcal := int(value)
+	return &kcal
+}
+
+func number(s string) float64 {
+	if n, ok := numberWords[s]; ok {
+		return n
+	}
+
+	n, _ := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	return n
+}
+
+func trimFiller(s string) string {
+	words := strings.Fields(s)
+	for len(words) > 0 && fillerWords[words[0]] {
+		words = words[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// quantity splits an item text into the amount and the name. Without
+// a unit the amount is a count, grams is zero.
+func quantity(s string) (count float64, grams float64, name string) {
+	if m := trailQtyRe.FindStringSubmatch(s); m != nil {
+		return 0, weight(number(m[2]), m[3]), m[1]
+	}
+
+	m := leadQtyRe.FindStringSubmatch(s)
+	if m == nil || m[3] == "" {
+		return 1, 0, s
+	}
+
+	amount := number(m[1])
+	switch m[2] {
+	case "kg", "g", "gr", "gram", "grams":
+		return 0, weight(amount, m[2]), m[3]
+	default:
+		return amount, 0, m[3]
+	}
+}
+
+func weight(amount float64, unit string) float64 {
+	if unit == "kg" {
+		return amount * 1000
+	}
+
+	return amount
+}
+
+// match resolves the item against the catalog, foods before recipes:
+// same name, then the shortest name containing all item words, then
+// the longest name contained in the item.
+func match(
+	item models.QuickAddItem,
+	foods []models.Food,
+	recipes []models.Recipe,
+) models.QuickAddItem {
+	count, grams, name := quantity(item.Text)
+
+	words := key(name)
+	if len(words) == 0 {
+		return item
+	}
+
+	type candidate struct {
+		words  []string
+		food   *models.Food
+		recipe *models.Recipe
+	}
+
+	var candidates []candidate
+	for i := range foods {
+		candidates = append(candidates, candidate{words: key(foods[i].Name), food: &foods[i]})
+	}
+	for i := range recipes {
+		candidates = append(candidates, candidate{words: key(recipes[i].Name), recipe: &recipes[i]})
+	}
+
+	best := -1
+	bestLevel := 0
+	for i, c := range candidates {
+		if len(c.words) == 0 {
+			continue
+		}
+
+		var level int
+		switch {
+		case strings.Join(c.words, " ") == strings.Join(words, " "):
+			level = 3
+		case contains(c.words, words):
+			level = 2
+		case contains(words, c.words):
+			level = 1
+		default:
+			continue
+		}
+
+		if level < bestLevel {
+			continue
+		}
+
+		if level == bestLevel {
+			// Closest name within the level, earlier catalog entries win ties
+			shorter := len(c.words) < len(candidates[best].words)
+			longer := len(c.words) > len(candidates[best].words)
+			if !(level == 2 && shorter) && !(level == 1 && longer) {
+				continue
+			}
+		}
+
+		best, bestLevel = i, level
+	}
+
+	if best < 0 {
+		return item
+	}
+
+	c := candidates[best]
+	if c.food != nil {
+		item.FoodId = &c.food.Id
+		item.Name = c.food.Name
+		item.Grams = grams
+		if grams == 0 {
+			item.Grams = count * PortionGrams
+		}
+		return item
+	}
+
+	item.RecipeId = &c.recipe.Id
+	item.Name = c.recipe.Name
+	item.Servings = count
+	if grams > 0 {
+		item.Servings = servings(*c.recipe, grams)
+	}
+
+	return item
+}
+
+// servings converts grams of a recipe to servings by the ingredients weight.
+func servings(recipe models.Recipe, grams float64) float64 {
+	var total float64
+	for _, ingredient := range recipe.Ingredients {
+		total += ingredient.Grams
+	}
+
+	if total == 0 || recipe.Servings == 0 {
+		return 1
+	}
+
+	return math.Round(grams/(total/float64(recipe.Servings))*100) / 100
+}
+
+// key is the normalized word list of a name, words are singular.
+func key(name string) []string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for i, word := range words {
+		words[i] = singular(word)
+	}
+
+	return words
+}
+
+func singular(word string) string {
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"),
+		strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "sses"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// contains reports whether all words of sub are in words.
+func contains(words []string, sub []string) bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+
+	for _, word := range sub {
+		if !set[word] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,153 @@
+package quickadd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/quickadd"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	loc = time.FixedZone("UTC+3", 3*60*60)
+	now = time.Date(2024, 4, 10, 14, 25, 0, 0, loc)
+)
+
+var foods = []models.Food{
+	{Id: 1, Name: "Egg", Value: 155},
+	{Id: 2, Name: "Whole grain toast", Value: 250},
+	{Id: 3, Name: "Toast", Value: 265},
+	{Id: 4, Name: "Rice", Value: 130},
+	{Id: 5, Name: "Mac and cheese", Value: 160},
+	{Id: 6, Name: "Breakfast burrito", Value: 210},
+}
+
+var recipes = []models.Recipe{
+	{
+		Id:       1,
+		Name:     "Chicken curry",
+		Servings: 4,
+		Ingredients: []models.RecipeIngredient{
+			{FoodId: 7, Grams: 600},
+			{FoodId: 8, Grams: 400},
+		},
+	},
+}
+
+func id(value int64) *int64 {
+	return &value
+}
+
+func kcal(value int) *int {
+	return &value
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want models.QuickAdd
+	}{
+		{
+			name: "Total value, time and day",
+			text: "2 eggs and toast 350kcal at 8am yesterday",
+			want: models.QuickAdd{
+				DateRecord: time.Date(2024, 4, 9, 8, 0, 0, 0, loc),
+				Value:      kcal(350),
+				Items: []models.QuickAddItem{
+					{Text: "2 eggs", Name: "Egg", FoodId: id(1), Grams: 200},
+					{Text: "toast", Name: "Toast", FoodId: id(3), Grams: 100},
+				},
+			},
+		},
+		{
+			name: "Item values in kJ, 24h time",
+			text: "Rice 150g 800 kJ, toast 300kcal 20:15",
+			want: models.QuickAdd{
+				DateRecord: time.Date(2024, 4, 10, 20, 15, 0, 0, loc),
+				Items: []models.QuickAddItem{
+					{Text: "rice 150g", Name: "Rice", FoodId: id(4), Grams: 150, Value: kcal(191)},
+					{Text: "toast", Name: "Toast", FoodId: id(3), Grams: 100, Value: kcal(300)},
+				},
+			},
+		},
+		{
+			name: "Meal hour, recipe servings and grams",
+			text: "I had 2 servings of chicken curry with 250 g chicken curry for dinner",
+			want: models.QuickAdd{
+				DateRecord: time.Date(2024, 4, 10, 19, 0, 0, 0, loc),
+				Meal:       models.MealDinner,
+				Items: []models.QuickAddItem{
+					{Text: "2 servings of chicken curry", Name: "Chicken curry", RecipeId: id(1), Servings: 2},
+					{Text: "250 g chicken curry", Name: "Chicken curry", RecipeId: id(1), Servings: 1},
+				},
+			},
+		},
+		{
+			name: "Catalog names keep separators and keywords",
+			text: "half a mac and cheese + breakfast burrito on 2024-04-01",
+			want: models.QuickAdd{
+				DateRecord: time.Date(2024, 4, 1, 14, 25, 0, 0, loc),
+				Items: []models.QuickAddItem{
+					{Text: "half a mac and cheese", Name: "Mac and cheese", FoodId: id(5), Grams: 50},
+					{Text: "breakfast burrito", Name: "Breakfast burrito", FoodId: id(6), Grams: 100},
+				},
+			},
+		},
+		{
+			name: "Partial names and unmatched items",
+			text: "buttered toast, grain toast and 1.5kg of pickles",
+			want: models.QuickAdd{
+				DateRecord: now,
+				Items: []models.QuickAddItem{
+					{Text: "buttered toast", Name: "Toast", FoodId: id(3), Grams: 100},
+					{Text: "grain toast", Name: "Whole grain toast", FoodId: id(2), Grams: 100},
+					{Text: "1.5kg of pickles"},
+				},
+			},
+		},
+		{
+			name: "Value only",
+			text: "450 kcal for lunch",
+			want: models.QuickAdd{
+				DateRecord: time.Date(2024, 4, 10, 13, 0, 0, 0, loc),
+				Meal:       models.MealLunch,
+				Value:      kcal(450),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := quickadd.Parse(tc.text, now, foods, recipes)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, result)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := quickadd.Parse("  ", now, foods, recipes)
+	require.ErrorIs(t, err, quickadd.ErrEmptyText)
+
+	_, err = quickadd.Parse("yesterday at 9pm", now, foods, recipes)
+	require.ErrorIs(t, err, quickadd.ErrNoItems)
+
+	_, err = quickadd.Parse("toast at 13pm", now, foods, recipes)
+	require.ErrorIs(t, err, quickadd.ErrInvalidTime)
+
+	_, err = quickadd.Parse("toast on 2024-02-30", now, foods, recipes)
+	require.ErrorIs(t, err, quickadd.ErrInvalidDate)
+}
+
+func TestParseIsDeterministic(t *testing.T) {
+	first, err := quickadd.Parse("egg, toast and rice 100kcal, 200kcal", now, foods, recipes)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		result, err := quickadd.Parse("egg, toast and rice 100kcal, 200kcal", now, foods, recipes)
+		require.NoError(t, err)
+		require.Equal(t, first, result)
+	}
+}
@@ -0,0 +1,244 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/pdf"
+)
+
+const (
+	marginX    = 50.0
+	contentW   = pdf.A4Width - 2*marginX
+	rowHeight  = 14.0
+	fontSize   = 10.0
+	titleSize  = 18.0
+	headerSize = 13.0
+
+	chartHeight = 220.0
+)
+
+var (
+	colorOk   = pdf.Color{R: 0.26, G: 0.63, B: 0.28}
+	colorOver = pdf.Color{R: 0.9, G: 0.22, B: 0.21}
+	colorLine = pdf.Color{R: 0.26, G: 0.65, B: 0.96}
+	colorGrid = pdf.Color{R: 0.88, G: 0.88, B: 0.88}
+	colorRow  = pdf.Color{R: 0.96, G: 0.96, B: 0.96}
+)
+
+// RenderMonthly writes the monthly report as a two pages PDF:
+// summary with daily totals, then weight trend and top foods.
+func RenderMonthly(w io.Writer, report models.MonthlyReport) error {
+	doc := pdf.New()
+
+	page := doc.AddPage()
+	y := 60.0
+
+	page.Text(
+		marginX, y, titleSize, pdf.HelveticaBold, pdf.Black,
+		"Monthly nutrition report - "+report.Month.Format("January 2006"),
+	)
+	y += 30
+
+	y = summary(page, y, report.Intake)
+	y += 20
+
+	dailyTotals(page, y, report.Intake)
+
+	page = doc.AddPage()
+	y = 60.0
+
+	y = weightTrend(page, y, report.Weights)
+	y += 30
+
+	topFoods(page, y, report.TopFoods, report.Intake.EnergyUnit.OrDefault())
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func summary(page *pdf.Page, y float64, intake models.IntakeStats) float64 {
+	days := len(intake.Days)
+	unit := intake.EnergyUnit.OrDefault()
+
+	adherence := "-"
+	if intake.DaysLogged > 0 {
+		adherence = fmt.Sprintf(
+			"%.0f%%",
+			float64(intake.DaysOnTarget)/float64(intake.DaysLogged)*100,
+		)
+	}
+
+	rows := [][2]string{
+		{"Daily limit", fmt.Sprintf("%d %s", intake.DailyLimit, unit)},
+		{"Days logged", fmt.Sprintf("%d of %d", intake.DaysLogged, days)},
+		{"Days within limit", fmt.Sprintf("%d (%s)", intake.DaysOnTarget, adherence)},
+		{"Days over limit", fmt.Sprintf("%d", intake.DaysLogged-intake.DaysOnTarget)},
+		{"Average intake", fmt.Sprintf("%d %s", intake.Average, unit)},
+		{"Total intake", fmt.Sprintf("%d %s", intake.Total, unit)},
+	}
+
+	page.Text(marginX, y, headerSize, pdf.HelveticaBold, pdf.Black, "Summary")
+	y += 20
+
+	for _, row := range rows {
+		page.Text(marginX, y, fontSize, pdf.Helvetica, pdf.Black, row[0])
+		page.Text(marginX+150, y, fontSize, pdf.HelveticaBold, pdf.Black, row[1])
+		y += rowHeight
+	}
+
+	return y
+}
+
+func dailyTotals(page *pdf.Page, y float64, intake models.IntakeStats) float64 {
+	page.Text(marginX, y, headerSize, pdf.HelveticaBold, pdf.Black, "Daily totals")
+	y += 20
+
+	columns := []float64{marginX + 4, marginX + 220, marginX + 330, marginX + 360}
+
+	page.Text(columns[0], y, fontSize, pdf.HelveticaBold, pdf.Black, "Date")
+	page.TextRight(columns[1], y, fontSize, pdf.HelveticaBold, pdf.Black, "Intake, "+string(intake.EnergyUnit.OrDefault()))
+	page.Text(columns[3], y, fontSize, pdf.HelveticaBold, pdf.Black, "Status")
+	page.Line(marginX, y+4, marginX+contentW, y+4, 0.5, pdf.Gray)
+	y += rowHeight
+
+	for i, day := range intake.Days {
+		if i%2 == 1 {
+			page.Rect(marginX, y-rowHeight+3.5, contentW, rowHeight, colorRow)
+		}
+
+		page.Text(columns[0], y, fontSize, pdf.Helvetica, pdf.Black, day.Day.Format("Mon, 02 Jan"))
+
+		switch {
+		case day.RecordsCount == 0:
+			page.TextRight(columns[1], y, fontSize, pdf.Helvetica, pdf.Gray, "-")
+			page.Text(columns[3], y, fontSize, pdf.Helvetica, pdf.Gray, "not logged")
+		case day.Value <= intake.DailyLimit:
+			page.TextRight(columns[1], y, fontSize, pdf.Helvetica, pdf.Black, fmt.Sprint(day.Value))
+			page.Text(columns[3], y, fontSize, pdf.Helvetica, colorOk, "within limit")
+		default:
+			page.TextRight(columns[1], y, fontSize, pdf.Helvetica, pdf.Black, fmt.Sprint(day.Value))
+			page.Text(
+				columns[3], y, fontSize, pdf.Helvetica, colorOver,
+				fmt.Sprintf("over by %d", day.Value-intake.DailyLimit),
+			)
+		}
+
+		y += rowHeight
+	}
+
+	return y
+}
+
+func weightTrend(page *pdf.Page, y float64, weights []models.Weight) float64 {
+	page.Text(marginX, y, headerSize, pdf.HelveticaBold, pdf.Black, "Weight trend")
+	y += 20
+
+	if len(weights) == 0 {
+		page.Text(marginX, y, fontSize, pdf.Helvetica, pdf.Gray, "No weigh-ins this month")
+		return y + rowHeight
+	}
+
+	first, last := weights[0], weights[len(weights)-1]
+	page.Text(
+		marginX, y, fontSize, pdf.Helvetica, pdf.Black,
+		fmt.Sprintf(
+			"Start %.1f kg, end %.1f kg, change %+.1f kg",
+			first.Value, last.Value, last.Value-first.Value,
+		),
+	)
+	y += 16
+
+	lo, hi := first.Value, first.Value
+	for _, weight := range weights {
+		lo = math.Min(lo, weight.Value)
+		hi = math.Max(hi, weight.Value)
+	}
+	lo, hi = math.Floor(lo-1), math.Ceil(hi+1)
+
+	const axisW = 40.0
+
+	left, right := marginX+axisW, marginX+contentW
+	top, bottom := y, y+chartHeight
+
+	span := last.DateRecord.Sub(first.DateRecord).Seconds()
+
+	x := func(weight models.Weight) float64 {
+		if span == 0 {
+			return (left + right) / 2
+		}
+		return left + (right-left)*weight.DateRecord.Sub(first.DateRecord).Seconds()/span
+	}
+	yOf := func(value float64) float64 {
+		return bottom - (value-lo)/(hi-lo)*(bottom-top)
+	}
+
+	const ticks = 4
+	for i := 0; i <= ticks; i++ {
+		value := lo + (hi-lo)*float64(i)/ticks
+		page.Line(left, yOf(value), right, yOf(value), 0.5, colorGrid)
+		page.TextRight(left-6, yOf(value)+3, fontSize-1, pdf.Helvetica, pdf.Gray, fmt.Sprintf("%.1f", value))
+	}
+
+	points := make([][2]float64, 0, len(weights))
+	for _, weight := range weights {
+		points = append(points, [2]float64{x(weight), yOf(weight.Value)})
+	}
+
+	page.Polyline(points, 1.5, colorLine)
+	for _, point := range points {
+		page.Rect(point[0]-2, point[1]-2, 4, 4, colorLine)
+	}
+
+	page.Line(left, bottom, right, bottom, 0.5, pdf.Gray)
+	page.Text(left, bottom+14, fontSize-1, pdf.Helvetica, pdf.Gray, first.DateRecord.Format("02 Jan"))
+	page.TextRight(right, bottom+14, fontSize-1, pdf.Helvetica, pdf.Gray, last.DateRecord.Format("02 Jan"))
+
+	return bottom + 14
+}
+
+func topFoods(
+	page *pdf.Page,
+	y float64,
+	foods []models.FoodTotal,
+	unit models.EnergyUnit,
+) float64 {
+	page.Text(marginX, y, headerSize, pdf.HelveticaBold, pdf.Black, "Top foods")
+	y += 20
+
+	if len(foods) == 0 {
+		page.Text(marginX, y, fontSize, pdf.Helvetica, pdf.Gray, "No described records this month")
+		return y + rowHeight
+	}
+
+	columns := []float64{marginX + 4, marginX + 380, marginX + contentW - 4}
+
+	page.Text(columns[0], y, fontSize, pdf.HelveticaBold, pdf.Black, "Food")
+	page.TextRight(columns[1], y, fontSize, pdf.HelveticaBold, pdf.Black, "Times")
+	page.TextRight(columns[2], y, fontSize, pdf.HelveticaBold, pdf.Black, "Total, "+string(unit))
+	page.Line(marginX, y+4, marginX+contentW, y+4, 0.5, pdf.Gray)
+	y += rowHeight
+
+	for i, food := range foods {
+		if i%2 == 1 {
+			page.Rect(marginX, y-rowHeight+3.5, contentW, rowHeight, colorRow)
+		}
+
+		page.Text(columns[0], y, fontSize, pdf.Helvetica, pdf.Black, truncate(food.Name, 60))
+		page.TextRight(columns[1], y, fontSize, pdf.Helvetica, pdf.Black, fmt.Sprint(food.RecordsCount))
+		page.TextRight(columns[2], y, fontSize, pdf.Helvetica, pdf.Black, fmt.Sprint(food.Value))
+		y += rowHeight
+	}
+
+	return y
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
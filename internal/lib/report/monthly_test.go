@@ -0,0 +1,66 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/report"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMonthly(t *testing.T) {
+	month := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name   string
+		report models.MonthlyReport
+	}{
+		{
+			name: "full report",
+			report: models.MonthlyReport{
+				Month: month,
+				Intake: models.IntakeStats{
+					From:         month,
+					To:           month.AddDate(0, 0, 1),
+					DailyLimit:   2000,
+					Total:        4200,
+					Average:      2100,
+					DaysLogged:   2,
+					DaysOnTarget: 1,
+					Days: []models.DailyTotal{
+						{Day: month, Value: 1800, RecordsCount: 3},
+						{Day: month.AddDate(0, 0, 1), Value: 2400, RecordsCount: 4},
+					},
+				},
+				Weights: []models.Weight{
+					{Value: 80.5, DateRecord: month},
+					{Value: 79.9, DateRecord: month.AddDate(0, 0, 7)},
+				},
+				TopFoods: []models.FoodTotal{{Name: "Oatmeal (with berries)", Value: 900, RecordsCount: 3}},
+			},
+		},
+		{
+			name:   "empty month",
+			report: models.MonthlyReport{Month: month},
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, report.RenderMonthly(&buf, tc.report))
+
+			out := buf.String()
+			require.True(t, strings.HasPrefix(out, "%PDF-"))
+			require.Contains(t, out, "/Count 2")
+		})
+	}
+}
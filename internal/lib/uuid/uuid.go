@@ -0,0 +1,33 @@
+// Package uuid generates and validates random (version 4) UUIDs in the
+// canonical lowercase form.
+package uuid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+)
+
+var uuidRe = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// New returns a random UUID.
+func New() string {
+	var b [16]byte
+
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	s := hex.EncodeToString(b[:])
+
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// Valid reports whether s is a UUID of any version in the canonical
+// lowercase form.
+func Valid(s string) bool {
+	return uuidRe.MatchString(s)
+}
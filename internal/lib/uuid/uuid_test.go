@@ -0,0 +1,43 @@
+package uuid_test
+
+import (
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	require.True(t, uuid.Valid(a))
+	require.NotEqual(t, a, b)
+	require.Equal(t, byte('4'), a[14])
+	require.Contains(t, "89ab", string(a[19]))
+}
+
+func TestValid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "version 4", value: "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c", expected: true},
+		{name: "version 1", value: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", expected: true},
+		{name: "uppercase", value: "0B8F8D3E-4C1E-4F0A-9B7E-3F1C2D5A6B7C", expected: false},
+		{name: "no dashes", value: "0b8f8d3e4c1e4f0a9b7e3f1c2d5a6b7c", expected: false},
+		{name: "braces", value: "{0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c}", expected: false},
+		{name: "empty", value: "", expected: false},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, uuid.Valid(tc.value))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/go-chi/jwtauth"
 	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
@@ -26,12 +27,37 @@ type AccountProvider interface {
 //go:generate go run github.com/vektra/mockery/v2@v2.42.2 --name=AccountSaver
 type AccountSaver interface {
 	SaveAccount(ctx context.Context, userId int64) (uid int64, err error)
+	UpdateAccountProfile(
+		ctx context.Context,
+		accountId int64,
+		version int64,
+		profile models.Profile,
+	) error
+	UpdateAccountEnergyUnit(
+		ctx context.Context,
+		accountId int64,
+		version int64,
+		unit models.EnergyUnit,
+	) error
+	UpdateAccountMacroTargets(
+		ctx context.Context,
+		accountId int64,
+		version int64,
+		targets models.MacroTargets,
+	) error
 }
 
 var (
 	ErrAccountNotFound = errors.New("account not found")
 	ErrAccountExists   = errors.New("account exists")
 	ErrInvalidJWT      = errors.New("invalid jwt")
+
+	ErrInvalidBirthDate  = errors.New("invalid birth date")
+	ErrInvalidEnergyUnit = errors.New("invalid energy unit")
+
+	ErrInvalidMacroTargets = errors.New("invalid macro targets")
+
+	ErrVersionMismatch = errors.New("version mismatch")
 )
 
 func New(
@@ -125,3 +151,127 @@ func (a *Account) GetAccountByContextJWT(ctx context.Context) (models.Account, e
 
 	return a.GetAccountByUserId(ctx, userId)
 }
+
+// UpdateProfileForCurrentUser stores sex and birth date used for reference intakes.
+// Zero version skips the optimistic concurrency check, the same for all updates below.
+func (a *Account) UpdateProfileForCurrentUser(
+	ctx context.Context,
+	version int64,
+	profile models.Profile,
+) (models.Account, error) {
+	const op = "services.account.UpdateProfileForCurrentUser"
+
+	log := a.log.With(slog.String("op", op))
+
+	acc, err := a.GetAccountByContextJWT(ctx)
+	if err != nil {
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !profile.BirthDate.Before(time.Now()) {
+		return models.Account{}, fmt.Errorf("%s: %w", op, ErrInvalidBirthDate)
+	}
+
+	if version == 0 {
+		version = acc.Version
+	}
+
+	if err := a.accountSaver.UpdateAccountProfile(ctx, acc.Id, version, profile); err != nil {
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("account version mismatch", slog.Int64("version", version))
+			return models.Account{}, fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
+		log.Error("failed to update account profile", slog.String("err", err.Error()))
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	acc.Version = version + 1
+
+	acc.Sex = profile.Sex
+	acc.BirthDate = &profile.BirthDate
+
+	return acc, nil
+}
+
+// UpdateEnergyUnitForCurrentUser sets the unit energy values are accepted
+// and rendered in, stored values are not changed.
+func (a *Account) UpdateEnergyUnitForCurrentUser(
+	ctx context.Context,
+	version int64,
+	unit models.EnergyUnit,
+) (models.Account, error) {
+	const op = "services.account.UpdateEnergyUnitForCurrentUser"
+
+	log := a.log.With(slog.String("op", op))
+
+	acc, err := a.GetAccountByContextJWT(ctx)
+	if err != nil {
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if unit != models.EnergyUnitKcal && unit != models.EnergyUnitKj {
+		return models.Account{}, fmt.Errorf("%s: %w", op, ErrInvalidEnergyUnit)
+	}
+
+	if version == 0 {
+		version = acc.Version
+	}
+
+	if err := a.accountSaver.UpdateAccountEnergyUnit(ctx, acc.Id, version, unit); err != nil {
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("account version mismatch", slog.Int64("version", version))
+			return models.Account{}, fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
+		log.Error("failed to update energy unit", slog.String("err", err.Error()))
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	acc.Version = version + 1
+
+	acc.EnergyUnit = unit
+
+	return acc, nil
+}
+
+// UpdateMacroTargetsForCurrentUser sets daily macronutrient targets in grams,
+// zero target is not planned for.
+func (a *Account) UpdateMacroTargetsForCurrentUser(
+	ctx context.Context,
+	version int64,
+	targets models.MacroTargets,
+) (models.Account, error) {
+	const op = "services.account.UpdateMacroTargetsForCurrentUser"
+
+	log := a.log.With(slog.String("op", op))
+
+	acc, err := a.GetAccountByContextJWT(ctx)
+	if err != nil {
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if targets.Carbs < 0 || targets.Protein < 0 || targets.Fat < 0 {
+		return models.Account{}, fmt.Errorf("%s: %w", op, ErrInvalidMacroTargets)
+	}
+
+	if version == 0 {
+		version = acc.Version
+	}
+
+	if err := a.accountSaver.UpdateAccountMacroTargets(ctx, acc.Id, version, targets); err != nil {
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("account version mismatch", slog.Int64("version", version))
+			return models.Account{}, fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
+		log.Error("failed to update macro targets", slog.String("err", err.Error()))
+		return models.Account{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	acc.Version = version + 1
+
+	acc.MacroTargets = targets
+
+	return acc, nil
+}
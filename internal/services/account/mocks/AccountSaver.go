@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/karmaplush/simple-diet-tracker/internal/domain/models"
 )
 
 // AccountSaver is an autogenerated mock type for the AccountSaver type
@@ -41,6 +43,60 @@ func (_m *AccountSaver) SaveAccount(ctx context.Context, userId int64) (int64, e
 	return r0, r1
 }
 
+// UpdateAccountEnergyUnit provides a mock function with given fields: ctx, accountId, version, unit
+func (_m *AccountSaver) UpdateAccountEnergyUnit(ctx context.Context, accountId int64, version int64, unit models.EnergyUnit) error {
+	ret := _m.Called(ctx, accountId, version, unit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountEnergyUnit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.EnergyUnit) error); ok {
+		r0 = rf(ctx, accountId, version, unit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAccountMacroTargets provides a mock function with given fields: ctx, accountId, version, targets
+func (_m *AccountSaver) UpdateAccountMacroTargets(ctx context.Context, accountId int64, version int64, targets models.MacroTargets) error {
+	ret := _m.Called(ctx, accountId, version, targets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountMacroTargets")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.MacroTargets) error); ok {
+		r0 = rf(ctx, accountId, version, targets)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAccountProfile provides a mock function with given fields: ctx, accountId, version, profile
+func (_m *AccountSaver) UpdateAccountProfile(ctx context.Context, accountId int64, version int64, profile models.Profile) error {
+	ret := _m.Called(ctx, accountId, version, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, models.Profile) error); ok {
+		r0 = rf(ctx, accountId, version, profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAccountSaver creates a new instance of AccountSaver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAccountSaver(t interface {
@@ -0,0 +1,114 @@
+package achievement
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Achievement struct {
+	log                 *slog.Logger
+	rules               []Rule
+	achievementProvider AchievementProvider
+	achievementSaver    AchievementSaver
+	accountProvider     AccountProvider
+}
+
+type AchievementProvider interface {
+	UnlockedAchievements(ctx context.Context, accountId int64) (map[string]time.Time, error)
+}
+
+type AchievementSaver interface {
+	SaveAchievements(
+		ctx context.Context,
+		accountId int64,
+		codes []string,
+		unlockedAt time.Time,
+	) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+func New(
+	log *slog.Logger,
+	rules []Rule,
+	achievementProvider AchievementProvider,
+	achievementSaver AchievementSaver,
+	accountProvider AccountProvider,
+) *Achievement {
+	return &Achievement{
+		log:                 log,
+		rules:               rules,
+		achievementProvider: achievementProvider,
+		achievementSaver:    achievementSaver,
+		accountProvider:     accountProvider,
+	}
+}
+
+func (a *Achievement) GetAchievementsForCurrentUser(
+	ctx context.Context,
+) ([]models.Achievement, error) {
+	const op = "services.achievement.GetAchievementsForCurrentUser"
+
+	log := a.log.With(slog.String("op", op))
+
+	acc, err := a.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get achievements - incorrect token")
+		return []models.Achievement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unlocked, err := a.achievementProvider.UnlockedAchievements(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get achievements", slog.String("err", err.Error()))
+		return []models.Achievement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	achievements := make([]models.Achievement, 0, len(a.rules))
+
+	for _, rule := range a.rules {
+		achievement := models.Achievement{
+			Code:        rule.Code,
+			Title:       rule.Title,
+			Description: rule.Description,
+		}
+
+		if unlockedAt, ok := unlocked[rule.Code]; ok {
+			achievement.Unlocked = true
+			achievement.UnlockedAt = &unlockedAt
+		}
+
+		achievements = append(achievements, achievement)
+	}
+
+	return achievements, nil
+}
+
+// HandleEvents evaluates events against the rule set and persists
+// every matched achievement. Unlocking is idempotent.
+func (a *Achievement) HandleEvents(
+	ctx context.Context,
+	accountId int64,
+	events ...models.AchievementEvent,
+) error {
+	const op = "services.achievement.HandleEvents"
+
+	log := a.log.With(slog.String("op", op), slog.Int64("account_id", accountId))
+
+	codes := Evaluate(a.rules, events...)
+	if len(codes) == 0 {
+		return nil
+	}
+
+	if err := a.achievementSaver.SaveAchievements(ctx, accountId, codes, time.Now()); err != nil {
+		log.Error("failed to save achievements", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package achievement
+
+import "github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+
+// Rule unlocks an achievement once an event of the given type
+// reports a value greater or equal to the threshold.
+type Rule struct {
+	Code        string
+	Title       string
+	Description string
+	Event       models.AchievementEventType
+	Threshold   int
+}
+
+var DefaultRules = []Rule{
+	{
+		Code:        "first_record",
+		Title:       "First bite",
+		Description: "Log your first record",
+		Event:       models.EventRecordCreated,
+		Threshold:   1,
+	},
+	{
+		Code:        "records_100",
+		Title:       "Centurion",
+		Description: "Log 100 records",
+		Event:       models.EventRecordCreated,
+		Threshold:   100,
+	},
+	{
+		Code:        "records_1000",
+		Title:       "Bookkeeper",
+		Description: "Log 1000 records",
+		Event:       models.EventRecordCreated,
+		Threshold:   1000,
+	},
+	{
+		Code:        "logging_streak_7",
+		Title:       "One week in",
+		Description: "Log records 7 days in a row",
+		Event:       models.EventLoggingStreak,
+		Threshold:   7,
+	},
+	{
+		Code:        "logging_streak_30",
+		Title:       "Habit formed",
+		Description: "Log records 30 days in a row",
+		Event:       models.EventLoggingStreak,
+		Threshold:   30,
+	},
+	{
+		Code:        "logging_streak_100",
+		Title:       "Unstoppable",
+		Description: "Log records 100 days in a row",
+		Event:       models.EventLoggingStreak,
+		Threshold:   100,
+	},
+	{
+		Code:        "on_target_streak_7",
+		Title:       "On target",
+		Description: "Stay within the daily limit 7 days in a row",
+		Event:       models.EventOnTargetStreak,
+		Threshold:   7,
+	},
+	{
+		Code:        "on_target_streak_30",
+		Title:       "Iron will",
+		Description: "Stay within the daily limit 30 days in a row",
+		Event:       models.EventOnTargetStreak,
+		Threshold:   30,
+	},
+	{
+		Code:        "weight_lost_1",
+		Title:       "First kilogram",
+		Description: "Lose 1 kg since your first weigh-in",
+		Event:       models.EventWeightMilestone,
+		Threshold:   1,
+	},
+	{
+		Code:        "weight_lost_5",
+		Title:       "Lighter",
+		Description: "Lose 5 kg since your first weigh-in",
+		Event:       models.EventWeightMilestone,
+		Threshold:   5,
+	},
+	{
+		Code:        "weight_lost_10",
+		Title:       "New me",
+		Description: "Lose 10 kg since your first weigh-in",
+		Event:       models.EventWeightMilestone,
+		Threshold:   10,
+	},
+}
+
+// Evaluate returns codes of the rules matched by the events.
+func Evaluate(rules []Rule, events ...models.AchievementEvent) []string {
+	var codes []string
+
+	for _, rule := range rules {
+		for _, event := range events {
+			if rule.Event == event.Type && event.Value >= rule.Threshold {
+				codes = append(codes, rule.Code)
+				break
+			}
+		}
+	}
+
+	return codes
+}
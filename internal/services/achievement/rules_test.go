@@ -0,0 +1,73 @@
+package achievement_test
+
+import (
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/achievement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		events        []models.AchievementEvent
+		expectedCodes []string
+	}{
+		{
+			name:          "no events",
+			events:        nil,
+			expectedCodes: nil,
+		},
+		{
+			name: "first record",
+			events: []models.AchievementEvent{
+				{Type: models.EventRecordCreated, Value: 1},
+			},
+			expectedCodes: []string{"first_record"},
+		},
+		{
+			name: "streaks below threshold",
+			events: []models.AchievementEvent{
+				{Type: models.EventLoggingStreak, Value: 6},
+				{Type: models.EventOnTargetStreak, Value: 3},
+			},
+			expectedCodes: nil,
+		},
+		{
+			name: "several thresholds reached at once",
+			events: []models.AchievementEvent{
+				{Type: models.EventRecordCreated, Value: 150},
+				{Type: models.EventLoggingStreak, Value: 30},
+				{Type: models.EventOnTargetStreak, Value: 7},
+			},
+			expectedCodes: []string{
+				"first_record",
+				"records_100",
+				"logging_streak_7",
+				"logging_streak_30",
+				"on_target_streak_7",
+			},
+		},
+		{
+			name: "weight milestone",
+			events: []models.AchievementEvent{
+				{Type: models.EventWeightMilestone, Value: 5},
+			},
+			expectedCodes: []string{"weight_lost_1", "weight_lost_5"},
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			codes := achievement.Evaluate(achievement.DefaultRules, tc.events...)
+
+			require.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Analytics struct {
+	log             *slog.Logger
+	recordProvider  RecordProvider
+	accountProvider AccountProvider
+}
+
+type RecordProvider interface {
+	RecordsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.Record, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	// Late night is [lateNightStartHour, 24) and [0, lateNightEndHour)
+	lateNightStartHour = 21
+	lateNightEndHour   = 5
+)
+
+func New(
+	log *slog.Logger,
+	recordProvider RecordProvider,
+	accountProvider AccountProvider,
+) *Analytics {
+	return &Analytics{
+		log:             log,
+		recordProvider:  recordProvider,
+		accountProvider: accountProvider,
+	}
+}
+
+// GetMealTimingForCurrentUser reports meal timing for days from..to inclusive.
+// Hours and days are taken in the location of from.
+func (a *Analytics) GetMealTimingForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (models.MealTiming, error) {
+	const op = "services.analytics.GetMealTimingForCurrentUser"
+
+	log := a.log.With(slog.String("op", op))
+
+	acc, err := a.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get meal timing - incorrect token")
+		return models.MealTiming{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records, err := a.recordProvider.RecordsByAccountIdInRange(
+		ctx,
+		acc.Id,
+		from,
+		to.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		log.Error("failed to get records", slog.String("err", err.Error()))
+		return models.MealTiming{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return MealTiming(records, from.Location()).In(acc.EnergyUnit), nil
+}
+
+// MealTiming aggregates records sorted by date into chart-ready series.
+func MealTiming(records []models.Record, loc *time.Location) models.MealTiming {
+	timing := models.MealTiming{
+		ByHour:        make([]models.HourBucket, 24),
+		ByMeal:        make([]models.MealBucket, len(models.Meals)),
+		EatingWindows: []models.EatingWindow{},
+	}
+
+	for hour := range timing.ByHour {
+		timing.ByHour[hour].Hour = hour
+	}
+
+	mealIndex := make(map[models.Meal]int, len(models.Meals))
+	for i, meal := range models.Meals {
+		timing.ByMeal[i].Meal = meal
+		mealIndex[meal] = i
+	}
+
+	for _, record := range records {
+		dateRecord := record.DateRecord.In(loc)
+		hour := dateRecord.Hour()
+
+		timing.Total += record.Value
+		timing.ByHour[hour].Value += record.Value
+
+		meal := record.Meal
+		if _, ok := mealIndex[meal]; !ok {
+			meal = models.MealByHour(hour)
+		}
+		timing.ByMeal[mealIndex[meal]].Value += record.Value
+
+		if hour >= lateNightStartHour || hour < lateNightEndHour {
+			timing.LateNightValue += record.Value
+		}
+
+		day := time.Date(dateRecord.Year(), dateRecord.Month(), dateRecord.Day(), 0, 0, 0, 0, loc)
+
+		last := len(timing.EatingWindows) - 1
+		if last < 0 || !timing.EatingWindows[last].Day.Equal(day) {
+			timing.EatingWindows = append(timing.EatingWindows, models.EatingWindow{
+				Day:   day,
+				First: dateRecord,
+				Last:  dateRecord,
+			})
+			continue
+		}
+
+		timing.EatingWindows[last].Last = dateRecord
+	}
+
+	var windowsMinutes int
+
+	for i := range timing.EatingWindows {
+		window := &timing.EatingWindows[i]
+		window.Minutes = int(window.Last.Sub(window.First).Minutes())
+		windowsMinutes += window.Minutes
+	}
+
+	if len(timing.EatingWindows) > 0 {
+		timing.AverageEatingWindowMinutes = windowsMinutes / len(timing.EatingWindows)
+	}
+
+	for i := range timing.ByHour {
+		timing.ByHour[i].Share = share(timing.ByHour[i].Value, timing.Total)
+	}
+
+	for i := range timing.ByMeal {
+		timing.ByMeal[i].Share = share(timing.ByMeal[i].Value, timing.Total)
+	}
+
+	timing.LateNightShare = share(timing.LateNightValue, timing.Total)
+
+	return timing
+}
+
+// share returns part of total rounded to 4 decimal places.
+func share(value int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(value)/float64(total)*10000) / 10000
+}
@@ -0,0 +1,61 @@
+package analytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/analytics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMealTiming(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, 4, day, hour, minute, 0, 0, loc).UTC()
+	}
+
+	records := []models.Record{
+		{Value: 400, Meal: models.MealBreakfast, DateRecord: at(1, 8, 0)},
+		{Value: 600, Meal: models.MealLunch, DateRecord: at(1, 13, 30)},
+		{Value: 500, Meal: models.MealDinner, DateRecord: at(1, 19, 0)},
+		{Value: 500, Meal: "", DateRecord: at(1, 23, 0)},
+		{Value: 1000, Meal: models.MealLunch, DateRecord: at(2, 12, 0)},
+	}
+
+	timing := analytics.MealTiming(records, loc)
+
+	require.Equal(t, 3000, timing.Total)
+
+	require.Len(t, timing.ByHour, 24)
+	require.Equal(t, 400, timing.ByHour[8].Value)
+	require.Equal(t, 1000, timing.ByHour[12].Value)
+	require.Equal(t, 500, timing.ByHour[23].Value)
+	require.Equal(t, 0.1667, timing.ByHour[23].Share)
+
+	require.Equal(t, []models.MealBucket{
+		{Meal: models.MealBreakfast, Value: 400, Share: 0.1333},
+		{Meal: models.MealLunch, Value: 1600, Share: 0.5333},
+		{Meal: models.MealDinner, Value: 500, Share: 0.1667},
+		{Meal: models.MealSnack, Value: 500, Share: 0.1667},
+	}, timing.ByMeal)
+
+	require.Len(t, timing.EatingWindows, 2)
+	require.Equal(t, 15*60, timing.EatingWindows[0].Minutes)
+	require.Equal(t, 0, timing.EatingWindows[1].Minutes)
+	require.Equal(t, 15*60/2, timing.AverageEatingWindowMinutes)
+
+	require.Equal(t, 500, timing.LateNightValue)
+	require.Equal(t, 0.1667, timing.LateNightShare)
+}
+
+func TestMealTimingEmpty(t *testing.T) {
+	timing := analytics.MealTiming(nil, time.UTC)
+
+	require.Equal(t, 0, timing.Total)
+	require.Len(t, timing.ByHour, 24)
+	require.Len(t, timing.ByMeal, len(models.Meals))
+	require.Empty(t, timing.EatingWindows)
+	require.Equal(t, 0.0, timing.LateNightShare)
+}
@@ -0,0 +1,216 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/export"
+)
+
+type Export struct {
+	log              *slog.Logger
+	recordIterator   RecordIterator
+	catalogProvider  CatalogProvider
+	trackingProvider TrackingProvider
+	accountProvider  AccountProvider
+}
+
+type RecordIterator interface {
+	EachRecordByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+		fn func(models.Record) error,
+	) error
+}
+
+type CatalogProvider interface {
+	FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error)
+	RecipesByAccountId(ctx context.Context, accountId int64) ([]models.Recipe, error)
+}
+
+type TrackingProvider interface {
+	WeightsByAccountId(ctx context.Context, accountId int64) ([]models.Weight, error)
+	MeasurementsByAccountId(
+		ctx context.Context,
+		accountId int64,
+		measurementType models.MeasurementType,
+	) ([]models.Measurement, error)
+	FastingSessionsByAccountId(ctx context.Context, accountId int64) ([]models.FastingSession, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+func New(
+	log *slog.Logger,
+	recordIterator RecordIterator,
+	catalogProvider CatalogProvider,
+	trackingProvider TrackingProvider,
+	accountProvider AccountProvider,
+) *Export {
+	return &Export{
+		log:              log,
+		recordIterator:   recordIterator,
+		catalogProvider:  catalogProvider,
+		trackingProvider: trackingProvider,
+		accountProvider:  accountProvider,
+	}
+}
+
+// ExportForCurrentUser writes the account, its catalog and data logged in
+// [from, to) with records last, zero bounds leave the range open. Records
+// are streamed, everything else is loaded before the encoder begins, so an
+// error returned before Begin leaves the output untouched. Energy values
+// are in the account energy unit.
+func (e *Export) ExportForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	encoder export.Encoder,
+) error {
+	const op = "services.export.ExportForCurrentUser"
+
+	log := e.log.With(slog.String("op", op))
+
+	acc, err := e.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not export account data - incorrect token")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	sections, err := e.sections(ctx, acc, from, to)
+	if err != nil {
+		log.Error("failed to get account data", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := encoder.Begin(acc.InEnergyUnit()); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, section := range sections {
+		if err := encoder.Section(section.name); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, item := range section.items {
+			if err := encoder.Item(item); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err := encoder.Section(models.ExportRecords); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	count := 0
+
+	err = e.recordIterator.EachRecordByAccountIdInRange(ctx, acc.Id, from, to, func(record models.Record) error {
+		record.Value = acc.EnergyUnit.FromKcal(record.Value)
+		count++
+		return encoder.Item(record)
+	})
+	if err != nil {
+		log.Error("failed to export records", slog.Int("exported", count), slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := encoder.End(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("account data exported", slog.Int64("accountId", acc.Id), slog.Int("records", count))
+
+	return nil
+}
+
+type section struct {
+	name  models.ExportSection
+	items []any
+}
+
+// sections loads exported data besides records.
+func (e *Export) sections(
+	ctx context.Context,
+	acc models.Account,
+	from time.Time,
+	to time.Time,
+) ([]section, error) {
+	foods, err := e.catalogProvider.FoodsByAccountId(ctx, acc.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes, err := e.catalogProvider.RecipesByAccountId(ctx, acc.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	weights, err := e.trackingProvider.WeightsByAccountId(ctx, acc.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	measurements, err := e.trackingProvider.MeasurementsByAccountId(ctx, acc.Id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := e.trackingProvider.FastingSessionsByAccountId(ctx, acc.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	foodItems := make([]any, 0, len(foods))
+	for _, food := range foods {
+		food.Value = acc.EnergyUnit.FromKcal(food.Value)
+		foodItems = append(foodItems, food)
+	}
+
+	recipeItems := make([]any, 0, len(recipes))
+	for _, recipe := range recipes {
+		recipe.Value = acc.EnergyUnit.FromKcal(recipe.Value)
+		recipeItems = append(recipeItems, recipe)
+	}
+
+	weightItems := make([]any, 0, len(weights))
+	for _, weight := range weights {
+		if InRange(weight.DateRecord, from, to) {
+			weightItems = append(weightItems, weight)
+		}
+	}
+
+	measurementItems := make([]any, 0, len(measurements))
+	for _, measurement := range measurements {
+		if InRange(measurement.DateRecord, from, to) {
+			measurementItems = append(measurementItems, measurement)
+		}
+	}
+
+	sessionItems := make([]any, 0, len(sessions))
+	for _, session := range sessions {
+		if InRange(session.StartedAt, from, to) {
+			sessionItems = append(sessionItems, session)
+		}
+	}
+
+	return []section{
+		{name: models.ExportFoods, items: foodItems},
+		{name: models.ExportRecipes, items: recipeItems},
+		{name: models.ExportWeights, items: weightItems},
+		{name: models.ExportMeasurements, items: measurementItems},
+		{name: models.ExportFastingSessions, items: sessionItems},
+	}, nil
+}
+
+// InRange reports whether t is in [from, to), zero bounds are open.
+func InRange(t time.Time, from time.Time, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+}
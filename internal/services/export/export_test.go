@@ -0,0 +1,41 @@
+package export_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/services/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInRange(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		t        time.Time
+		from     time.Time
+		to       time.Time
+		expected bool
+	}{
+		{name: "inside", t: from.AddDate(0, 0, 10), from: from, to: to, expected: true},
+		{name: "from is inclusive", t: from, from: from, to: to, expected: true},
+		{name: "to is exclusive", t: to, from: from, to: to, expected: false},
+		{name: "before", t: from.Add(-time.Second), from: from, to: to, expected: false},
+		{name: "open from", t: from.AddDate(-5, 0, 0), to: to, expected: true},
+		{name: "open to", t: to.AddDate(5, 0, 0), from: from, expected: true},
+		{name: "open range", t: time.Time{}.Add(time.Hour), expected: true},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, export.InRange(tc.t, tc.from, tc.to))
+		})
+	}
+}
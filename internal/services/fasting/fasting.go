@@ -0,0 +1,379 @@
+package fasting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Fasting struct {
+	log             *slog.Logger
+	sessionProvider SessionProvider
+	sessionSaver    SessionSaver
+	recordProvider  RecordProvider
+	accountProvider AccountProvider
+}
+
+type SessionProvider interface {
+	ActiveFastingSession(ctx context.Context, accountId int64) (models.FastingSession, error)
+	FastingSessionsByAccountId(ctx context.Context, accountId int64) ([]models.FastingSession, error)
+}
+
+type SessionSaver interface {
+	SaveFastingSession(ctx context.Context, session models.FastingSession) (int64, error)
+	FinishFastingSession(
+		ctx context.Context,
+		accountId int64,
+		sessionId int64,
+		endedAt time.Time,
+	) error
+}
+
+type RecordProvider interface {
+	RecordsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.Record, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	ProtocolCustom = "custom"
+)
+
+// Protocols are the fasting presets, planned duration is FastingHours.
+var Protocols = []models.FastingProtocol{
+	{Name: "16:8", FastingHours: 16, EatingHours: 8},
+	{Name: "18:6", FastingHours: 18, EatingHours: 6},
+	{Name: "omad", FastingHours: 23, EatingHours: 1},
+}
+
+var (
+	ErrUnknownProtocol   = errors.New("unknown fasting protocol")
+	ErrFastingInProgress = errors.New("fasting already in progress")
+	ErrNoActiveFasting   = errors.New("no active fasting")
+	ErrInvalidEndTime    = errors.New("fasting can not end before it starts")
+)
+
+func New(
+	log *slog.Logger,
+	sessionProvider SessionProvider,
+	sessionSaver SessionSaver,
+	recordProvider RecordProvider,
+	accountProvider AccountProvider,
+) *Fasting {
+	return &Fasting{
+		log:             log,
+		sessionProvider: sessionProvider,
+		sessionSaver:    sessionSaver,
+		recordProvider:  recordProvider,
+		accountProvider: accountProvider,
+	}
+}
+
+// StartFastingForCurrentUser starts a session for a preset protocol or, for
+// ProtocolCustom, for plannedMinutes. Zero startedAt means now.
+func (f *Fasting) StartFastingForCurrentUser(
+	ctx context.Context,
+	protocol string,
+	plannedMinutes int,
+	startedAt time.Time,
+) (models.FastingSession, error) {
+	const op = "services.fasting.StartFastingForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not start fasting - incorrect token")
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	plannedMinutes, err = PlannedMinutes(protocol, plannedMinutes)
+	if err != nil {
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if startedAt.IsZero() {
+		startedAt = time.Now()
+	}
+
+	session := models.FastingSession{
+		AccountId:      acc.Id,
+		Protocol:       protocol,
+		PlannedMinutes: plannedMinutes,
+		StartedAt:      startedAt,
+	}
+
+	session.Id, err = f.sessionSaver.SaveFastingSession(ctx, session)
+	if err != nil {
+		if errors.Is(err, storage.ErrFastingSessionExists) {
+			log.Info("fasting already in progress", slog.Int64("accountId", acc.Id))
+			return models.FastingSession{}, fmt.Errorf("%s: %w", op, ErrFastingInProgress)
+		}
+
+		log.Error("failed to save fasting session", slog.String("err", err.Error()))
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Progress(session, time.Now()), nil
+}
+
+// StopFastingForCurrentUser ends the active session. Zero endedAt means now.
+func (f *Fasting) StopFastingForCurrentUser(
+	ctx context.Context,
+	endedAt time.Time,
+) (models.FastingSession, error) {
+	const op = "services.fasting.StopFastingForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not stop fasting - incorrect token")
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	session, err := f.sessionProvider.ActiveFastingSession(ctx, acc.Id)
+	if err != nil {
+		if errors.Is(err, storage.ErrFastingSessionNotFound) {
+			return models.FastingSession{}, fmt.Errorf("%s: %w", op, ErrNoActiveFasting)
+		}
+
+		log.Error("failed to get active fasting session", slog.String("err", err.Error()))
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if endedAt.IsZero() {
+		endedAt = time.Now()
+	}
+
+	if endedAt.Before(session.StartedAt) {
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, ErrInvalidEndTime)
+	}
+
+	err = f.sessionSaver.FinishFastingSession(ctx, acc.Id, session.Id, endedAt)
+	if err != nil {
+		if errors.Is(err, storage.ErrFastingSessionNotFound) {
+			return models.FastingSession{}, fmt.Errorf("%s: %w", op, ErrNoActiveFasting)
+		}
+
+		log.Error("failed to finish fasting session", slog.String("err", err.Error()))
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	session.EndedAt = &endedAt
+
+	return Progress(session, endedAt), nil
+}
+
+func (f *Fasting) GetFastingHistoryForCurrentUser(
+	ctx context.Context,
+) ([]models.FastingSession, error) {
+	const op = "services.fasting.GetFastingHistoryForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	sessions, err := f.sessions(ctx, log)
+	if err != nil {
+		return []models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+func (f *Fasting) GetFastingStatsForCurrentUser(
+	ctx context.Context,
+) (models.FastingStats, error) {
+	const op = "services.fasting.GetFastingStatsForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	sessions, err := f.sessions(ctx, log)
+	if err != nil {
+		return models.FastingStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Stats(sessions), nil
+}
+
+// GetFastingWindowsForCurrentUser detects actual fasting windows for days
+// from..to inclusive from gaps between records. Days are taken in the
+// location of from.
+func (f *Fasting) GetFastingWindowsForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) ([]models.FastingWindow, error) {
+	const op = "services.fasting.GetFastingWindowsForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get fasting windows - incorrect token")
+		return []models.FastingWindow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// The day before from is needed for the overnight gap ending on from
+	records, err := f.recordProvider.RecordsByAccountIdInRange(
+		ctx,
+		acc.Id,
+		from.AddDate(0, 0, -1),
+		to.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		log.Error("failed to get records", slog.String("err", err.Error()))
+		return []models.FastingWindow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Windows(records, from, to), nil
+}
+
+func (f *Fasting) sessions(ctx context.Context, log *slog.Logger) ([]models.FastingSession, error) {
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get fasting sessions - incorrect token")
+		return nil, err
+	}
+
+	sessions, err := f.sessionProvider.FastingSessionsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get fasting sessions", slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	now := time.Now()
+
+	result := make([]models.FastingSession, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, Progress(session, now))
+	}
+
+	return result, nil
+}
+
+// PlannedMinutes resolves the planned duration of a protocol.
+func PlannedMinutes(protocol string, customMinutes int) (int, error) {
+	if protocol == ProtocolCustom {
+		if customMinutes <= 0 {
+			return 0, ErrUnknownProtocol
+		}
+		return customMinutes, nil
+	}
+
+	for _, preset := range Protocols {
+		if preset.Name == protocol {
+			return preset.FastingHours * 60, nil
+		}
+	}
+
+	return 0, ErrUnknownProtocol
+}
+
+// Progress fills duration and completion of a session, active sessions
+// are measured up to now.
+func Progress(session models.FastingSession, now time.Time) models.FastingSession {
+	end := now
+	if session.EndedAt != nil {
+		end = *session.EndedAt
+	}
+
+	if end.After(session.StartedAt) {
+		session.DurationMinutes = int(end.Sub(session.StartedAt).Minutes())
+	}
+
+	session.Completed = session.EndedAt != nil && session.DurationMinutes >= session.PlannedMinutes
+
+	return session
+}
+
+// Stats aggregates sessions processed by Progress. Active session is
+// reported separately and not counted.
+func Stats(sessions []models.FastingSession) models.FastingStats {
+	stats := models.FastingStats{
+		ProtocolsCounts: map[string]int{},
+	}
+
+	var totalMinutes int
+
+	for i := range sessions {
+		session := sessions[i]
+
+		if session.EndedAt == nil {
+			stats.ActiveSession = &session
+			continue
+		}
+
+		stats.SessionsCount++
+		stats.ProtocolsCounts[session.Protocol]++
+		totalMinutes += session.DurationMinutes
+
+		if session.Completed {
+			stats.CompletedCount++
+		}
+
+		if session.DurationMinutes > stats.LongestMinutes {
+			stats.LongestMinutes = session.DurationMinutes
+		}
+	}
+
+	if stats.SessionsCount > 0 {
+		stats.AverageMinutes = totalMinutes / stats.SessionsCount
+		stats.CompletionRate = math.Round(
+			float64(stats.CompletedCount)/float64(stats.SessionsCount)*10000,
+		) / 10000
+	}
+
+	return stats
+}
+
+// Windows reports for every day from..to (in the location of from) the
+// longest gap between consecutive records which ends on that day.
+// Records must be sorted by date.
+func Windows(records []models.Record, from time.Time, to time.Time) []models.FastingWindow {
+	loc := from.Location()
+	windows := []models.FastingWindow{}
+
+	for i := 1; i < len(records); i++ {
+		start := records[i-1].DateRecord.In(loc)
+		end := records[i].DateRecord.In(loc)
+
+		day := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+		if day.Before(from) || day.After(to) {
+			continue
+		}
+
+		minutes := int(end.Sub(start).Minutes())
+
+		last := len(windows) - 1
+		if last >= 0 && windows[last].Day.Equal(day) {
+			if minutes > windows[last].Minutes {
+				windows[last].Start = start
+				windows[last].End = end
+				windows[last].Minutes = minutes
+			}
+			continue
+		}
+
+		windows = append(windows, models.FastingWindow{
+			Day:     day,
+			Start:   start,
+			End:     end,
+			Minutes: minutes,
+		})
+	}
+
+	return windows
+}
@@ -0,0 +1,107 @@
+package fasting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/fasting"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlannedMinutes(t *testing.T) {
+	minutes, err := fasting.PlannedMinutes("16:8", 0)
+	require.NoError(t, err)
+	require.Equal(t, 16*60, minutes)
+
+	minutes, err = fasting.PlannedMinutes("omad", 90)
+	require.NoError(t, err)
+	require.Equal(t, 23*60, minutes)
+
+	minutes, err = fasting.PlannedMinutes(fasting.ProtocolCustom, 90)
+	require.NoError(t, err)
+	require.Equal(t, 90, minutes)
+
+	_, err = fasting.PlannedMinutes(fasting.ProtocolCustom, 0)
+	require.ErrorIs(t, err, fasting.ErrUnknownProtocol)
+
+	_, err = fasting.PlannedMinutes("20:4", 0)
+	require.ErrorIs(t, err, fasting.ErrUnknownProtocol)
+}
+
+func TestProgressAndStats(t *testing.T) {
+	start := time.Date(2024, 4, 1, 20, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 3)
+
+	ended := func(hours int) *time.Time {
+		end := start.Add(time.Duration(hours) * time.Hour)
+		return &end
+	}
+
+	sessions := []models.FastingSession{
+		{Protocol: "16:8", PlannedMinutes: 16 * 60, StartedAt: start, EndedAt: ended(17)},
+		{Protocol: "16:8", PlannedMinutes: 16 * 60, StartedAt: start, EndedAt: ended(12)},
+		{Protocol: "omad", PlannedMinutes: 23 * 60, StartedAt: start, EndedAt: ended(23)},
+		{Protocol: "18:6", PlannedMinutes: 18 * 60, StartedAt: now.Add(-2 * time.Hour)},
+	}
+
+	for i := range sessions {
+		sessions[i] = fasting.Progress(sessions[i], now)
+	}
+
+	require.True(t, sessions[0].Completed)
+	require.False(t, sessions[1].Completed)
+	require.Equal(t, 120, sessions[3].DurationMinutes)
+	require.False(t, sessions[3].Completed)
+
+	stats := fasting.Stats(sessions)
+
+	require.Equal(t, 3, stats.SessionsCount)
+	require.Equal(t, 2, stats.CompletedCount)
+	require.Equal(t, 0.6667, stats.CompletionRate)
+	require.Equal(t, (17+12+23)*60/3, stats.AverageMinutes)
+	require.Equal(t, 23*60, stats.LongestMinutes)
+	require.Equal(t, map[string]int{"16:8": 2, "omad": 1}, stats.ProtocolsCounts)
+	require.NotNil(t, stats.ActiveSession)
+	require.Equal(t, "18:6", stats.ActiveSession.Protocol)
+}
+
+func TestStatsEmpty(t *testing.T) {
+	stats := fasting.Stats(nil)
+
+	require.Equal(t, 0, stats.SessionsCount)
+	require.Equal(t, 0.0, stats.CompletionRate)
+	require.Nil(t, stats.ActiveSession)
+}
+
+func TestWindows(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, 4, day, hour, 0, 0, 0, loc).UTC()
+	}
+
+	records := []models.Record{
+		{DateRecord: at(1, 20)},
+		{DateRecord: at(2, 9)},
+		{DateRecord: at(2, 13)},
+		{DateRecord: at(2, 19)},
+		{DateRecord: at(3, 12)},
+		{DateRecord: at(5, 8)},
+	}
+
+	from := time.Date(2024, 4, 2, 0, 0, 0, 0, loc)
+	to := time.Date(2024, 4, 4, 0, 0, 0, 0, loc)
+
+	windows := fasting.Windows(records, from, to)
+
+	require.Len(t, windows, 2)
+
+	require.Equal(t, from, windows[0].Day)
+	require.Equal(t, at(1, 20), windows[0].Start.UTC())
+	require.Equal(t, at(2, 9), windows[0].End.UTC())
+	require.Equal(t, 13*60, windows[0].Minutes)
+
+	require.Equal(t, from.AddDate(0, 0, 1), windows[1].Day)
+	require.Equal(t, 17*60, windows[1].Minutes)
+}
@@ -0,0 +1,150 @@
+package food
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Food struct {
+	log             *slog.Logger
+	foodProvider    FoodProvider
+	foodSaver       FoodSaver
+	accountProvider AccountProvider
+}
+
+type FoodProvider interface {
+	FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error)
+}
+
+type FoodSaver interface {
+	SaveFood(ctx context.Context, food models.Food) (int64, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+var (
+	ErrFoodExists       = errors.New("food exists")
+	ErrInvalidNutrients = errors.New("invalid nutrients")
+	ErrInvalidCategory  = errors.New("invalid food category")
+)
+
+func New(
+	log *slog.Logger,
+	foodProvider FoodProvider,
+	foodSaver FoodSaver,
+	accountProvider AccountProvider,
+) *Food {
+	return &Food{
+		log:             log,
+		foodProvider:    foodProvider,
+		foodSaver:       foodSaver,
+		accountProvider: accountProvider,
+	}
+}
+
+func (f *Food) GetFoodsForCurrentUser(ctx context.Context) ([]models.Food, error) {
+	const op = "services.food.GetFoodsForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get foods - incorrect token")
+		return []models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foods, err := f.foodProvider.FoodsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("can not get foods", slog.String("err", err.Error()))
+		return []models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(foods) == 0 {
+		foods = []models.Food{}
+	}
+
+	for i := range foods {
+		foods[i].Value = acc.EnergyUnit.FromKcal(foods[i].Value)
+	}
+
+	return foods, nil
+}
+
+func (f *Food) CreateFoodForCurrentUser(ctx context.Context, food models.Food) (models.Food, error) {
+	const op = "services.food.CreateFoodForCurrentUser"
+
+	log := f.log.With(slog.String("op", op))
+
+	acc, err := f.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not create food - incorrect token")
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := ValidateNutrients(food.Nutrients); err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := restriction.ValidateAllergens(food.Allergens); err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := ValidateCategory(food.Category); err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	food.AccountId = acc.Id
+	food.Value = acc.EnergyUnit.ToKcal(food.Value)
+
+	food.Id, err = f.foodSaver.SaveFood(ctx, food)
+	if err != nil {
+		if errors.Is(err, storage.ErrFoodExists) {
+			log.Info("food exists", slog.String("name", food.Name))
+			return models.Food{}, fmt.Errorf("%s: %w", op, ErrFoodExists)
+		}
+
+		log.Error("failed to save food", slog.String("err", err.Error()))
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	food.Value = acc.EnergyUnit.FromKcal(food.Value)
+
+	return food, nil
+}
+
+// ValidateNutrients accepts known micro and macronutrients with non-negative amounts.
+func ValidateNutrients(nutrients models.Nutrients) error {
+	for nutrient, amount := range nutrients {
+		_, micro := models.NutrientUnits[nutrient]
+		_, macro := models.MacronutrientUnits[nutrient]
+
+		if !micro && !macro || amount < 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidNutrients, nutrient)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCategory accepts no category or one of FoodCategories.
+func ValidateCategory(category models.FoodCategory) error {
+	if category == "" {
+		return nil
+	}
+
+	for _, known := range models.FoodCategories {
+		if category == known {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidCategory, category)
+}
@@ -0,0 +1,196 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Idempotency struct {
+	log             *slog.Logger
+	keyProvider     KeyProvider
+	keySaver        KeySaver
+	keyRemover      KeyRemover
+	accountProvider AccountProvider
+	ttl             time.Duration
+}
+
+type KeyProvider interface {
+	IdempotencyKey(ctx context.Context, accountId int64, key string) (models.IdempotencyKey, error)
+}
+
+type KeySaver interface {
+	SaveIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+	CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
+}
+
+type KeyRemover interface {
+	DeleteIdempotencyKey(ctx context.Context, accountId int64, key string) error
+	PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const MaxKeyLength = 255
+
+var (
+	ErrInvalidKey    = errors.New("invalid idempotency key")
+	ErrKeyInProgress = errors.New("request with the idempotency key is in progress")
+	ErrKeyReused     = errors.New("idempotency key reused with another request")
+)
+
+func New(
+	log *slog.Logger,
+	keyProvider KeyProvider,
+	keySaver KeySaver,
+	keyRemover KeyRemover,
+	accountProvider AccountProvider,
+	ttl time.Duration,
+) *Idempotency {
+	return &Idempotency{
+		log:             log,
+		keyProvider:     keyProvider,
+		keySaver:        keySaver,
+		keyRemover:      keyRemover,
+		accountProvider: accountProvider,
+		ttl:             ttl,
+	}
+}
+
+// BeginForCurrentUser reserves the key for the request with the fingerprint.
+// Returned key has zero Status when reserved, otherwise it holds the
+// response of the completed request to replay. Expired keys are reused.
+func (i *Idempotency) BeginForCurrentUser(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+) (models.IdempotencyKey, error) {
+	const op = "services.idempotency.BeginForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	if !ValidKey(key) {
+		return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+	}
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not begin idempotent request - incorrect token")
+		return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reserved := models.IdempotencyKey{
+		AccountId:   acc.Id,
+		Key:         key,
+		Fingerprint: fingerprint,
+		DateCreated: time.Now(),
+	}
+
+	// The second attempt follows removal of an expired key
+	for attempt := 0; attempt < 2; attempt++ {
+		err := i.keySaver.SaveIdempotencyKey(ctx, reserved)
+		if err == nil {
+			return reserved, nil
+		}
+
+		if !errors.Is(err, storage.ErrIdempotencyKeyExists) {
+			log.Error("failed to save idempotency key", slog.String("err", err.Error()))
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		stored, err := i.keyProvider.IdempotencyKey(ctx, acc.Id, key)
+		if err != nil {
+			if errors.Is(err, storage.ErrIdempotencyKeyNotFound) {
+				continue
+			}
+
+			log.Error("failed to get idempotency key", slog.String("err", err.Error()))
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if stored.DateCreated.Before(time.Now().Add(-i.ttl)) {
+			if err := i.keyRemover.DeleteIdempotencyKey(ctx, acc.Id, key); err != nil {
+				log.Error("failed to delete expired idempotency key", slog.String("err", err.Error()))
+				return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+			}
+			continue
+		}
+
+		if stored.Fingerprint != fingerprint {
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, ErrKeyReused)
+		}
+
+		if stored.Status == 0 {
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, ErrKeyInProgress)
+		}
+
+		return stored, nil
+	}
+
+	return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, ErrKeyInProgress)
+}
+
+// Complete stores the response of the request the key was reserved for.
+func (i *Idempotency) Complete(ctx context.Context, key models.IdempotencyKey) error {
+	const op = "services.idempotency.Complete"
+
+	log := i.log.With(slog.String("op", op))
+
+	if err := i.keySaver.CompleteIdempotencyKey(ctx, key); err != nil {
+		log.Error("failed to complete idempotency key", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Release removes the key reserved for a failed request so a retry runs it again.
+func (i *Idempotency) Release(ctx context.Context, key models.IdempotencyKey) error {
+	const op = "services.idempotency.Release"
+
+	log := i.log.With(slog.String("op", op))
+
+	if err := i.keyRemover.DeleteIdempotencyKey(ctx, key.AccountId, key.Key); err != nil {
+		log.Error("failed to release idempotency key", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes keys older than ttl and returns the number of purged keys.
+func (i *Idempotency) PurgeExpired(ctx context.Context) (int, error) {
+	const op = "services.idempotency.PurgeExpired"
+
+	log := i.log.With(slog.String("op", op))
+
+	purged, err := i.keyRemover.PurgeIdempotencyKeys(ctx, time.Now().Add(-i.ttl))
+	if err != nil {
+		log.Error("failed to purge idempotency keys", slog.String("err", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(purged), nil
+}
+
+// ValidKey reports whether the key is 1 to MaxKeyLength visible ASCII characters.
+func ValidKey(key string) bool {
+	if key == "" || len(key) > MaxKeyLength {
+		return false
+	}
+
+	for _, c := range []byte(key) {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
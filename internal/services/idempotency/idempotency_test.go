@@ -0,0 +1,36 @@
+package idempotency_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/services/idempotency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		key      string
+		expected bool
+	}{
+		{name: "uuid", key: "0b8f8d3e-4c1e-4f0a-9b7e-3f1c2d5a6b7c", expected: true},
+		{name: "single character", key: "a", expected: true},
+		{name: "max length", key: strings.Repeat("k", idempotency.MaxKeyLength), expected: true},
+		{name: "empty", key: "", expected: false},
+		{name: "too long", key: strings.Repeat("k", idempotency.MaxKeyLength+1), expected: false},
+		{name: "space", key: "retry 1", expected: false},
+		{name: "non ascii", key: "ключ", expected: false},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, idempotency.ValidKey(tc.key))
+		})
+	}
+}
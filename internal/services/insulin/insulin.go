@@ -0,0 +1,422 @@
+package insulin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Insulin struct {
+	log                 *slog.Logger
+	settingsProvider    SettingsProvider
+	settingsSaver       SettingsSaver
+	recordProvider      RecordProvider
+	calculationProvider CalculationProvider
+	calculationSaver    CalculationSaver
+	accountProvider     AccountProvider
+}
+
+type SettingsProvider interface {
+	InsulinSettingsByAccountId(ctx context.Context, accountId int64) (models.InsulinSettings, error)
+}
+
+type SettingsSaver interface {
+	SaveInsulinSettings(ctx context.Context, accountId int64, settings models.InsulinSettings) error
+}
+
+type RecordProvider interface {
+	RecordsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.Record, error)
+}
+
+type CalculationProvider interface {
+	BolusCalculationsByAccountId(ctx context.Context, accountId int64) ([]models.BolusCalculation, error)
+}
+
+type CalculationSaver interface {
+	SaveBolusCalculation(ctx context.Context, calculation models.BolusCalculation) (int64, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	DefaultDoseIncrement = 0.5
+	MaxCarbRatio         = 150
+)
+
+// glucoseRange is a plausible range of readings and targets in a unit.
+type glucoseRange struct {
+	minReading float64
+	maxReading float64
+	minTarget  float64
+	maxTarget  float64
+}
+
+var glucoseRanges = map[models.GlucoseUnit]glucoseRange{
+	models.GlucoseUnitMgDl:  {minReading: 20, maxReading: 600, minTarget: 70, maxTarget: 200},
+	models.GlucoseUnitMmolL: {minReading: 1.1, maxReading: 33.3, minTarget: 3.9, maxTarget: 11.1},
+}
+
+var (
+	ErrSettingsNotFound = errors.New("insulin settings not found")
+	ErrInvalidSettings  = errors.New("invalid insulin settings")
+	ErrInvalidGlucose   = errors.New("glucose reading out of range")
+	ErrAmbiguousCarbs   = errors.New("carbs can be either given or taken from a meal")
+)
+
+func New(
+	log *slog.Logger,
+	settingsProvider SettingsProvider,
+	settingsSaver SettingsSaver,
+	recordProvider RecordProvider,
+	calculationProvider CalculationProvider,
+	calculationSaver CalculationSaver,
+	accountProvider AccountProvider,
+) *Insulin {
+	return &Insulin{
+		log:                 log,
+		settingsProvider:    settingsProvider,
+		settingsSaver:       settingsSaver,
+		recordProvider:      recordProvider,
+		calculationProvider: calculationProvider,
+		calculationSaver:    calculationSaver,
+		accountProvider:     accountProvider,
+	}
+}
+
+func (i *Insulin) GetInsulinSettingsForCurrentUser(ctx context.Context) (models.InsulinSettings, error) {
+	const op = "services.insulin.GetInsulinSettingsForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get insulin settings - incorrect token")
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	settings, err := i.settings(ctx, acc.Id)
+	if err != nil {
+		if !errors.Is(err, ErrSettingsNotFound) {
+			log.Error("failed to get insulin settings", slog.String("err", err.Error()))
+		}
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return settings, nil
+}
+
+// UpdateInsulinSettingsForCurrentUser replaces settings, zero dose increment
+// means DefaultDoseIncrement.
+func (i *Insulin) UpdateInsulinSettingsForCurrentUser(
+	ctx context.Context,
+	settings models.InsulinSettings,
+) (models.InsulinSettings, error) {
+	const op = "services.insulin.UpdateInsulinSettingsForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not update insulin settings - incorrect token")
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if settings.DoseIncrement == 0 {
+		settings.DoseIncrement = DefaultDoseIncrement
+	}
+
+	if err := ValidateSettings(settings); err != nil {
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := i.settingsSaver.SaveInsulinSettings(ctx, acc.Id, settings); err != nil {
+		log.Error("failed to save insulin settings", slog.String("err", err.Error()))
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return settings, nil
+}
+
+// GetCarbSummaryForCurrentUser returns carbs per meal of day,
+// day is the local midnight the day starts at.
+func (i *Insulin) GetCarbSummaryForCurrentUser(
+	ctx context.Context,
+	day time.Time,
+) (models.CarbSummary, error) {
+	const op = "services.insulin.GetCarbSummaryForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get carb summary - incorrect token")
+		return models.CarbSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records, err := i.recordProvider.RecordsByAccountIdInRange(ctx, acc.Id, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		log.Error("failed to get records", slog.String("err", err.Error()))
+		return models.CarbSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return CarbSummary(records, day), nil
+}
+
+// CalculateBolusForCurrentUser suggests a dose with the current settings
+// and saves the calculation to the audit trail.
+func (i *Insulin) CalculateBolusForCurrentUser(
+	ctx context.Context,
+	input models.BolusInput,
+) (models.BolusCalculation, error) {
+	const op = "services.insulin.CalculateBolusForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not calculate bolus - incorrect token")
+		return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if input.Carbs != nil && input.Meal != "" {
+		return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, ErrAmbiguousCarbs)
+	}
+
+	settings, err := i.settings(ctx, acc.Id)
+	if err != nil {
+		if !errors.Is(err, ErrSettingsNotFound) {
+			log.Error("failed to get insulin settings", slog.String("err", err.Error()))
+		}
+		return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if input.Glucose != nil {
+		limits := glucoseRanges[settings.GlucoseUnit]
+
+		if *input.Glucose < limits.minReading || *input.Glucose > limits.maxReading {
+			return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, ErrInvalidGlucose)
+		}
+	}
+
+	calculation := models.BolusCalculation{
+		AccountId:      acc.Id,
+		CreatedAt:      time.Now(),
+		CarbsSource:    models.CarbsSourceManual,
+		RecordIds:      []int64{},
+		Glucose:        input.Glucose,
+		InsulinOnBoard: input.InsulinOnBoard,
+		Settings:       settings,
+	}
+
+	if input.Carbs != nil {
+		calculation.Carbs = *input.Carbs
+	}
+
+	if input.Meal != "" {
+		records, err := i.recordProvider.RecordsByAccountIdInRange(
+			ctx,
+			acc.Id,
+			input.Day,
+			input.Day.AddDate(0, 0, 1),
+		)
+		if err != nil {
+			log.Error("failed to get records", slog.String("err", err.Error()))
+			return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		day := input.Day
+
+		calculation.CarbsSource = models.CarbsSourceRecords
+		calculation.Meal = input.Meal
+		calculation.Day = &day
+
+		for _, record := range records {
+			if recordMeal(record, input.Day.Location()) != input.Meal {
+				continue
+			}
+
+			carbs, ok := record.Nutrients[models.NutrientCarbs]
+			if !ok {
+				calculation.UncountedRecords++
+				continue
+			}
+
+			calculation.Carbs += carbs
+			calculation.RecordIds = append(calculation.RecordIds, record.Id)
+		}
+
+		calculation.Carbs = round(calculation.Carbs)
+	}
+
+	calculation.CarbDose, calculation.CorrectionDose, calculation.SuggestedDose = Bolus(
+		settings,
+		calculation.Carbs,
+		input.Glucose,
+		input.InsulinOnBoard,
+	)
+
+	calculation.Id, err = i.calculationSaver.SaveBolusCalculation(ctx, calculation)
+	if err != nil {
+		log.Error("failed to save bolus calculation", slog.String("err", err.Error()))
+		return models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	calculation.Disclaimer = models.InsulinDisclaimer
+
+	return calculation, nil
+}
+
+func (i *Insulin) GetBolusHistoryForCurrentUser(ctx context.Context) ([]models.BolusCalculation, error) {
+	const op = "services.insulin.GetBolusHistoryForCurrentUser"
+
+	log := i.log.With(slog.String("op", op))
+
+	acc, err := i.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get bolus history - incorrect token")
+		return []models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	calculations, err := i.calculationProvider.BolusCalculationsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get bolus calculations", slog.String("err", err.Error()))
+		return []models.BolusCalculation{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(calculations) == 0 {
+		return []models.BolusCalculation{}, nil
+	}
+
+	for idx := range calculations {
+		calculations[idx].Disclaimer = models.InsulinDisclaimer
+	}
+
+	return calculations, nil
+}
+
+func (i *Insulin) settings(ctx context.Context, accountId int64) (models.InsulinSettings, error) {
+	settings, err := i.settingsProvider.InsulinSettingsByAccountId(ctx, accountId)
+	if err != nil {
+		if errors.Is(err, storage.ErrInsulinSettingsNotFound) {
+			return models.InsulinSettings{}, ErrSettingsNotFound
+		}
+		return models.InsulinSettings{}, err
+	}
+
+	return settings, nil
+}
+
+// ValidateSettings rejects non-positive ratios and targets outside
+// of a plausible range for the glucose unit.
+func ValidateSettings(settings models.InsulinSettings) error {
+	limits, ok := glucoseRanges[settings.GlucoseUnit]
+	if !ok {
+		return fmt.Errorf("%w: unknown glucose unit", ErrInvalidSettings)
+	}
+
+	if settings.CarbRatio <= 0 || settings.CarbRatio > MaxCarbRatio {
+		return fmt.Errorf("%w: carb ratio", ErrInvalidSettings)
+	}
+
+	if settings.CorrectionFactor <= 0 {
+		return fmt.Errorf("%w: correction factor", ErrInvalidSettings)
+	}
+
+	if settings.TargetGlucose < limits.minTarget || settings.TargetGlucose > limits.maxTarget {
+		return fmt.Errorf("%w: target glucose", ErrInvalidSettings)
+	}
+
+	if settings.DoseIncrement <= 0 || settings.DoseIncrement > 1 {
+		return fmt.Errorf("%w: dose increment", ErrInvalidSettings)
+	}
+
+	return nil
+}
+
+// Bolus returns the carb dose, the correction dose (negative below target,
+// zero without a glucose reading) and their sum minus insulin on board,
+// rounded down to the dose increment and never negative.
+func Bolus(
+	settings models.InsulinSettings,
+	carbs float64,
+	glucose *float64,
+	insulinOnBoard float64,
+) (carbDose float64, correctionDose float64, suggestedDose float64) {
+	carbDose = carbs / settings.CarbRatio
+
+	if glucose != nil {
+		correctionDose = (*glucose - settings.TargetGlucose) / settings.CorrectionFactor
+	}
+
+	total := carbDose + correctionDose - insulinOnBoard
+	if total > 0 {
+		// Epsilon keeps exact multiples from being floored one step down
+		suggestedDose = math.Floor(total/settings.DoseIncrement+1e-9) * settings.DoseIncrement
+	}
+
+	return round(carbDose), round(correctionDose), round(suggestedDose)
+}
+
+// CarbSummary sums carbs of records per meal, records without carbs data
+// are counted as uncounted.
+func CarbSummary(records []models.Record, day time.Time) models.CarbSummary {
+	summary := models.CarbSummary{
+		Day:   day,
+		Meals: make([]models.MealCarbs, len(models.Meals)),
+	}
+
+	index := make(map[models.Meal]int, len(models.Meals))
+	for idx, meal := range models.Meals {
+		summary.Meals[idx].Meal = meal
+		index[meal] = idx
+	}
+
+	for _, record := range records {
+		meal := &summary.Meals[index[recordMeal(record, day.Location())]]
+
+		carbs, ok := record.Nutrients[models.NutrientCarbs]
+		if !ok {
+			meal.UncountedRecords++
+			summary.UncountedRecords++
+			continue
+		}
+
+		meal.Carbs += carbs
+		meal.RecordsCount++
+		summary.Carbs += carbs
+	}
+
+	for idx := range summary.Meals {
+		summary.Meals[idx].Carbs = round(summary.Meals[idx].Carbs)
+	}
+	summary.Carbs = round(summary.Carbs)
+
+	return summary
+}
+
+// recordMeal falls back to the meal by local hour for records without one.
+func recordMeal(record models.Record, loc *time.Location) models.Meal {
+	if record.Meal != "" {
+		return record.Meal
+	}
+
+	return models.MealByHour(record.DateRecord.In(loc).Hour())
+}
+
+// round rounds to 2 decimal places.
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,99 @@
+package insulin_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/insulin"
+	"github.com/stretchr/testify/require"
+)
+
+var settings = models.InsulinSettings{
+	CarbRatio:        10,
+	CorrectionFactor: 50,
+	TargetGlucose:    110,
+	GlucoseUnit:      models.GlucoseUnitMgDl,
+	DoseIncrement:    0.5,
+}
+
+func TestBolus(t *testing.T) {
+	glucose := func(value float64) *float64 {
+		return &value
+	}
+
+	carbDose, correctionDose, suggestedDose := insulin.Bolus(settings, 60, glucose(210), 0)
+	require.Equal(t, 6.0, carbDose)
+	require.Equal(t, 2.0, correctionDose)
+	require.Equal(t, 8.0, suggestedDose)
+
+	// Insulin on board and rounding down to the increment
+	_, _, suggestedDose = insulin.Bolus(settings, 45, glucose(160), 1.2)
+	require.Equal(t, 4.0, suggestedDose)
+
+	// Below target reduces the carb dose
+	carbDose, correctionDose, suggestedDose = insulin.Bolus(settings, 30, glucose(85), 0)
+	require.Equal(t, 3.0, carbDose)
+	require.Equal(t, -0.5, correctionDose)
+	require.Equal(t, 2.5, suggestedDose)
+
+	// Never negative
+	_, _, suggestedDose = insulin.Bolus(settings, 0, glucose(60), 0)
+	require.Equal(t, 0.0, suggestedDose)
+
+	// No glucose reading, carbs only
+	_, correctionDose, suggestedDose = insulin.Bolus(settings, 25, nil, 0)
+	require.Equal(t, 0.0, correctionDose)
+	require.Equal(t, 2.5, suggestedDose)
+}
+
+func TestValidateSettings(t *testing.T) {
+	require.NoError(t, insulin.ValidateSettings(settings))
+
+	mmol := settings
+	mmol.GlucoseUnit = models.GlucoseUnitMmolL
+	mmol.CorrectionFactor = 2.5
+	mmol.TargetGlucose = 6
+	require.NoError(t, insulin.ValidateSettings(mmol))
+
+	// mg/dL target with mmol/L unit
+	mmol.TargetGlucose = 110
+	require.ErrorIs(t, insulin.ValidateSettings(mmol), insulin.ErrInvalidSettings)
+
+	zeroRatio := settings
+	zeroRatio.CarbRatio = 0
+	require.ErrorIs(t, insulin.ValidateSettings(zeroRatio), insulin.ErrInvalidSettings)
+
+	unknownUnit := settings
+	unknownUnit.GlucoseUnit = "mg"
+	require.ErrorIs(t, insulin.ValidateSettings(unknownUnit), insulin.ErrInvalidSettings)
+}
+
+func TestCarbSummary(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	day := time.Date(2024, 4, 1, 0, 0, 0, 0, loc)
+
+	records := []models.Record{
+		{Id: 1, Meal: models.MealBreakfast, Nutrients: models.Nutrients{models.NutrientCarbs: 40.5}},
+		{Id: 2, Meal: models.MealBreakfast, Nutrients: models.Nutrients{models.NutrientCarbs: 12}},
+		{Id: 3, Meal: models.MealBreakfast},
+		// No meal, 13:00 local is lunch
+		{
+			Id:         4,
+			DateRecord: time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC),
+			Nutrients:  models.Nutrients{models.NutrientCarbs: 70, models.NutrientProtein: 30},
+		},
+	}
+
+	summary := insulin.CarbSummary(records, day)
+
+	require.Equal(t, day, summary.Day)
+	require.Equal(t, 122.5, summary.Carbs)
+	require.Equal(t, 1, summary.UncountedRecords)
+	require.Equal(t, []models.MealCarbs{
+		{Meal: models.MealBreakfast, Carbs: 52.5, RecordsCount: 2, UncountedRecords: 1},
+		{Meal: models.MealLunch, Carbs: 70, RecordsCount: 1},
+		{Meal: models.MealDinner},
+		{Meal: models.MealSnack},
+	}, summary.Meals)
+}
@@ -0,0 +1,235 @@
+package mealplan
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// mealShares split the daily limit between meals of a planned day.
+var mealShares = []struct {
+	meal  models.Meal
+	share float64
+}{
+	{meal: models.MealBreakfast, share: 0.25},
+	{meal: models.MealLunch, share: 0.35},
+	{meal: models.MealSnack, share: 0.10},
+	{meal: models.MealDinner, share: 0.30},
+}
+
+// Portions tried for every candidate food (grams) and recipe (servings).
+var (
+	foodPortions   = portions(50, 400, 25)
+	recipePortions = portions(0.5, 3, 0.5)
+)
+
+const (
+	// maxRounds bounds local search of a day, a day usually settles in a few.
+	maxRounds = 20
+
+	energyWeight     = 2.0
+	macroWeight      = 1.0
+	balanceWeight    = 0.25
+	repeatPenalty    = 1.0
+	yesterdayPenalty = 0.25
+)
+
+// option is a candidate portion, key identifies the food or the recipe.
+type option struct {
+	key  string
+	meal models.PlannedMeal
+}
+
+// Generate plans days starting at startDay, one food portion or recipe
+// servings per meal. Every day starts from the portion closest to each meal
+// share of the daily limit and is improved by local search: one meal at a
+// time is replaced by the option lowering the day score (deviation from the
+// daily limit and macro targets, meal balance and repeats) until no
+// replacement helps. Foods and recipes of the previous day are slightly
+// penalized so consecutive days differ when the catalog allows.
+func Generate(
+	foods []models.Food,
+	recipes []models.Recipe,
+	startDay time.Time,
+	days int,
+	dailyLimit int,
+	targets models.MacroTargets,
+) []models.MealPlanDay {
+	options := candidateOptions(foods, recipes)
+	if len(options) == 0 || dailyLimit <= 0 {
+		return []models.MealPlanDay{}
+	}
+
+	macros := targets.Nutrients()
+	yesterday := map[string]bool{}
+
+	var meals []models.PlannedMeal
+
+	for d := 0; d < days; d++ {
+		day := startDay.AddDate(0, 0, d)
+		slots := planDay(options, float64(dailyLimit), macros, yesterday)
+
+		yesterday = map[string]bool{}
+
+		for i, slot := range slots {
+			meal := slot.meal
+			meal.Day = day
+			meal.Meal = mealShares[i].meal
+			meals = append(meals, meal)
+
+			yesterday[slot.key] = true
+		}
+	}
+
+	return models.MealPlanDays(meals)
+}
+
+func planDay(
+	options []option,
+	limit float64,
+	macros models.Nutrients,
+	yesterday map[string]bool,
+) []option {
+	slots := make([]option, len(mealShares))
+	used := map[string]bool{}
+
+	for i, share := range mealShares {
+		best, bestScore := 0, math.Inf(1)
+
+		for j, opt := range options {
+			score := math.Abs(float64(opt.meal.Value)-share.share*limit) / limit
+			if used[opt.key] {
+				score += repeatPenalty
+			}
+
+			if score < bestScore {
+				best, bestScore = j, score
+			}
+		}
+
+		slots[i] = options[best]
+		used[options[best].key] = true
+	}
+
+	current := dayScore(slots, limit, macros, yesterday)
+
+	for round := 0; round < maxRounds; round++ {
+		improved := false
+
+		for i := range slots {
+			kept := slots[i]
+
+			for _, opt := range options {
+				slots[i] = opt
+
+				if score := dayScore(slots, limit, macros, yesterday); score < current-1e-9 {
+					current, kept, improved = score, opt, true
+				}
+			}
+
+			slots[i] = kept
+		}
+
+		if !improved {
+			break
+		}
+	}
+
+	return slots
+}
+
+// dayScore is lower for days closer to the limit and macro targets.
+func dayScore(
+	slots []option,
+	limit float64,
+	macros models.Nutrients,
+	yesterday map[string]bool,
+) float64 {
+	var (
+		value float64
+		score float64
+	)
+
+	nutrients := models.Nutrients{}
+	seen := map[string]bool{}
+
+	for i, slot := range slots {
+		value += float64(slot.meal.Value)
+
+		for nutrient := range macros {
+			nutrients[nutrient] += slot.meal.Nutrients[nutrient]
+		}
+
+		score += balanceWeight * math.Abs(float64(slot.meal.Value)-mealShares[i].share*limit) / limit
+
+		if seen[slot.key] {
+			score += repeatPenalty
+		}
+		if yesterday[slot.key] {
+			score += yesterdayPenalty
+		}
+		seen[slot.key] = true
+	}
+
+	score += energyWeight * math.Abs(value-limit) / limit
+
+	for nutrient, target := range macros {
+		score += macroWeight * math.Abs(nutrients[nutrient]-target) / target / float64(len(macros))
+	}
+
+	return score
+}
+
+func candidateOptions(foods []models.Food, recipes []models.Recipe) []option {
+	var options []option
+
+	for _, food := range foods {
+		food := food
+
+		for _, grams := range foodPortions {
+			factor := grams / 100
+
+			options = append(options, option{
+				key: "food:" + strconv.FormatInt(food.Id, 10),
+				meal: models.PlannedMeal{
+					FoodId:    &food.Id,
+					Name:      food.Name,
+					Grams:     grams,
+					Value:     int(math.Round(float64(food.Value) * factor)),
+					Nutrients: food.Nutrients.Scale(factor),
+				},
+			})
+		}
+	}
+
+	for _, recipe := range recipes {
+		recipe := recipe
+
+		for _, servings := range recipePortions {
+			options = append(options, option{
+				key: "recipe:" + strconv.FormatInt(recipe.Id, 10),
+				meal: models.PlannedMeal{
+					RecipeId:  &recipe.Id,
+					Name:      recipe.Name,
+					Servings:  servings,
+					Value:     int(math.Round(float64(recipe.Value) * servings)),
+					Nutrients: recipe.Nutrients.Scale(servings),
+				},
+			})
+		}
+	}
+
+	return options
+}
+
+func portions(from float64, to float64, step float64) []float64 {
+	var result []float64
+
+	for portion := from; portion <= to; portion += step {
+		result = append(result, portion)
+	}
+
+	return result
+}
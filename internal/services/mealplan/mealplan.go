@@ -0,0 +1,326 @@
+package mealplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type MealPlan struct {
+	log             *slog.Logger
+	catalogProvider CatalogProvider
+	restrictions    RestrictionsProvider
+	planProvider    PlanProvider
+	planSaver       PlanSaver
+	recordCreator   RecordCreator
+	accountProvider AccountProvider
+}
+
+type CatalogProvider interface {
+	FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error)
+	RecipesByAccountId(ctx context.Context, accountId int64) ([]models.Recipe, error)
+}
+
+type RestrictionsProvider interface {
+	RestrictionsByAccountId(ctx context.Context, accountId int64) (models.Restrictions, error)
+}
+
+type PlanProvider interface {
+	MealPlanById(ctx context.Context, accountId int64, planId int64) (models.MealPlan, error)
+	MealPlansByAccountId(ctx context.Context, accountId int64) ([]models.MealPlan, error)
+}
+
+type PlanSaver interface {
+	SaveMealPlan(ctx context.Context, plan models.MealPlan) (int64, error)
+	SetPlannedMealRecord(ctx context.Context, planId int64, mealId int64, recordId int64) error
+}
+
+type RecordCreator interface {
+	CreateRecordForCurrentUser(
+		ctx context.Context,
+		record models.Record,
+	) (models.Record, []models.RestrictionWarning, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const MaxDays = 7
+
+var (
+	ErrInvalidDays         = errors.New("invalid number of plan days")
+	ErrNoCandidates        = errors.New("no foods or recipes to plan with")
+	ErrMealPlanNotFound    = errors.New("meal plan not found")
+	ErrPlannedMealNotFound = errors.New("planned meal not found")
+	ErrPlannedMealLogged   = errors.New("planned meal is already logged")
+)
+
+func New(
+	log *slog.Logger,
+	catalogProvider CatalogProvider,
+	restrictions RestrictionsProvider,
+	planProvider PlanProvider,
+	planSaver PlanSaver,
+	recordCreator RecordCreator,
+	accountProvider AccountProvider,
+) *MealPlan {
+	return &MealPlan{
+		log:             log,
+		catalogProvider: catalogProvider,
+		restrictions:    restrictions,
+		planProvider:    planProvider,
+		planSaver:       planSaver,
+		recordCreator:   recordCreator,
+		accountProvider: accountProvider,
+	}
+}
+
+// GenerateMealPlanForCurrentUser plans days starting at startDay for the
+// account daily limit and macro targets. Favorite foods and recipes are
+// planned with when there are any, otherwise the whole catalog is used.
+// Foods and recipes conflicting with account restrictions are never planned.
+func (m *MealPlan) GenerateMealPlanForCurrentUser(
+	ctx context.Context,
+	startDay time.Time,
+	days int,
+) (models.MealPlan, error) {
+	const op = "services.mealplan.GenerateMealPlanForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not generate meal plan - incorrect token")
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if days < 1 || days > MaxDays {
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, ErrInvalidDays)
+	}
+
+	foods, err := m.catalogProvider.FoodsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get foods", slog.String("err", err.Error()))
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recipes, err := m.catalogProvider.RecipesByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get recipes", slog.String("err", err.Error()))
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restrictions, err := m.restrictions.RestrictionsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get restrictions", slog.String("err", err.Error()))
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foods, recipes = Candidates(foods, recipes, restrictions)
+	if len(foods) == 0 && len(recipes) == 0 {
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, ErrNoCandidates)
+	}
+
+	startDay = time.Date(startDay.Year(), startDay.Month(), startDay.Day(), 0, 0, 0, 0, time.UTC)
+
+	plan := models.MealPlan{
+		AccountId:    acc.Id,
+		CreatedAt:    time.Now(),
+		StartDay:     startDay,
+		DailyLimit:   acc.DailyLimit,
+		MacroTargets: acc.MacroTargets,
+		Days:         Generate(foods, recipes, startDay, days, acc.DailyLimit, acc.MacroTargets),
+	}
+
+	plan.Id, err = m.planSaver.SaveMealPlan(ctx, plan)
+	if err != nil {
+		log.Error("failed to save meal plan", slog.String("err", err.Error()))
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Saved plan is read back for ids of planned meals
+	plan, err = m.planProvider.MealPlanById(ctx, acc.Id, plan.Id)
+	if err != nil {
+		log.Error("failed to get meal plan", slog.String("err", err.Error()))
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return plan.In(acc.EnergyUnit), nil
+}
+
+func (m *MealPlan) GetMealPlansForCurrentUser(ctx context.Context) ([]models.MealPlan, error) {
+	const op = "services.mealplan.GetMealPlansForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get meal plans - incorrect token")
+		return []models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	plans, err := m.planProvider.MealPlansByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get meal plans", slog.String("err", err.Error()))
+		return []models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(plans) == 0 {
+		return []models.MealPlan{}, nil
+	}
+
+	for i := range plans {
+		plans[i] = plans[i].In(acc.EnergyUnit)
+	}
+
+	return plans, nil
+}
+
+// LogPlannedMealForCurrentUser creates a record of a planned meal at its
+// usual hour of the planned day in loc. Record creation rules (restrictions,
+// streaks, achievements) apply as for any other record.
+func (m *MealPlan) LogPlannedMealForCurrentUser(
+	ctx context.Context,
+	planId int64,
+	mealId int64,
+	loc *time.Location,
+) (models.Record, []models.RestrictionWarning, error) {
+	const op = "services.mealplan.LogPlannedMealForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not log planned meal - incorrect token")
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	plan, err := m.planProvider.MealPlanById(ctx, acc.Id, planId)
+	if err != nil {
+		if errors.Is(err, storage.ErrMealPlanNotFound) {
+			return models.Record{}, nil, fmt.Errorf("%s: %w", op, ErrMealPlanNotFound)
+		}
+
+		log.Error("failed to get meal plan", slog.String("err", err.Error()))
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var planned *models.PlannedMeal
+
+	for _, meal := range plan.Meals() {
+		if meal.Id == mealId {
+			planned = &meal
+			break
+		}
+	}
+
+	if planned == nil {
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, ErrPlannedMealNotFound)
+	}
+
+	if planned.RecordId != nil {
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, ErrPlannedMealLogged)
+	}
+
+	record, warnings, err := m.recordCreator.CreateRecordForCurrentUser(
+		ctx,
+		PlannedRecord(*planned, acc.EnergyUnit, loc),
+	)
+	if err != nil {
+		return models.Record{}, warnings, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := m.planSaver.SetPlannedMealRecord(ctx, plan.Id, planned.Id, record.Id); err != nil {
+		if errors.Is(err, storage.ErrPlannedMealLogged) {
+			log.Info("planned meal logged concurrently", slog.Int64("mealId", planned.Id))
+			return models.Record{}, nil, fmt.Errorf("%s: %w", op, ErrPlannedMealLogged)
+		}
+
+		log.Error("failed to link planned meal record", slog.String("err", err.Error()))
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return record, warnings, nil
+}
+
+// PlannedRecord is a record of a planned meal referencing its catalog food
+// or recipe, planned value and nutrients are used when the catalog entry
+// is gone. Value is in unit as records are created in the account unit.
+func PlannedRecord(
+	meal models.PlannedMeal,
+	unit models.EnergyUnit,
+	loc *time.Location,
+) models.Record {
+	record := models.Record{
+		DateRecord: time.Date(
+			meal.Day.Year(), meal.Day.Month(), meal.Day.Day(),
+			meal.Meal.Hour(), 0, 0, 0, loc,
+		),
+		Meal: meal.Meal,
+	}
+
+	switch {
+	case meal.FoodId != nil:
+		record.FoodId = meal.FoodId
+		record.Grams = meal.Grams
+	case meal.RecipeId != nil:
+		record.RecipeId = meal.RecipeId
+		record.Servings = meal.Servings
+	default:
+		record.Value = unit.FromKcal(meal.Value)
+		record.Description = meal.Name
+		record.Nutrients = meal.Nutrients
+	}
+
+	return record
+}
+
+// Candidates filters out foods and recipes conflicting with restrictions
+// and keeps favorites only when there are any.
+func Candidates(
+	foods []models.Food,
+	recipes []models.Recipe,
+	restrictions models.Restrictions,
+) ([]models.Food, []models.Recipe) {
+	var (
+		allowedFoods    []models.Food
+		allowedRecipes  []models.Recipe
+		favoriteFoods   []models.Food
+		favoriteRecipes []models.Recipe
+	)
+
+	for _, food := range foods {
+		if food.Value <= 0 || len(restriction.Conflicts(restrictions, food.Allergens)) > 0 {
+			continue
+		}
+
+		allowedFoods = append(allowedFoods, food)
+		if food.Favorite {
+			favoriteFoods = append(favoriteFoods, food)
+		}
+	}
+
+	for _, recipe := range recipes {
+		if recipe.Value <= 0 || len(restriction.Conflicts(restrictions, recipe.Allergens)) > 0 {
+			continue
+		}
+
+		allowedRecipes = append(allowedRecipes, recipe)
+		if recipe.Favorite {
+			favoriteRecipes = append(favoriteRecipes, recipe)
+		}
+	}
+
+	if len(favoriteFoods) > 0 || len(favoriteRecipes) > 0 {
+		return favoriteFoods, favoriteRecipes
+	}
+
+	return allowedFoods, allowedRecipes
+}
@@ -0,0 +1,173 @@
+package mealplan_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/mealplan"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oats = models.Food{
+		Id:    1,
+		Name:  "Oats",
+		Value: 380,
+		Nutrients: models.Nutrients{
+			models.NutrientCarbs: 66, models.NutrientProtein: 13, models.NutrientFat: 7,
+		},
+	}
+	chicken = models.Food{
+		Id:    2,
+		Name:  "Chicken breast",
+		Value: 165,
+		Nutrients: models.Nutrients{
+			models.NutrientProtein: 31, models.NutrientFat: 4,
+		},
+	}
+	rice = models.Food{
+		Id:    3,
+		Name:  "Rice",
+		Value: 130,
+		Nutrients: models.Nutrients{
+			models.NutrientCarbs: 28, models.NutrientProtein: 3,
+		},
+	}
+	peanuts = models.Food{
+		Id:        4,
+		Name:      "Peanuts",
+		Value:     567,
+		Allergens: []models.Allergen{models.AllergenPeanuts},
+		Nutrients: models.Nutrients{
+			models.NutrientCarbs: 16, models.NutrientProtein: 26, models.NutrientFat: 49,
+		},
+	}
+	stew = models.Recipe{
+		Id:       1,
+		Name:     "Stew",
+		Servings: 4,
+		Value:    450,
+		Nutrients: models.Nutrients{
+			models.NutrientCarbs: 30, models.NutrientProtein: 35, models.NutrientFat: 18,
+		},
+	}
+)
+
+func TestGenerate(t *testing.T) {
+	startDay := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	targets := models.MacroTargets{Protein: 150}
+
+	days := mealplan.Generate(
+		[]models.Food{oats, chicken, rice, peanuts},
+		[]models.Recipe{stew},
+		startDay,
+		3,
+		2000,
+		targets,
+	)
+
+	require.Len(t, days, 3)
+
+	for i, day := range days {
+		require.Equal(t, startDay.AddDate(0, 0, i), day.Day)
+		require.Len(t, day.Meals, 4)
+
+		// Within 5% of the daily limit and 10% of the protein target
+		require.InDelta(t, 2000, day.Value, 100)
+		require.InDelta(t, 150, day.Nutrients[models.NutrientProtein], 15)
+
+		names := map[string]bool{}
+
+		for _, meal := range day.Meals {
+			require.Equal(t, day.Day, meal.Day)
+			require.False(t, names[meal.Name], "%s is planned twice a day", meal.Name)
+			names[meal.Name] = true
+		}
+	}
+
+	require.Equal(t, []models.Meal{
+		models.MealBreakfast, models.MealLunch, models.MealSnack, models.MealDinner,
+	}, []models.Meal{
+		days[0].Meals[0].Meal, days[0].Meals[1].Meal, days[0].Meals[2].Meal, days[0].Meals[3].Meal,
+	})
+
+	// Deterministic for the same input
+	require.Equal(t, days, mealplan.Generate(
+		[]models.Food{oats, chicken, rice, peanuts},
+		[]models.Recipe{stew},
+		startDay,
+		3,
+		2000,
+		targets,
+	))
+}
+
+func TestGenerateSingleFood(t *testing.T) {
+	startDay := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	days := mealplan.Generate([]models.Food{rice}, nil, startDay, 1, 1000, models.MacroTargets{})
+
+	require.Len(t, days, 1)
+	require.Len(t, days[0].Meals, 4)
+	require.LessOrEqual(t, math.Abs(float64(days[0].Value-1000)), 50.0)
+
+	for _, meal := range days[0].Meals {
+		require.Equal(t, rice.Id, *meal.FoodId)
+		require.Nil(t, meal.RecipeId)
+		require.Equal(t, int(math.Round(meal.Grams*1.3)), meal.Value)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	restrictions := models.Restrictions{Allergens: []models.Allergen{models.AllergenPeanuts}}
+
+	foods, recipes := mealplan.Candidates(
+		[]models.Food{oats, peanuts, {Id: 5, Name: "Water"}},
+		[]models.Recipe{stew},
+		restrictions,
+	)
+	require.Equal(t, []models.Food{oats}, foods)
+	require.Equal(t, []models.Recipe{stew}, recipes)
+
+	favorite := chicken
+	favorite.Favorite = true
+
+	foods, recipes = mealplan.Candidates(
+		[]models.Food{oats, favorite},
+		[]models.Recipe{stew},
+		restrictions,
+	)
+	require.Equal(t, []models.Food{favorite}, foods)
+	require.Empty(t, recipes)
+}
+
+func TestPlannedRecord(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	foodId := int64(2)
+
+	meal := models.PlannedMeal{
+		Id:     10,
+		Day:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Meal:   models.MealDinner,
+		FoodId: &foodId,
+		Name:   "Chicken breast",
+		Grams:  200,
+		Value:  330,
+	}
+
+	record := mealplan.PlannedRecord(meal, models.EnergyUnitKj, loc)
+	require.Equal(t, time.Date(2024, 4, 1, 19, 0, 0, 0, loc), record.DateRecord)
+	require.Equal(t, models.MealDinner, record.Meal)
+	require.Equal(t, &foodId, record.FoodId)
+	require.Equal(t, 200.0, record.Grams)
+	// Value is filled from the catalog food
+	require.Equal(t, 0, record.Value)
+
+	// Catalog food is gone, planned value is logged in the account unit
+	meal.FoodId = nil
+	record = mealplan.PlannedRecord(meal, models.EnergyUnitKj, loc)
+	require.Equal(t, 1381, record.Value)
+	require.Equal(t, "Chicken breast", record.Description)
+}
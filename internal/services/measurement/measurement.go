@@ -0,0 +1,393 @@
+package measurement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Measurement struct {
+	log                 *slog.Logger
+	measurementProvider MeasurementProvider
+	measurementSaver    MeasurementSaver
+	measurementRemover  MeasurementRemover
+	weightProvider      WeightProvider
+	accountProvider     AccountProvider
+}
+
+type MeasurementProvider interface {
+	MeasurementsByAccountId(
+		ctx context.Context,
+		accountId int64,
+		measurementType models.MeasurementType,
+	) ([]models.Measurement, error)
+}
+
+type MeasurementSaver interface {
+	SaveMeasurement(ctx context.Context, measurement models.Measurement) (int64, error)
+}
+
+type MeasurementRemover interface {
+	DeleteMeasurement(ctx context.Context, accountId int64, measurementId int64) error
+}
+
+type WeightProvider interface {
+	WeightsByAccountId(ctx context.Context, accountId int64) ([]models.Weight, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+// typeRe keeps custom types short slugs, e.g. "calf" or "upper_arm".
+var typeRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+var (
+	ErrInvalidType         = errors.New("invalid measurement type")
+	ErrInvalidUnit         = errors.New("invalid measurement unit")
+	ErrInvalidValue        = errors.New("invalid measurement value")
+	ErrMeasurementNotFound = errors.New("measurement not found")
+)
+
+func New(
+	log *slog.Logger,
+	measurementProvider MeasurementProvider,
+	measurementSaver MeasurementSaver,
+	measurementRemover MeasurementRemover,
+	weightProvider WeightProvider,
+	accountProvider AccountProvider,
+) *Measurement {
+	return &Measurement{
+		log:                 log,
+		measurementProvider: measurementProvider,
+		measurementSaver:    measurementSaver,
+		measurementRemover:  measurementRemover,
+		weightProvider:      weightProvider,
+		accountProvider:     accountProvider,
+	}
+}
+
+// GetMeasurementsForCurrentUser returns measurements oldest first,
+// of all types for an empty type.
+func (m *Measurement) GetMeasurementsForCurrentUser(
+	ctx context.Context,
+	measurementType string,
+) ([]models.Measurement, error) {
+	const op = "services.measurement.GetMeasurementsForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get measurements - incorrect token")
+		return []models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var t models.MeasurementType
+
+	if measurementType != "" {
+		t, err = NormalizeType(measurementType)
+		if err != nil {
+			return []models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	measurements, err := m.measurementProvider.MeasurementsByAccountId(ctx, acc.Id, t)
+	if err != nil {
+		log.Error("failed to get measurements", slog.String("err", err.Error()))
+		return []models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(measurements) == 0 {
+		measurements = []models.Measurement{}
+	}
+
+	return measurements, nil
+}
+
+func (m *Measurement) CreateMeasurementForCurrentUser(
+	ctx context.Context,
+	measurement models.Measurement,
+) (models.Measurement, error) {
+	const op = "services.measurement.CreateMeasurementForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not create measurement - incorrect token")
+		return models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	measurement.Type, err = NormalizeType(string(measurement.Type))
+	if err != nil {
+		return models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := Validate(measurement); err != nil {
+		return models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	measurement.AccountId = acc.Id
+	measurement.DateCreated = time.Now().UTC()
+
+	measurement.Id, err = m.measurementSaver.SaveMeasurement(ctx, measurement)
+	if err != nil {
+		log.Error("failed to save measurement", slog.String("err", err.Error()))
+		return models.Measurement{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return measurement, nil
+}
+
+func (m *Measurement) DeleteMeasurementForCurrentUser(ctx context.Context, measurementId int64) error {
+	const op = "services.measurement.DeleteMeasurementForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not delete measurement - incorrect token")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := m.measurementRemover.DeleteMeasurement(ctx, acc.Id, measurementId); err != nil {
+		if errors.Is(err, storage.ErrMeasurementNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrMeasurementNotFound)
+		}
+
+		log.Error("failed to delete measurement", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetMeasurementSummaryForCurrentUser returns the latest measurement of
+// every type with metrics derived from them and the latest weight.
+func (m *Measurement) GetMeasurementSummaryForCurrentUser(
+	ctx context.Context,
+) (models.MeasurementSummary, error) {
+	const op = "services.measurement.GetMeasurementSummaryForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get measurement summary - incorrect token")
+		return models.MeasurementSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	measurements, err := m.measurementProvider.MeasurementsByAccountId(ctx, acc.Id, "")
+	if err != nil {
+		log.Error("failed to get measurements", slog.String("err", err.Error()))
+		return models.MeasurementSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	weights, err := m.weightProvider.WeightsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get weights", slog.String("err", err.Error()))
+		return models.MeasurementSummary{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Summary(measurements, weights), nil
+}
+
+// GetMeasurementTrendForCurrentUser returns the series of the type
+// for days from..to inclusive.
+func (m *Measurement) GetMeasurementTrendForCurrentUser(
+	ctx context.Context,
+	measurementType string,
+	from time.Time,
+	to time.Time,
+) (models.MeasurementSeries, error) {
+	const op = "services.measurement.GetMeasurementTrendForCurrentUser"
+
+	log := m.log.With(slog.String("op", op))
+
+	acc, err := m.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get measurement trend - incorrect token")
+		return models.MeasurementSeries{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	t, err := NormalizeType(measurementType)
+	if err != nil {
+		return models.MeasurementSeries{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	measurements, err := m.measurementProvider.MeasurementsByAccountId(ctx, acc.Id, t)
+	if err != nil {
+		log.Error("failed to get measurements", slog.String("err", err.Error()))
+		return models.MeasurementSeries{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return Series(t, measurements, from, to), nil
+}
+
+// NormalizeType lowercases the type, types are matched case-insensitively.
+func NormalizeType(measurementType string) (models.MeasurementType, error) {
+	measurementType = strings.ToLower(strings.TrimSpace(measurementType))
+
+	if !typeRe.MatchString(measurementType) {
+		return "", ErrInvalidType
+	}
+
+	return models.MeasurementType(measurementType), nil
+}
+
+// Validate checks the unit fits the type and the value fits the unit.
+func Validate(measurement models.Measurement) error {
+	if !measurement.Unit.Valid() {
+		return ErrInvalidUnit
+	}
+
+	if units := measurement.Type.Units(); units != nil && !slices.Contains(units, measurement.Unit) {
+		return ErrInvalidUnit
+	}
+
+	if measurement.Value <= 0 ||
+		measurement.Unit == models.MeasurementUnitPercent && measurement.Value >= 100 {
+		return ErrInvalidValue
+	}
+
+	return nil
+}
+
+// Summary takes the latest measurement of every type from measurements
+// sorted oldest first, derived metrics use the latest values as well.
+func Summary(measurements []models.Measurement, weights []models.Weight) models.MeasurementSummary {
+	latest := make(map[models.MeasurementType]models.Measurement)
+	for _, measurement := range measurements {
+		latest[measurement.Type] = measurement
+	}
+
+	summary := models.MeasurementSummary{Latest: make([]models.Measurement, 0, len(latest))}
+	for _, measurement := range latest {
+		summary.Latest = append(summary.Latest, measurement)
+	}
+	sort.Slice(summary.Latest, func(i, j int) bool {
+		return summary.Latest[i].Type < summary.Latest[j].Type
+	})
+
+	waist, okWaist := cm(latest[models.MeasurementWaist])
+	height, okHeight := cm(latest[models.MeasurementHeight])
+	if okWaist && okHeight {
+		ratio := round(waist/height, 2)
+		summary.Derived.WaistToHeight = &ratio
+	}
+
+	bodyFat, okBodyFat := latest[models.MeasurementBodyFat]
+	if okBodyFat && len(weights) > 0 {
+		weight := weights[len(weights)-1].Value
+		fatMass := round(weight*bodyFat.Value/100, 1)
+		leanMass := round(weight-weight*bodyFat.Value/100, 1)
+		summary.Derived.FatMass = &fatMass
+		summary.Derived.LeanMass = &leanMass
+	}
+
+	return summary
+}
+
+// Series keeps the last measurement of every day from..to, values are
+// converted to the unit of the latest one. Change and WeeklyRate need
+// at least two days.
+func Series(
+	measurementType models.MeasurementType,
+	measurements []models.Measurement,
+	from time.Time,
+	to time.Time,
+) models.MeasurementSeries {
+	from = truncateDay(from)
+	to = truncateDay(to)
+
+	series := models.MeasurementSeries{
+		Type:   measurementType,
+		From:   from,
+		To:     to,
+		Points: []models.MeasurementPoint{},
+	}
+
+	var inRange []models.Measurement
+	for _, measurement := range measurements {
+		day := truncateDay(measurement.DateRecord)
+		if measurement.Type == measurementType && !day.Before(from) && !day.After(to) {
+			inRange = append(inRange, measurement)
+		}
+	}
+
+	if len(inRange) == 0 {
+		return series
+	}
+
+	series.Unit = inRange[len(inRange)-1].Unit
+
+	for _, measurement := range inRange {
+		// Custom types may mix dimensions, other units are left out
+		value, ok := measurement.Unit.Convert(measurement.Value, series.Unit)
+		if !ok {
+			continue
+		}
+
+		point := models.MeasurementPoint{
+			Day:   truncateDay(measurement.DateRecord),
+			Value: round(value, 2),
+		}
+
+		if n := len(series.Points); n > 0 && series.Points[n-1].Day.Equal(point.Day) {
+			series.Points[n-1] = point
+		} else {
+			series.Points = append(series.Points, point)
+		}
+	}
+
+	if len(series.Points) < 2 {
+		return series
+	}
+
+	first, last := series.Points[0], series.Points[len(series.Points)-1]
+	change := round(last.Value-first.Value, 2)
+	series.Change = &change
+
+	// Least squares slope over days since the first point
+	var sumX, sumY, sumXY, sumXX float64
+	n := float64(len(series.Points))
+
+	for _, point := range series.Points {
+		x := point.Day.Sub(first.Day).Hours() / 24
+		sumX += x
+		sumY += point.Value
+		sumXY += x * point.Value
+		sumXX += x * x
+	}
+
+	rate := round((n*sumXY-sumX*sumY)/(n*sumXX-sumX*sumX)*7, 2)
+	series.WeeklyRate = &rate
+
+	return series
+}
+
+func cm(measurement models.Measurement) (float64, bool) {
+	return measurement.Unit.Convert(measurement.Value, models.MeasurementUnitCm)
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
+
+// truncateDay maps a date to UTC midnight like daily totals.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
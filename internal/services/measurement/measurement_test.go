@@ -0,0 +1,135 @@
+package measurement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/measurement"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 4, d, 8, 0, 0, 0, time.UTC)
+}
+
+func TestNormalizeType(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    models.MeasurementType
+		expectedErr error
+	}{
+		{input: "Waist", expected: models.MeasurementWaist},
+		{input: " upper_arm ", expected: "upper_arm"},
+		{input: "", expectedErr: measurement.ErrInvalidType},
+		{input: "1st", expectedErr: measurement.ErrInvalidType},
+		{input: "upper arm", expectedErr: measurement.ErrInvalidType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := measurement.NormalizeType(tc.input)
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		measurement models.Measurement
+		expectedErr error
+	}{
+		{
+			name:        "waist in inches",
+			measurement: models.Measurement{Type: models.MeasurementWaist, Value: 32, Unit: models.MeasurementUnitIn},
+		},
+		{
+			name:        "custom type in any unit",
+			measurement: models.Measurement{Type: "grip", Value: 40, Unit: models.MeasurementUnitKg},
+		},
+		{
+			name:        "waist in percents",
+			measurement: models.Measurement{Type: models.MeasurementWaist, Value: 32, Unit: models.MeasurementUnitPercent},
+			expectedErr: measurement.ErrInvalidUnit,
+		},
+		{
+			name:        "unknown unit",
+			measurement: models.Measurement{Type: "calf", Value: 32, Unit: "ft"},
+			expectedErr: measurement.ErrInvalidUnit,
+		},
+		{
+			name:        "body fat over 100%",
+			measurement: models.Measurement{Type: models.MeasurementBodyFat, Value: 100, Unit: models.MeasurementUnitPercent},
+			expectedErr: measurement.ErrInvalidValue,
+		},
+		{
+			name:        "zero value",
+			measurement: models.Measurement{Type: models.MeasurementHips, Value: 0, Unit: models.MeasurementUnitCm},
+			expectedErr: measurement.ErrInvalidValue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, measurement.Validate(tc.measurement), tc.expectedErr)
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	measurements := []models.Measurement{
+		{Type: models.MeasurementWaist, Value: 90, Unit: models.MeasurementUnitCm, DateRecord: day(1)},
+		{Type: models.MeasurementHeight, Value: 70, Unit: models.MeasurementUnitIn, DateRecord: day(1)},
+		{Type: models.MeasurementBodyFat, Value: 25, Unit: models.MeasurementUnitPercent, DateRecord: day(2)},
+		{Type: models.MeasurementWaist, Value: 88.9, Unit: models.MeasurementUnitCm, DateRecord: day(3)},
+	}
+	weights := []models.Weight{{Value: 82}, {Value: 80}}
+
+	summary := measurement.Summary(measurements, weights)
+
+	require.Len(t, summary.Latest, 3)
+	require.Equal(t, models.MeasurementBodyFat, summary.Latest[0].Type)
+	require.Equal(t, measurements[3], summary.Latest[2])
+
+	// 88.9 cm / 177.8 cm
+	require.Equal(t, 0.5, *summary.Derived.WaistToHeight)
+	require.Equal(t, 60.0, *summary.Derived.LeanMass)
+	require.Equal(t, 20.0, *summary.Derived.FatMass)
+
+	empty := measurement.Summary(measurements[:1], nil)
+	require.Nil(t, empty.Derived.WaistToHeight)
+	require.Nil(t, empty.Derived.LeanMass)
+	require.Equal(t, []models.Measurement{}, measurement.Summary(nil, nil).Latest)
+}
+
+func TestSeries(t *testing.T) {
+	measurements := []models.Measurement{
+		{Type: models.MeasurementWaist, Value: 100, Unit: models.MeasurementUnitCm, DateRecord: day(1)},
+		{Type: models.MeasurementWaist, Value: 96, Unit: models.MeasurementUnitCm, DateRecord: day(2)},
+		{Type: models.MeasurementWaist, Value: 95, Unit: models.MeasurementUnitCm, DateRecord: day(2).Add(time.Hour)},
+		{Type: models.MeasurementWaist, Value: 36, Unit: models.MeasurementUnitIn, DateRecord: day(9)},
+		{Type: models.MeasurementWaist, Value: 30, Unit: models.MeasurementUnitIn, DateRecord: day(20)},
+	}
+
+	series := measurement.Series(models.MeasurementWaist, measurements, day(2), day(15))
+
+	require.Equal(t, models.MeasurementUnitIn, series.Unit)
+	require.Equal(t, []models.MeasurementPoint{
+		{Day: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), Value: 37.4},
+		{Day: time.Date(2024, 4, 9, 0, 0, 0, 0, time.UTC), Value: 36},
+	}, series.Points)
+	require.Equal(t, -1.4, *series.Change)
+	require.Equal(t, -1.4, *series.WeeklyRate)
+
+	single := measurement.Series(models.MeasurementWaist, measurements, day(20), day(20))
+	require.Len(t, single.Points, 1)
+	require.Nil(t, single.Change)
+	require.Nil(t, single.WeeklyRate)
+
+	empty := measurement.Series(models.MeasurementHips, measurements, day(1), day(30))
+	require.Equal(t, []models.MeasurementPoint{}, empty.Points)
+	require.Equal(t, models.MeasurementUnit(""), empty.Unit)
+}
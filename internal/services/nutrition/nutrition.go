@@ -0,0 +1,184 @@
+package nutrition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Nutrition struct {
+	log              *slog.Logger
+	nutrientProvider NutrientProvider
+	accountProvider  AccountProvider
+}
+
+type NutrientProvider interface {
+	NutrientAmountsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.NutrientAmount, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	// Average below deficientShare of the target is a deficiency
+	deficientShare = 0.7
+)
+
+var (
+	ErrProfileIncomplete = errors.New("account profile is incomplete")
+)
+
+func New(
+	log *slog.Logger,
+	nutrientProvider NutrientProvider,
+	accountProvider AccountProvider,
+) *Nutrition {
+	return &Nutrition{
+		log:              log,
+		nutrientProvider: nutrientProvider,
+		accountProvider:  accountProvider,
+	}
+}
+
+// GetMicronutrientReportForCurrentUser compares average daily intake for days
+// from..to inclusive with reference intakes for the account sex and age.
+// Days are taken in the location of from.
+func (n *Nutrition) GetMicronutrientReportForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (models.MicronutrientReport, error) {
+	const op = "services.nutrition.GetMicronutrientReportForCurrentUser"
+
+	log := n.log.With(slog.String("op", op))
+
+	acc, err := n.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get micronutrient report - incorrect token")
+		return models.MicronutrientReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if acc.Sex == "" || acc.BirthDate == nil {
+		return models.MicronutrientReport{}, fmt.Errorf("%s: %w", op, ErrProfileIncomplete)
+	}
+
+	age := Age(*acc.BirthDate, to)
+
+	references, err := ReferenceIntakes(acc.Sex, age)
+	if err != nil {
+		return models.MicronutrientReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	amounts, err := n.nutrientProvider.NutrientAmountsByAccountIdInRange(
+		ctx,
+		acc.Id,
+		from,
+		to.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		log.Error("failed to get nutrient amounts", slog.String("err", err.Error()))
+		return models.MicronutrientReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report := MicronutrientReport(amounts, references, from.Location())
+	report.From = from
+	report.To = to
+	report.Sex = acc.Sex
+	report.Age = age
+
+	return report, nil
+}
+
+// Age returns full years between birthDate and on.
+func Age(birthDate time.Time, on time.Time) int {
+	age := on.Year() - birthDate.Year()
+
+	if on.Month() < birthDate.Month() ||
+		(on.Month() == birthDate.Month() && on.Day() < birthDate.Day()) {
+		age--
+	}
+
+	return age
+}
+
+// MicronutrientReport averages amounts over days with nutrient data and
+// flags every known nutrient against references.
+func MicronutrientReport(
+	amounts []models.NutrientAmount,
+	references map[models.Nutrient]models.ReferenceIntake,
+	loc *time.Location,
+) models.MicronutrientReport {
+	totals := make(map[models.Nutrient]float64, len(models.NutrientUnits))
+	days := make(map[string]struct{})
+
+	for _, amount := range amounts {
+		if _, ok := models.NutrientUnits[amount.Nutrient]; !ok {
+			continue
+		}
+
+		totals[amount.Nutrient] += amount.Amount
+		days[amount.DateRecord.In(loc).Format(time.DateOnly)] = struct{}{}
+	}
+
+	report := models.MicronutrientReport{
+		DaysLogged: len(days),
+		Nutrients:  make([]models.NutrientIntake, 0, len(models.NutrientUnits)),
+	}
+
+	for nutrient, unit := range models.NutrientUnits {
+		reference := references[nutrient]
+
+		intake := models.NutrientIntake{
+			Nutrient:   nutrient,
+			Unit:       unit,
+			Target:     reference.Target,
+			UpperLimit: reference.UpperLimit,
+			Status:     models.NutrientStatusAdequate,
+		}
+
+		if report.DaysLogged == 0 {
+			intake.Status = models.NutrientStatusNoData
+			report.Nutrients = append(report.Nutrients, intake)
+			continue
+		}
+
+		intake.Average = round(totals[nutrient] / float64(report.DaysLogged))
+
+		if reference.Target > 0 {
+			intake.Percent = round(intake.Average / reference.Target * 100)
+
+			if intake.Average < reference.Target*deficientShare {
+				intake.Status = models.NutrientStatusDeficient
+			}
+		}
+
+		if reference.UpperLimit > 0 && intake.Average > reference.UpperLimit {
+			intake.Status = models.NutrientStatusExcess
+		}
+
+		report.Nutrients = append(report.Nutrients, intake)
+	}
+
+	sort.Slice(report.Nutrients, func(i, j int) bool {
+		return report.Nutrients[i].Nutrient < report.Nutrients[j].Nutrient
+	})
+
+	return report
+}
+
+// round rounds to 2 decimal places.
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,97 @@
+package nutrition_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/nutrition"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAge(t *testing.T) {
+	birthDate := time.Date(1990, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, 33, nutrition.Age(birthDate, time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, 34, nutrition.Age(birthDate, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, 34, nutrition.Age(birthDate, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestReferenceIntakes(t *testing.T) {
+	female, err := nutrition.ReferenceIntakes(models.SexFemale, 35)
+	require.NoError(t, err)
+	require.Len(t, female, len(models.NutrientUnits))
+	require.Equal(t, models.ReferenceIntake{Target: 18, UpperLimit: 45}, female[models.NutrientIron])
+	require.Equal(t, models.ReferenceIntake{Target: 1000, UpperLimit: 2500}, female[models.NutrientCalcium])
+	require.Equal(t, models.ReferenceIntake{UpperLimit: 50}, female[models.NutrientSugar])
+
+	male, err := nutrition.ReferenceIntakes(models.SexMale, 75)
+	require.NoError(t, err)
+	require.Equal(t, models.ReferenceIntake{Target: 8, UpperLimit: 45}, male[models.NutrientIron])
+	require.Equal(t, models.ReferenceIntake{Target: 1200, UpperLimit: 2000}, male[models.NutrientCalcium])
+	require.Equal(t, models.ReferenceIntake{Target: 20, UpperLimit: 100}, male[models.NutrientVitaminD])
+	require.Equal(t, models.ReferenceIntake{Target: 420}, male[models.NutrientMagnesium])
+
+	_, err = nutrition.ReferenceIntakes(models.SexMale, 10)
+	require.ErrorIs(t, err, nutrition.ErrNoReference)
+}
+
+func TestMicronutrientReport(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, 4, day, hour, 0, 0, 0, loc).UTC()
+	}
+
+	amounts := []models.NutrientAmount{
+		{RecordId: 1, DateRecord: at(1, 1), Nutrient: models.NutrientSodium, Amount: 2000},
+		{RecordId: 1, DateRecord: at(1, 1), Nutrient: models.NutrientIron, Amount: 4},
+		{RecordId: 2, DateRecord: at(1, 20), Nutrient: models.NutrientSodium, Amount: 1000},
+		{RecordId: 3, DateRecord: at(2, 9), Nutrient: models.NutrientSodium, Amount: 2000},
+		{RecordId: 3, DateRecord: at(2, 9), Nutrient: models.NutrientVitaminC, Amount: 150},
+		{RecordId: 4, DateRecord: at(3, 9), Nutrient: models.NutrientCarbs, Amount: 60},
+	}
+
+	references := map[models.Nutrient]models.ReferenceIntake{
+		models.NutrientSodium:   {Target: 1500, UpperLimit: 2300},
+		models.NutrientIron:     {Target: 18, UpperLimit: 45},
+		models.NutrientVitaminC: {Target: 75, UpperLimit: 2000},
+	}
+
+	report := nutrition.MicronutrientReport(amounts, references, loc)
+
+	require.Equal(t, 2, report.DaysLogged)
+	require.Len(t, report.Nutrients, len(models.NutrientUnits))
+
+	intakes := make(map[models.Nutrient]models.NutrientIntake, len(report.Nutrients))
+	for _, intake := range report.Nutrients {
+		intakes[intake.Nutrient] = intake
+	}
+
+	require.Equal(t, models.NutrientIntake{
+		Nutrient:   models.NutrientSodium,
+		Unit:       "mg",
+		Average:    2500,
+		Target:     1500,
+		UpperLimit: 2300,
+		Percent:    166.67,
+		Status:     models.NutrientStatusExcess,
+	}, intakes[models.NutrientSodium])
+
+	require.Equal(t, 2.0, intakes[models.NutrientIron].Average)
+	require.Equal(t, models.NutrientStatusDeficient, intakes[models.NutrientIron].Status)
+
+	require.Equal(t, 75.0, intakes[models.NutrientVitaminC].Average)
+	require.Equal(t, 100.0, intakes[models.NutrientVitaminC].Percent)
+	require.Equal(t, models.NutrientStatusAdequate, intakes[models.NutrientVitaminC].Status)
+
+	require.Equal(t, models.NutrientStatusAdequate, intakes[models.NutrientSugar].Status)
+}
+
+func TestMicronutrientReportEmpty(t *testing.T) {
+	report := nutrition.MicronutrientReport(nil, nil, time.UTC)
+
+	require.Equal(t, 0, report.DaysLogged)
+	require.Len(t, report.Nutrients, len(models.NutrientUnits))
+	require.Equal(t, models.NutrientStatusNoData, report.Nutrients[0].Status)
+}
@@ -0,0 +1,112 @@
+package nutrition
+
+import (
+	"errors"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+// MinReferenceAge is the youngest age covered by built-in reference intakes.
+const MinReferenceAge = 14
+
+var ErrNoReference = errors.New("no reference intakes for age")
+
+// referenceRule applies to accounts of sex (any if empty) aged minAge or older
+// until a later rule of the same nutrient matches.
+type referenceRule struct {
+	sex    models.Sex
+	minAge int
+	models.ReferenceIntake
+}
+
+func rule(sex models.Sex, minAge int, target float64, upperLimit float64) referenceRule {
+	return referenceRule{
+		sex:             sex,
+		minAge:          minAge,
+		ReferenceIntake: models.ReferenceIntake{Target: target, UpperLimit: upperLimit},
+	}
+}
+
+// referenceRules are based on the Dietary Reference Intakes (RDA or AI as
+// target, UL as upper limit). Sodium, sugar and saturated fat use common
+// dietary guideline limits for a 2000 kcal diet.
+var referenceRules = map[models.Nutrient][]referenceRule{
+	models.NutrientSodium: {
+		rule("", 14, 1500, 2300),
+	},
+	models.NutrientSugar: {
+		rule("", 14, 0, 50),
+	},
+	models.NutrientSaturatedFat: {
+		rule("", 14, 0, 20),
+	},
+	models.NutrientFiber: {
+		rule(models.SexMale, 14, 38, 0),
+		rule(models.SexMale, 51, 30, 0),
+		rule(models.SexFemale, 14, 26, 0),
+		rule(models.SexFemale, 19, 25, 0),
+		rule(models.SexFemale, 51, 21, 0),
+	},
+	models.NutrientVitaminA: {
+		rule(models.SexMale, 14, 900, 3000),
+		rule(models.SexFemale, 14, 700, 2800),
+		rule(models.SexFemale, 19, 700, 3000),
+	},
+	models.NutrientVitaminC: {
+		rule(models.SexMale, 14, 75, 1800),
+		rule(models.SexMale, 19, 90, 2000),
+		rule(models.SexFemale, 14, 65, 1800),
+		rule(models.SexFemale, 19, 75, 2000),
+	},
+	models.NutrientVitaminD: {
+		rule("", 14, 15, 100),
+		rule("", 71, 20, 100),
+	},
+	models.NutrientCalcium: {
+		rule("", 14, 1300, 3000),
+		rule("", 19, 1000, 2500),
+		rule(models.SexMale, 51, 1000, 2000),
+		rule(models.SexMale, 71, 1200, 2000),
+		rule(models.SexFemale, 51, 1200, 2000),
+	},
+	models.NutrientIron: {
+		rule(models.SexMale, 14, 11, 45),
+		rule(models.SexMale, 19, 8, 45),
+		rule(models.SexFemale, 14, 15, 45),
+		rule(models.SexFemale, 19, 18, 45),
+		rule(models.SexFemale, 51, 8, 45),
+	},
+	models.NutrientPotassium: {
+		rule(models.SexMale, 14, 3000, 0),
+		rule(models.SexMale, 19, 3400, 0),
+		rule(models.SexFemale, 14, 2300, 0),
+		rule(models.SexFemale, 19, 2600, 0),
+	},
+	models.NutrientMagnesium: {
+		rule(models.SexMale, 14, 410, 0),
+		rule(models.SexMale, 19, 400, 0),
+		rule(models.SexMale, 31, 420, 0),
+		rule(models.SexFemale, 14, 360, 0),
+		rule(models.SexFemale, 19, 310, 0),
+		rule(models.SexFemale, 31, 320, 0),
+	},
+}
+
+// ReferenceIntakes returns reference daily intakes of every known nutrient.
+func ReferenceIntakes(sex models.Sex, age int) (map[models.Nutrient]models.ReferenceIntake, error) {
+	if age < MinReferenceAge {
+		return nil, ErrNoReference
+	}
+
+	intakes := make(map[models.Nutrient]models.ReferenceIntake, len(referenceRules))
+
+	for nutrient, rules := range referenceRules {
+		for _, r := range rules {
+			if (r.sex == "" || r.sex == sex) && r.minAge <= age {
+				intakes[nutrient] = r.ReferenceIntake
+			}
+		}
+	}
+
+	return intakes, nil
+}
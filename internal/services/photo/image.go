@@ -0,0 +1,147 @@
+package photo
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+
+	// Decoders of the supported photo types
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// MaxSize is the upload limit in bytes
+	MaxSize = 10 << 20
+	// MaxPixels guards against small files decoding to huge images
+	MaxPixels = 40_000_000
+	// ThumbnailSize is the longest thumbnail side in pixels
+	ThumbnailSize = 320
+
+	thumbnailQuality = 80
+	// samples per thumbnail pixel side, enough to smooth downscaling
+	// without visiting every source pixel
+	thumbnailSamples = 4
+)
+
+// Extensions of the supported content types, as sniffed from the file
+// header, the client provided type is never trusted.
+var Extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+var (
+	ErrPhotoTooLarge   = errors.New("photo is too large")
+	ErrUnsupportedType = errors.New("unsupported photo type")
+	ErrInvalidPhoto    = errors.New("invalid photo")
+)
+
+// Processed is an uploaded photo checked and decoded, Thumbnail is JPEG.
+type Processed struct {
+	ContentType string
+	Width       int
+	Height      int
+	Thumbnail   []byte
+}
+
+// Process sniffs the content type of data, validates the image
+// and renders its thumbnail.
+func Process(data []byte) (Processed, error) {
+	if len(data) > MaxSize {
+		return Processed{}, ErrPhotoTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := Extensions[contentType]; !ok {
+		return Processed{}, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width == 0 || cfg.Height == 0 {
+		return Processed{}, ErrInvalidPhoto
+	}
+
+	if cfg.Width*cfg.Height > MaxPixels {
+		return Processed{}, ErrPhotoTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Processed{}, ErrInvalidPhoto
+	}
+
+	var thumbnail bytes.Buffer
+
+	err = jpeg.Encode(
+		&thumbnail,
+		Thumbnail(img, ThumbnailSize),
+		&jpeg.Options{Quality: thumbnailQuality},
+	)
+	if err != nil {
+		return Processed{}, err
+	}
+
+	return Processed{
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Thumbnail:   thumbnail.Bytes(),
+	}, nil
+}
+
+// Thumbnail scales img to fit size x size keeping the aspect ratio,
+// smaller images keep their size. Every thumbnail pixel averages
+// a grid of source samples, transparency is flattened over white
+// as thumbnails are JPEG.
+func Thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, max(1, h*size/w)
+		} else {
+			tw, th = max(1, w*size/h), size
+		}
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, tw, th))
+
+	scaleX := float64(w) / float64(tw)
+	scaleY := float64(h) / float64(th)
+
+	for y := 0; y < th; y++ {
+		for x := 0; x < tw; x++ {
+			var r, g, b, a uint32
+
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					px := bounds.Min.X + int((float64(x)+(float64(sx)+0.5)/thumbnailSamples)*scaleX)
+					py := bounds.Min.Y + int((float64(y)+(float64(sy)+0.5)/thumbnailSamples)*scaleY)
+
+					pr, pg, pb, pa := img.At(px, py).RGBA()
+					r, g, b, a = r+pr, g+pg, b+pb, a+pa
+				}
+			}
+
+			// Colors are alpha premultiplied, adding the missing
+			// alpha blends transparent pixels over white
+			n := uint32(thumbnailSamples * thumbnailSamples)
+			r, g, b, a = r/n, g/n, b/n, a/n
+			thumbnail.Set(x, y, color.RGBA64{
+				R: uint16(r + 0xffff - a),
+				G: uint16(g + 0xffff - a),
+				B: uint16(b + 0xffff - a),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return thumbnail
+}
@@ -0,0 +1,285 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Photo struct {
+	log             *slog.Logger
+	recordProvider  RecordProvider
+	photoProvider   PhotoProvider
+	photoSaver      PhotoSaver
+	blobStore       BlobStore
+	accountProvider AccountProvider
+}
+
+type RecordProvider interface {
+	RecordById(ctx context.Context, recordId int64) (models.Record, error)
+}
+
+type PhotoProvider interface {
+	RecordPhotoById(ctx context.Context, accountId int64, photoId int64) (models.RecordPhoto, error)
+	RecordPhotosByRecordId(
+		ctx context.Context,
+		accountId int64,
+		recordId int64,
+	) ([]models.RecordPhoto, error)
+}
+
+type PhotoSaver interface {
+	SaveRecordPhoto(ctx context.Context, photo models.RecordPhoto) (int64, error)
+}
+
+// BlobStore keeps photo files, keys are slash separated paths.
+type BlobStore interface {
+	SaveBlob(ctx context.Context, key string, r io.Reader) error
+	Blob(ctx context.Context, key string) (io.ReadCloser, error)
+	DeleteBlob(ctx context.Context, key string) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const thumbnailContentType = "image/jpeg"
+
+var (
+	ErrRecordNotFound = errors.New("record not found")
+	ErrPhotoNotFound  = errors.New("photo not found")
+)
+
+func New(
+	log *slog.Logger,
+	recordProvider RecordProvider,
+	photoProvider PhotoProvider,
+	photoSaver PhotoSaver,
+	blobStore BlobStore,
+	accountProvider AccountProvider,
+) *Photo {
+	return &Photo{
+		log:             log,
+		recordProvider:  recordProvider,
+		photoProvider:   photoProvider,
+		photoSaver:      photoSaver,
+		blobStore:       blobStore,
+		accountProvider: accountProvider,
+	}
+}
+
+// UploadPhotoForCurrentUser stores the photo read from r and its thumbnail
+// in the blob store and attaches them to the record. Reading stops after
+// MaxSize bytes.
+func (p *Photo) UploadPhotoForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+	r io.Reader,
+) (models.RecordPhoto, error) {
+	const op = "services.photo.UploadPhotoForCurrentUser"
+
+	log := p.log.With(slog.String("op", op))
+
+	acc, err := p.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not upload photo - incorrect token")
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := p.checkRecord(ctx, acc.Id, recordId); err != nil {
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		log.Error("failed to read photo", slog.String("err", err.Error()))
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	processed, err := Process(data)
+	if err != nil {
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	base := fmt.Sprintf("photos/%d/%d/%s", acc.Id, recordId, name)
+
+	photo := models.RecordPhoto{
+		AccountId:    acc.Id,
+		RecordId:     recordId,
+		ContentType:  processed.ContentType,
+		Size:         int64(len(data)),
+		Width:        processed.Width,
+		Height:       processed.Height,
+		BlobKey:      base + Extensions[processed.ContentType],
+		ThumbnailKey: base + "_thumb.jpg",
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	if err := p.blobStore.SaveBlob(ctx, photo.BlobKey, bytes.NewReader(data)); err != nil {
+		log.Error("failed to save photo blob", slog.String("err", err.Error()))
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = p.blobStore.SaveBlob(ctx, photo.ThumbnailKey, bytes.NewReader(processed.Thumbnail))
+	if err != nil {
+		log.Error("failed to save thumbnail blob", slog.String("err", err.Error()))
+		p.deleteBlobs(ctx, log, photo)
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	photo.Id, err = p.photoSaver.SaveRecordPhoto(ctx, photo)
+	if err != nil {
+		log.Error("failed to save photo", slog.String("err", err.Error()))
+		p.deleteBlobs(ctx, log, photo)
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return photo, nil
+}
+
+func (p *Photo) GetPhotosForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+) ([]models.RecordPhoto, error) {
+	const op = "services.photo.GetPhotosForCurrentUser"
+
+	log := p.log.With(slog.String("op", op))
+
+	acc, err := p.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get photos - incorrect token")
+		return []models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := p.checkRecord(ctx, acc.Id, recordId); err != nil {
+		return []models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	photos, err := p.photoProvider.RecordPhotosByRecordId(ctx, acc.Id, recordId)
+	if err != nil {
+		log.Error("failed to get photos", slog.String("err", err.Error()))
+		return []models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(photos) == 0 {
+		photos = []models.RecordPhoto{}
+	}
+
+	return photos, nil
+}
+
+// OpenPhotoForCurrentUser returns the content type and the content of
+// the photo or its thumbnail, the caller closes the content.
+func (p *Photo) OpenPhotoForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+	photoId int64,
+	thumbnail bool,
+) (string, io.ReadCloser, error) {
+	const op = "services.photo.OpenPhotoForCurrentUser"
+
+	log := p.log.With(slog.String("op", op))
+
+	acc, err := p.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not open photo - incorrect token")
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	photo, err := p.photoProvider.RecordPhotoById(ctx, acc.Id, photoId)
+	if err != nil {
+		if errors.Is(err, storage.ErrPhotoNotFound) {
+			return "", nil, fmt.Errorf("%s: %w", op, ErrPhotoNotFound)
+		}
+
+		log.Error("failed to get photo", slog.String("err", err.Error()))
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if photo.RecordId != recordId {
+		return "", nil, fmt.Errorf("%s: %w", op, ErrPhotoNotFound)
+	}
+
+	key, contentType := photo.BlobKey, photo.ContentType
+	if thumbnail {
+		key, contentType = photo.ThumbnailKey, thumbnailContentType
+	}
+
+	content, err := p.blobStore.Blob(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			log.Error("photo blob is missing", slog.Int64("photoId", photo.Id))
+			return "", nil, fmt.Errorf("%s: %w", op, ErrPhotoNotFound)
+		}
+
+		log.Error("failed to open photo blob", slog.String("err", err.Error()))
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return contentType, content, nil
+}
+
+// RemovePhotoBlobs deletes blobs of photos whose rows are already deleted
+// with their records. A failed deletion leaves an orphan file, which is
+// unreachable and only takes space.
+func (p *Photo) RemovePhotoBlobs(ctx context.Context, photos []models.RecordPhoto) {
+	const op = "services.photo.RemovePhotoBlobs"
+
+	log := p.log.With(slog.String("op", op))
+
+	for _, photo := range photos {
+		p.deleteBlobs(ctx, log, photo)
+	}
+}
+
+// checkRecord returns ErrRecordNotFound for records of other accounts too.
+func (p *Photo) checkRecord(ctx context.Context, accountId int64, recordId int64) error {
+	record, err := p.recordProvider.RecordById(ctx, recordId)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return ErrRecordNotFound
+		}
+
+		p.log.Error("failed to get record", slog.String("err", err.Error()))
+		return err
+	}
+
+	if record.AccountId != accountId {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// deleteBlobs is best effort, failures only leave orphan files.
+func (p *Photo) deleteBlobs(ctx context.Context, log *slog.Logger, photo models.RecordPhoto) {
+	for _, key := range []string{photo.BlobKey, photo.ThumbnailKey} {
+		if err := p.blobStore.DeleteBlob(ctx, key); err != nil {
+			log.Error("failed to delete blob", slog.String("key", key), slog.String("err", err.Error()))
+		}
+	}
+}
+
+// randomName keeps blob keys unguessable and unique per upload.
+func randomName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,74 @@
+package photo_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/services/photo"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcess(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		for y := 0; y < 400; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+
+	processed, err := photo.Process(encodePNG(t, img))
+	require.NoError(t, err)
+	require.Equal(t, "image/png", processed.ContentType)
+	require.Equal(t, 800, processed.Width)
+	require.Equal(t, 400, processed.Height)
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(processed.Thumbnail))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, photo.ThumbnailSize, photo.ThumbnailSize/2), thumbnail.Bounds())
+
+	// Sniffed type, not a declared one
+	_, err = photo.Process([]byte("<html><body>not a photo</body></html>"))
+	require.ErrorIs(t, err, photo.ErrUnsupportedType)
+
+	// PNG signature with a broken body
+	broken := encodePNG(t, img)[:64]
+	_, err = photo.Process(broken)
+	require.ErrorIs(t, err, photo.ErrInvalidPhoto)
+
+	_, err = photo.Process(make([]byte, photo.MaxSize+1))
+	require.ErrorIs(t, err, photo.ErrPhotoTooLarge)
+}
+
+func TestThumbnail(t *testing.T) {
+	// Portrait is fitted by height
+	portrait := image.NewRGBA(image.Rect(0, 0, 300, 900))
+	require.Equal(t, image.Rect(0, 0, 100, 300), photo.Thumbnail(portrait, 300).Bounds())
+
+	// Small images keep their size
+	small := image.NewRGBA(image.Rect(10, 10, 50, 30))
+	require.Equal(t, image.Rect(0, 0, 40, 20), photo.Thumbnail(small, 300).Bounds())
+
+	// Halves are averaged, transparency is flattened over white
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 0, G: 0, B: 0, A: 255})
+	img.Set(1, 0, color.NRGBA{A: 0})
+
+	thumbnail := photo.Thumbnail(img, 1)
+	require.Equal(t, image.Rect(0, 0, 1, 1), thumbnail.Bounds())
+
+	r, g, b, a := thumbnail.At(0, 0).RGBA()
+	require.InDelta(t, 0x7fff, r, 0x100)
+	require.InDelta(t, 0x7fff, g, 0x100)
+	require.InDelta(t, 0x7fff, b, 0x100)
+	require.Equal(t, uint32(0xffff), a)
+}
@@ -0,0 +1,187 @@
+package recipe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Recipe struct {
+	log             *slog.Logger
+	recipeProvider  RecipeProvider
+	recipeSaver     RecipeSaver
+	foodProvider    FoodProvider
+	accountProvider AccountProvider
+}
+
+type RecipeProvider interface {
+	RecipesByAccountId(ctx context.Context, accountId int64) ([]models.Recipe, error)
+}
+
+type RecipeSaver interface {
+	SaveRecipe(ctx context.Context, recipe models.Recipe) (int64, error)
+}
+
+type FoodProvider interface {
+	FoodById(ctx context.Context, accountId int64, foodId int64) (models.Food, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+var (
+	ErrRecipeExists = errors.New("recipe exists")
+	ErrFoodNotFound = errors.New("food not found")
+)
+
+func New(
+	log *slog.Logger,
+	recipeProvider RecipeProvider,
+	recipeSaver RecipeSaver,
+	foodProvider FoodProvider,
+	accountProvider AccountProvider,
+) *Recipe {
+	return &Recipe{
+		log:             log,
+		recipeProvider:  recipeProvider,
+		recipeSaver:     recipeSaver,
+		foodProvider:    foodProvider,
+		accountProvider: accountProvider,
+	}
+}
+
+func (r *Recipe) GetRecipesForCurrentUser(ctx context.Context) ([]models.Recipe, error) {
+	const op = "services.recipe.GetRecipesForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get recipes - incorrect token")
+		return []models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recipes, err := r.recipeProvider.RecipesByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("can not get recipes", slog.String("err", err.Error()))
+		return []models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(recipes) == 0 {
+		recipes = []models.Recipe{}
+	}
+
+	for i := range recipes {
+		recipes[i].Value = acc.EnergyUnit.FromKcal(recipes[i].Value)
+	}
+
+	return recipes, nil
+}
+
+// CreateRecipeForCurrentUser saves a recipe of catalog foods, allergens of
+// the recipe are extended with allergens of its ingredients.
+func (r *Recipe) CreateRecipeForCurrentUser(
+	ctx context.Context,
+	recipe models.Recipe,
+) (models.Recipe, error) {
+	const op = "services.recipe.CreateRecipeForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not create recipe - incorrect token")
+		return models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := restriction.ValidateAllergens(recipe.Allergens); err != nil {
+		return models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foods := make(map[int64]models.Food, len(recipe.Ingredients))
+
+	for _, ingredient := range recipe.Ingredients {
+		food, err := r.foodProvider.FoodById(ctx, acc.Id, ingredient.FoodId)
+		if err != nil {
+			if errors.Is(err, storage.ErrFoodNotFound) {
+				return models.Recipe{}, fmt.Errorf("%s: %w", op, ErrFoodNotFound)
+			}
+
+			log.Error("failed to get food", slog.String("err", err.Error()))
+			return models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		foods[food.Id] = food
+	}
+
+	recipe = Compose(recipe, foods)
+	recipe.AccountId = acc.Id
+
+	recipe.Id, err = r.recipeSaver.SaveRecipe(ctx, recipe)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecipeExists) {
+			log.Info("recipe exists", slog.String("name", recipe.Name))
+			return models.Recipe{}, fmt.Errorf("%s: %w", op, ErrRecipeExists)
+		}
+
+		log.Error("failed to save recipe", slog.String("err", err.Error()))
+		return models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recipe.Value = acc.EnergyUnit.FromKcal(recipe.Value)
+
+	return recipe, nil
+}
+
+// Compose computes per serving value and nutrients and merges allergens
+// of ingredient foods.
+func Compose(recipe models.Recipe, foods map[int64]models.Food) models.Recipe {
+	var value float64
+
+	nutrients := models.Nutrients{}
+	allergens := make(map[models.Allergen]struct{})
+
+	for _, allergen := range recipe.Allergens {
+		allergens[allergen] = struct{}{}
+	}
+
+	for i, ingredient := range recipe.Ingredients {
+		food := foods[ingredient.FoodId]
+		factor := ingredient.Grams / 100
+
+		recipe.Ingredients[i].Name = food.Name
+		value += float64(food.Value) * factor
+
+		for nutrient, amount := range food.Nutrients {
+			nutrients[nutrient] += amount * factor
+		}
+
+		for _, allergen := range food.Allergens {
+			allergens[allergen] = struct{}{}
+		}
+	}
+
+	servings := float64(recipe.Servings)
+
+	recipe.Value = int(math.Round(value / servings))
+	recipe.Nutrients = nutrients.Scale(1 / servings)
+
+	recipe.Allergens = make([]models.Allergen, 0, len(allergens))
+	for allergen := range allergens {
+		recipe.Allergens = append(recipe.Allergens, allergen)
+	}
+
+	sort.Slice(recipe.Allergens, func(i, j int) bool {
+		return recipe.Allergens[i] < recipe.Allergens[j]
+	})
+
+	return recipe
+}
@@ -0,0 +1,51 @@
+package recipe_test
+
+import (
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/recipe"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompose(t *testing.T) {
+	foods := map[int64]models.Food{
+		1: {
+			Id:        1,
+			Name:      "Flour",
+			Value:     360,
+			Nutrients: models.Nutrients{models.NutrientFiber: 3},
+			Allergens: []models.Allergen{models.AllergenGluten},
+		},
+		2: {
+			Id:        2,
+			Name:      "Milk",
+			Value:     60,
+			Nutrients: models.Nutrients{models.NutrientCalcium: 120},
+			Allergens: []models.Allergen{models.AllergenMilk},
+		},
+	}
+
+	result := recipe.Compose(models.Recipe{
+		Name:     "Pancakes",
+		Servings: 4,
+		Ingredients: []models.RecipeIngredient{
+			{FoodId: 1, Grams: 200},
+			{FoodId: 2, Grams: 400},
+		},
+		Allergens: []models.Allergen{models.AllergenEggs},
+	}, foods)
+
+	require.Equal(t, (720+240)/4, result.Value)
+	require.Equal(t, models.Nutrients{
+		models.NutrientFiber:   1.5,
+		models.NutrientCalcium: 120,
+	}, result.Nutrients)
+	require.Equal(t, []models.Allergen{
+		models.AllergenEggs,
+		models.AllergenGluten,
+		models.AllergenMilk,
+	}, result.Allergens)
+	require.Equal(t, "Flour", result.Ingredients[0].Name)
+	require.Equal(t, "Milk", result.Ingredients[1].Name)
+}
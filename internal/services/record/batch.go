@@ -0,0 +1,224 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+// MaxBatchOperations limits operations of a single batch.
+const MaxBatchOperations = 100
+
+var (
+	ErrInvalidBatch     = errors.New("batch should have 1 to 100 operations")
+	ErrInvalidOperation = errors.New("invalid batch operation")
+	ErrDuplicateRecord  = errors.New("record changed more than once in batch")
+)
+
+// OperationError reports the batch operation that failed, no operation
+// of the batch is applied.
+type OperationError struct {
+	Index    int
+	Err      error
+	Warnings []models.RestrictionWarning
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d: %s", e.Index, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyBatchForCurrentUser applies create, update and delete operations
+// atomically and returns results in operation order. Created records are
+// prepared as by CreateRecordForCurrentUser, updates replace the fields
+// UpdateRecordForCurrentUser does. A record can be changed once per batch,
+// zero version skips the check.
+func (r *Record) ApplyBatchForCurrentUser(
+	ctx context.Context,
+	operations []models.RecordOperation,
+) ([]models.RecordOperationResult, error) {
+	const op = "services.record.ApplyBatchForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not apply batch - incorrect token")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(operations) == 0 || len(operations) > MaxBatchOperations {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidBatch)
+	}
+
+	prepared := make([]models.RecordOperation, len(operations))
+	results := make([]models.RecordOperationResult, len(operations))
+	olds := make([]models.Record, len(operations))
+	changed := make(map[int64]bool, len(operations))
+
+	for i, operation := range operations {
+		prepared[i] = operation
+		results[i] = models.RecordOperationResult{
+			Type:     operation.Type,
+			RecordId: operation.RecordId,
+		}
+
+		if operation.Type == models.RecordOperationCreate {
+			record, warnings, err := r.prepareRecord(ctx, log, acc, operation.Record)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, &OperationError{
+					Index:    i,
+					Err:      err,
+					Warnings: warnings,
+				})
+			}
+
+			prepared[i].Record = record
+			results[i].Warnings = warnings
+			continue
+		}
+
+		if operation.Type != models.RecordOperationUpdate &&
+			operation.Type != models.RecordOperationDelete {
+			return nil, fmt.Errorf("%s: %w", op, &OperationError{Index: i, Err: ErrInvalidOperation})
+		}
+
+		if changed[operation.RecordId] {
+			return nil, fmt.Errorf("%s: %w", op, &OperationError{Index: i, Err: ErrDuplicateRecord})
+		}
+		changed[operation.RecordId] = true
+
+		olds[i], err = r.accountRecord(ctx, acc, operation.RecordId)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, &OperationError{Index: i, Err: err})
+		}
+
+		if operation.Version == 0 {
+			prepared[i].Version = olds[i].Version
+		}
+
+		if operation.Type == models.RecordOperationUpdate {
+			record := olds[i]
+			record.Value = acc.EnergyUnit.ToKcal(operation.Record.Value)
+			record.Meal = operation.Record.Meal
+			record.Description = operation.Record.Description
+			record.DateRecord = operation.Record.DateRecord
+
+			if record.Meal == "" {
+				record.Meal = models.MealByHour(record.DateRecord.Hour())
+			}
+
+			record.FieldTimes = fieldTimesAt(time.Now())
+
+			prepared[i].Record = record
+		}
+	}
+
+	withRevisions(acc, prepared, olds)
+
+	ids, err := r.recordSaver.ApplyRecordBatch(ctx, acc.Id, prepared)
+	if err != nil {
+		var batchErr *storage.BatchError
+		if errors.As(err, &batchErr) {
+			return nil, fmt.Errorf("%s: %w", op, &OperationError{
+				Index: batchErr.Index,
+				Err:   operationError(batchErr.Err),
+			})
+		}
+
+		log.Error("failed to apply batch", slog.String("err", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, record := range r.batchApplied(ctx, log, acc, prepared, olds, ids) {
+		results[i].RecordId = ids[i]
+		results[i].Record = record
+	}
+
+	return results, nil
+}
+
+// withRevisions sets revisions of operations, olds are records before
+// updates and deletes.
+func withRevisions(acc models.Account, operations []models.RecordOperation, olds []models.Record) {
+	for i, operation := range operations {
+		switch operation.Type {
+		case models.RecordOperationCreate:
+			operations[i].Revision = newRevision(acc, 0, models.RecordCreated, nil, &operation.Record)
+		case models.RecordOperationUpdate:
+			operations[i].Revision = newRevision(acc, operation.RecordId, models.RecordUpdated, &olds[i], &operation.Record)
+		case models.RecordOperationDelete:
+			operations[i].Revision = newRevision(acc, operation.RecordId, models.RecordDeleted, &olds[i], nil)
+		}
+	}
+}
+
+// batchApplied refreshes streaks after applied operations and returns
+// changed records in the account energy unit, nil for deleted.
+func (r *Record) batchApplied(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	operations []models.RecordOperation,
+	olds []models.Record,
+	ids []int64,
+) []*models.Record {
+	records := make([]*models.Record, len(operations))
+	created := false
+	var dates []time.Time
+
+	for i, operation := range operations {
+		switch operation.Type {
+		case models.RecordOperationCreate:
+			created = true
+
+			record := operation.Record
+			record.Id = ids[i]
+			record.Version = 1
+
+			dates = append(dates, record.DateRecord)
+
+			record.Value = acc.EnergyUnit.FromKcal(record.Value)
+			records[i] = &record
+		case models.RecordOperationUpdate:
+			record := operation.Record
+			record.Version = operation.Version + 1
+
+			dates = append(dates, olds[i].DateRecord, record.DateRecord)
+
+			record.Value = acc.EnergyUnit.FromKcal(record.Value)
+			records[i] = &record
+		case models.RecordOperationDelete:
+			dates = append(dates, olds[i].DateRecord)
+		}
+	}
+
+	streaks := r.refreshStreaks(ctx, log, acc, dates...)
+	if created {
+		r.handleAchievements(ctx, log, acc, streaks)
+	}
+
+	return records
+}
+
+// operationError maps storage errors of a batch operation to service ones.
+func operationError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrRecordNotFound):
+		return ErrRecordNotFound
+	case errors.Is(err, storage.ErrVersionMismatch):
+		return ErrVersionMismatch
+	case errors.Is(err, storage.ErrTagNotFound):
+		return ErrTagNotFound
+	}
+
+	return err
+}
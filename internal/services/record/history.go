@@ -0,0 +1,105 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+// GetRecordHistoryForCurrentUser returns revisions of the record, oldest
+// first. History is append-only, it outlives trashed and purged records.
+func (r *Record) GetRecordHistoryForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+) ([]models.RecordRevision, error) {
+	const op = "services.record.GetRecordHistoryForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get record history - incorrect token")
+		return []models.RecordRevision{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revisions, err := r.revisionProvider.RecordRevisionsByRecordId(ctx, acc.Id, recordId)
+	if err != nil {
+		log.Error("failed to get record revisions", slog.String("err", err.Error()))
+		return []models.RecordRevision{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(revisions) == 0 {
+		// Records logged before history was kept have no revisions
+		if _, err := r.accountRecord(ctx, acc, recordId); err != nil {
+			return []models.RecordRevision{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return []models.RecordRevision{}, nil
+	}
+
+	for i := range revisions {
+		for _, values := range []*models.RecordValues{revisions[i].Old, revisions[i].New} {
+			if values != nil {
+				values.Value = acc.EnergyUnit.FromKcal(values.Value)
+			}
+		}
+	}
+
+	return revisions, nil
+}
+
+// accountRecord returns ErrRecordNotFound for records of other accounts too.
+func (r *Record) accountRecord(
+	ctx context.Context,
+	acc models.Account,
+	recordId int64,
+) (models.Record, error) {
+	record, err := r.recordProvider.RecordById(ctx, recordId)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return models.Record{}, ErrRecordNotFound
+		}
+
+		r.log.Error("failed to get record", slog.String("err", err.Error()))
+		return models.Record{}, err
+	}
+
+	if record.AccountId != acc.Id {
+		return models.Record{}, ErrRecordNotFound
+	}
+
+	return record, nil
+}
+
+// newRevision returns the revision of a change the account made to its
+// record, recordId is filled by storage for created records.
+func newRevision(
+	acc models.Account,
+	recordId int64,
+	action models.RecordAction,
+	before *models.Record,
+	after *models.Record,
+) models.RecordRevision {
+	revision := models.RecordRevision{
+		RecordId:  recordId,
+		AccountId: acc.Id,
+		ActorId:   acc.Id,
+		Action:    action,
+	}
+
+	if before != nil {
+		values := before.Values()
+		revision.Old = &values
+	}
+
+	if after != nil {
+		values := after.Values()
+		revision.New = &values
+	}
+
+	return revision
+}
@@ -0,0 +1,197 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/importer"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/uuid"
+)
+
+// MaxImportRows limits rows of a single import.
+const MaxImportRows = 20000
+
+var ErrTooManyRows = errors.New("import should have at most 20000 rows")
+
+// ImportRecordsForCurrentUser parses the file exported by another tracker
+// and creates records of its valid rows in one transaction, in dry-run mode
+// rows are only returned. Rows matching existing records by ImportKey are
+// skipped, so importing a file again adds nothing. Dates without a time are
+// in loc.
+func (r *Record) ImportRecordsForCurrentUser(
+	ctx context.Context,
+	parser importer.Parser,
+	file io.Reader,
+	loc *time.Location,
+	dryRun bool,
+) (models.ImportResult, error) {
+	const op = "services.record.ImportRecordsForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not import records - incorrect token")
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := parser.Parse(file, loc)
+	if err != nil {
+		log.Info("invalid import file", slog.String("err", err.Error()))
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(rows) > MaxImportRows {
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, ErrTooManyRows)
+	}
+
+	existing := make(map[string]int)
+
+	if from, to := importRange(rows, loc); !from.IsZero() {
+		err = r.recordProvider.EachRecordByAccountIdInRange(ctx, acc.Id, from, to, func(record models.Record) error {
+			existing[ImportKey(record, loc)]++
+			return nil
+		})
+		if err != nil {
+			log.Error("failed to get records", slog.String("err", err.Error()))
+			return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	MarkDuplicates(rows, existing, loc)
+
+	result := models.ImportResult{DryRun: dryRun, Rows: rows}
+
+	if result.Rows == nil {
+		result.Rows = []models.ImportRow{}
+	}
+
+	var (
+		operations []models.RecordOperation
+		indexes    []int
+	)
+
+	for i, row := range rows {
+		switch row.Status {
+		case models.ImportRowNew:
+			record := *row.Record
+			record.AccountId = acc.Id
+			record.Uuid = uuid.New()
+
+			operations = append(operations, models.RecordOperation{
+				Type:   models.RecordOperationCreate,
+				Record: record,
+			})
+			indexes = append(indexes, i)
+		case models.ImportRowDuplicate:
+			result.Duplicates++
+		case models.ImportRowInvalid:
+			result.Invalid++
+		}
+	}
+
+	result.Imported = len(operations)
+
+	for _, row := range rows {
+		if row.Record != nil {
+			row.Record.Value = acc.EnergyUnit.FromKcal(row.Record.Value)
+		}
+	}
+
+	if dryRun || len(operations) == 0 {
+		return result, nil
+	}
+
+	olds := make([]models.Record, len(operations))
+	withRevisions(acc, operations, olds)
+
+	ids, err := r.recordSaver.ApplyRecordBatch(ctx, acc.Id, operations)
+	if err != nil {
+		log.Error("failed to import records", slog.String("err", err.Error()))
+		return models.ImportResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records := r.batchApplied(ctx, log, acc, operations, olds, ids)
+	for j, i := range indexes {
+		rows[i].Record = records[j]
+	}
+
+	log.Info(
+		"records imported",
+		slog.Int64("accountId", acc.Id),
+		slog.Int("imported", result.Imported),
+		slog.Int("duplicates", result.Duplicates),
+		slog.Int("invalid", result.Invalid),
+	)
+
+	return result, nil
+}
+
+// ImportKey identifies records of the same food logged on a day, records
+// of other trackers carry nothing more to tell them apart.
+func ImportKey(record models.Record, loc *time.Location) string {
+	return strings.Join([]string{
+		record.DateRecord.In(loc).Format(time.DateOnly),
+		string(record.Meal),
+		strconv.Itoa(record.Value),
+		strings.ToLower(strings.TrimSpace(record.Description)),
+	}, "|")
+}
+
+// MarkDuplicates marks new rows matching existing records, which are counted
+// by ImportKey. An existing record matches a single row, so foods logged
+// twice a day are imported twice unless both are already there.
+func MarkDuplicates(rows []models.ImportRow, existing map[string]int, loc *time.Location) {
+	for i, row := range rows {
+		if row.Status != models.ImportRowNew {
+			continue
+		}
+
+		key := ImportKey(*row.Record, loc)
+		if existing[key] == 0 {
+			continue
+		}
+
+		existing[key]--
+		rows[i].Status = models.ImportRowDuplicate
+	}
+}
+
+// importRange returns days of new rows as [from, to) in loc, zero times
+// when there are none.
+func importRange(rows []models.ImportRow, loc *time.Location) (time.Time, time.Time) {
+	var first, last time.Time
+
+	for _, row := range rows {
+		if row.Status != models.ImportRowNew {
+			continue
+		}
+
+		date := row.Record.DateRecord
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if last.IsZero() || date.After(last) {
+			last = date
+		}
+	}
+
+	if first.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+
+	first = first.In(loc)
+	last = last.In(loc)
+
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	return from, to
+}
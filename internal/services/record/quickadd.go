@@ -0,0 +1,225 @@
+package record
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/quickadd"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+)
+
+// QuickAddForCurrentUser parses text against the account catalog and creates
+// the records, in preview mode records are only returned. Items missing from
+// the catalog without an energy value fail creation with ErrUnmatched, nothing
+// is saved when any record conflicts with restrictions in reject mode.
+// Records are created atomically.
+func (r *Record) QuickAddForCurrentUser(
+	ctx context.Context,
+	text string,
+	loc *time.Location,
+	preview bool,
+) (models.QuickAddResult, error) {
+	const op = "services.record.QuickAddForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not quick-add records - incorrect token")
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foods, err := r.catalogProvider.FoodsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get foods", slog.String("err", err.Error()))
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	recipes, err := r.catalogProvider.RecipesByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get recipes", slog.String("err", err.Error()))
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	parsed, err := quickadd.Parse(text, time.Now().In(loc), foods, recipes)
+	if err != nil {
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records, unmatched := QuickAddRecords(parsed)
+	result := models.QuickAddResult{Records: []models.Record{}, Unmatched: unmatched}
+
+	restrictions, err := r.restrictions.RestrictionsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get restrictions", slog.String("err", err.Error()))
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foodsById := make(map[int64]models.Food, len(foods))
+	for _, f := range foods {
+		foodsById[f.Id] = f
+	}
+
+	recipesById := make(map[int64]models.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		recipesById[recipe.Id] = recipe
+	}
+
+	var operations []models.RecordOperation
+
+	for _, record := range records {
+		if record.FoodId != nil {
+			f := foodsById[*record.FoodId]
+			record = FromFood(record, f)
+		}
+
+		if record.RecipeId != nil {
+			recipe := recipesById[*record.RecipeId]
+			record = FromRecipe(record, recipe)
+		}
+
+		// A total value makes one record of all items, which keeps
+		// allergens of every matched one
+		items := parsed.Items
+		if parsed.Value == nil {
+			items = []models.QuickAddItem{{FoodId: record.FoodId, RecipeId: record.RecipeId}}
+		}
+		allergens := QuickAddAllergens(items, foodsById, recipesById)
+
+		result.Warnings = append(result.Warnings, restriction.Conflicts(restrictions, allergens)...)
+
+		if record.Meal == "" {
+			record.Meal = models.MealByHour(record.DateRecord.Hour())
+		}
+
+		record.AccountId = acc.Id
+		operations = append(operations, models.RecordOperation{
+			Type:   models.RecordOperationCreate,
+			Record: record,
+		})
+
+		record.Value = acc.EnergyUnit.FromKcal(record.Value)
+		result.Records = append(result.Records, record)
+	}
+
+	if preview {
+		return result, nil
+	}
+
+	if len(unmatched) > 0 {
+		return result, fmt.Errorf("%s: %w", op, ErrUnmatched)
+	}
+
+	if len(result.Warnings) > 0 && restrictions.Mode == models.RestrictionModeReject {
+		log.Info("quick-add rejected by restrictions", slog.Int64("accountId", acc.Id))
+		return result, fmt.Errorf("%s: %w", op, ErrRestricted)
+	}
+
+	// Records are created in one transaction, a failed one saves none
+	olds := make([]models.Record, len(operations))
+	withRevisions(acc, operations, olds)
+
+	ids, err := r.recordSaver.ApplyRecordBatch(ctx, acc.Id, operations)
+	if err != nil {
+		log.Error("failed to save records", slog.String("err", err.Error()))
+		return models.QuickAddResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, record := range r.batchApplied(ctx, log, acc, operations, olds, ids) {
+		result.Records[i] = *record
+	}
+
+	return result, nil
+}
+
+// QuickAddAllergens returns allergens of the catalog foods and recipes
+// matched by items, each allergen once.
+func QuickAddAllergens(
+	items []models.QuickAddItem,
+	foodsById map[int64]models.Food,
+	recipesById map[int64]models.Recipe,
+) []models.Allergen {
+	var allergens []models.Allergen
+	seen := make(map[models.Allergen]bool)
+
+	add := func(found []models.Allergen) {
+		for _, allergen := range found {
+			if !seen[allergen] {
+				seen[allergen] = true
+				allergens = append(allergens, allergen)
+			}
+		}
+	}
+
+	for _, item := range items {
+		if item.FoodId != nil {
+			add(foodsById[*item.FoodId].Allergens)
+		}
+		if item.RecipeId != nil {
+			add(recipesById[*item.RecipeId].Allergens)
+		}
+	}
+
+	return allergens
+}
+
+// QuickAddRecords turns parsed quick-add text into records with values
+// in kcal. A total value makes a single record for all items, otherwise
+// every item is a record and unmatched items without a value are returned
+// by text instead.
+func QuickAddRecords(parsed models.QuickAdd) ([]models.Record, []string) {
+	base := models.Record{Meal: parsed.Meal, DateRecord: parsed.DateRecord}
+
+	if parsed.Value != nil {
+		record := base
+		record.Value = *parsed.Value
+
+		// A single matched item keeps the catalog reference and name
+		if len(parsed.Items) == 1 && parsed.Items[0].Matched() {
+			return []models.Record{withItem(record, parsed.Items[0])}, nil
+		}
+
+		var texts []string
+		for _, item := range parsed.Items {
+			texts = append(texts, item.Text)
+		}
+		record.Description = strings.Join(texts, ", ")
+
+		return []models.Record{record}, nil
+	}
+
+	var records []models.Record
+	var unmatched []string
+
+	for _, item := range parsed.Items {
+		if !item.Matched() && item.Value == nil {
+			unmatched = append(unmatched, item.Text)
+			continue
+		}
+
+		record := withItem(base, item)
+		if !item.Matched() {
+			record.Description = item.Text
+		}
+		if item.Value != nil {
+			record.Value = *item.Value
+		}
+
+		records = append(records, record)
+	}
+
+	return records, unmatched
+}
+
+func withItem(record models.Record, item models.QuickAddItem) models.Record {
+	record.FoodId = item.FoodId
+	record.Grams = item.Grams
+	record.RecipeId = item.RecipeId
+	record.Servings = item.Servings
+
+	return record
+}
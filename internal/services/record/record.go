@@ -5,17 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/food"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
 )
 
 type Record struct {
-	log             *slog.Logger
-	recordProvider  RecordProvider
-	recordSaver     RecordSaver
-	recordRemover   RecordRemover
-	accountProvider AccountProvider
+	log                *slog.Logger
+	recordProvider     RecordProvider
+	recordSaver        RecordSaver
+	recordRemover      RecordRemover
+	catalogProvider    CatalogProvider
+	restrictions       RestrictionsProvider
+	accountProvider    AccountProvider
+	streakRefresher    StreakRefresher
+	achievementHandler AchievementHandler
+	photoRemover       PhotoRemover
+	revisionProvider   RevisionProvider
 }
 
 type RecordProvider interface {
@@ -24,28 +35,113 @@ type RecordProvider interface {
 		ctx context.Context,
 		userId int64,
 		date time.Time,
+		tags []string,
 	) (records []models.Record, err error)
+	RecordsCountByAccountId(ctx context.Context, accountId int64) (int, error)
+	DeletedRecordsByAccountId(ctx context.Context, accountId int64) ([]models.Record, error)
+	DeletedRecordById(ctx context.Context, accountId int64, recordId int64) (models.Record, error)
+	RecordByUuid(ctx context.Context, accountId int64, recordUuid string) (models.Record, error)
+	RecordSyncState(ctx context.Context, accountId int64) (models.SyncState, error)
+	RecordChanges(
+		ctx context.Context,
+		accountId int64,
+		after int64,
+		upTo int64,
+		limit int,
+	) ([]models.Record, error)
+	EachRecordByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+		fn func(models.Record) error,
+	) error
+	SearchRecords(
+		ctx context.Context,
+		accountId int64,
+		match string,
+		from time.Time,
+		to time.Time,
+		limit int,
+	) ([]models.RecordSearchResult, error)
 }
 
 type RecordSaver interface {
-	SaveRecord(
+	SaveRecord(ctx context.Context, record models.Record, revision models.RecordRevision) (int64, error)
+	UpdateRecord(ctx context.Context, record models.Record, revision models.RecordRevision) error
+	ApplyRecordBatch(
 		ctx context.Context,
 		accountId int64,
-		value int,
-		dateRecord time.Time,
-	) (int64, error)
+		operations []models.RecordOperation,
+	) ([]int64, error)
+	SetRecordTags(
+		ctx context.Context,
+		accountId int64,
+		recordId int64,
+		version int64,
+		names []string,
+		revision models.RecordRevision,
+	) error
 }
 
 type RecordRemover interface {
-	DeleteRecord(ctx context.Context, accountId int64, recordId int64) error
+	DeleteRecord(
+		ctx context.Context,
+		accountId int64,
+		recordId int64,
+		version int64,
+		revision models.RecordRevision,
+	) error
+	RestoreRecord(ctx context.Context, accountId int64, recordId int64, revision models.RecordRevision) error
+	PurgeDeletedRecords(ctx context.Context, before time.Time) ([]models.Record, []models.RecordPhoto, error)
+}
+
+type CatalogProvider interface {
+	FoodById(ctx context.Context, accountId int64, foodId int64) (models.Food, error)
+	RecipeById(ctx context.Context, accountId int64, recipeId int64) (models.Recipe, error)
+	FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error)
+	RecipesByAccountId(ctx context.Context, accountId int64) ([]models.Recipe, error)
+}
+
+type RestrictionsProvider interface {
+	RestrictionsByAccountId(ctx context.Context, accountId int64) (models.Restrictions, error)
 }
 
 type AccountProvider interface {
 	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
 }
 
+type StreakRefresher interface {
+	RefreshStreaks(ctx context.Context, acc models.Account, dates ...time.Time) (models.Streaks, error)
+}
+
+type AchievementHandler interface {
+	HandleEvents(ctx context.Context, accountId int64, events ...models.AchievementEvent) error
+}
+
+type PhotoRemover interface {
+	RemovePhotoBlobs(ctx context.Context, photos []models.RecordPhoto)
+}
+
+type RevisionProvider interface {
+	RecordRevisionsByRecordId(
+		ctx context.Context,
+		accountId int64,
+		recordId int64,
+	) ([]models.RecordRevision, error)
+}
+
 var (
 	ErrRecordNotFound = errors.New("record not found")
+	ErrFoodNotFound   = errors.New("food not found")
+	ErrRecipeNotFound = errors.New("recipe not found")
+	ErrAmbiguousFood  = errors.New("record can reference either food or recipe")
+	ErrRestricted     = errors.New("record conflicts with account restrictions")
+	ErrUnmatched      = errors.New("quick-add items not found in catalog")
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrInvalidQuery   = errors.New("search query has no terms")
+
+	ErrVersionMismatch = errors.New("version mismatch")
 )
 
 func New(
@@ -53,20 +149,35 @@ func New(
 	recordProvider RecordProvider,
 	recordSaver RecordSaver,
 	recordRemover RecordRemover,
+	catalogProvider CatalogProvider,
+	restrictions RestrictionsProvider,
 	accountProvider AccountProvider,
+	streakRefresher StreakRefresher,
+	achievementHandler AchievementHandler,
+	photoRemover PhotoRemover,
+	revisionProvider RevisionProvider,
 ) *Record {
 	return &Record{
-		log:             log,
-		recordProvider:  recordProvider,
-		recordSaver:     recordSaver,
-		recordRemover:   recordRemover,
-		accountProvider: accountProvider,
+		log:                log,
+		recordProvider:     recordProvider,
+		recordSaver:        recordSaver,
+		recordRemover:      recordRemover,
+		catalogProvider:    catalogProvider,
+		restrictions:       restrictions,
+		accountProvider:    accountProvider,
+		streakRefresher:    streakRefresher,
+		achievementHandler: achievementHandler,
+		photoRemover:       photoRemover,
+		revisionProvider:   revisionProvider,
 	}
 }
 
+// GetRecordsForCurrentUser returns records of the day, records having
+// any of tags when tags are given.
 func (r *Record) GetRecordsForCurrentUser(
 	ctx context.Context,
 	date time.Time,
+	tags []string,
 ) ([]models.Record, error) {
 	const op = "services.record.GetRecordsForCurrentUser"
 
@@ -78,7 +189,12 @@ func (r *Record) GetRecordsForCurrentUser(
 		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	records, err := r.recordProvider.RecordsByUserId(ctx, acc.UserId, date)
+	tags, err = tag.NormalizeNames(tags)
+	if err != nil {
+		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records, err := r.recordProvider.RecordsByUserId(ctx, acc.UserId, date, tags)
 	if err != nil {
 		log.Error("can not get records")
 		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
@@ -88,14 +204,20 @@ func (r *Record) GetRecordsForCurrentUser(
 		records = []models.Record{}
 	}
 
+	for i := range records {
+		records[i].Value = acc.EnergyUnit.FromKcal(records[i].Value)
+	}
+
 	return records, nil
 }
 
+// CreateRecordForCurrentUser saves a record and returns it with warnings
+// about allergens conflicting with account restrictions. In reject mode
+// conflicting records are not saved, warnings are returned with ErrRestricted.
 func (r *Record) CreateRecordForCurrentUser(
 	ctx context.Context,
-	dateRecord time.Time,
-	value int,
-) error {
+	record models.Record,
+) (models.Record, []models.RestrictionWarning, error) {
 	const op = "services.record.CreateRecordForCurrentUser"
 
 	log := r.log.With(slog.String("op", op))
@@ -103,20 +225,255 @@ func (r *Record) CreateRecordForCurrentUser(
 	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
 	if err != nil {
 		log.Error("can not create record - incorrect token")
-		return fmt.Errorf("%s: %w", op, err)
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	_, err = r.recordSaver.SaveRecord(ctx, acc.Id, value, dateRecord)
+	record, warnings, err := r.prepareRecord(ctx, log, acc, record)
 	if err != nil {
+		return models.Record{}, warnings, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record.Id, err = r.recordSaver.SaveRecord(ctx, record, newRevision(acc, 0, models.RecordCreated, nil, &record))
+	if err != nil {
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return models.Record{}, nil, fmt.Errorf("%s: %w", op, ErrTagNotFound)
+		}
+
 		log.Error("failed to save record", slog.String("err", err.Error()))
-		return fmt.Errorf("%s: %w", op, err)
+		return models.Record{}, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	record.Version = 1
+
+	streaks := r.refreshStreaks(ctx, log, acc, record.DateRecord)
+	r.handleAchievements(ctx, log, acc, streaks)
+
+	record.Value = acc.EnergyUnit.FromKcal(record.Value)
+
+	return record, warnings, nil
 
 }
 
-func (r *Record) DeleteRecordForCurrentUser(ctx context.Context, recordId int64) error {
+// prepareRecord converts the record value to kcal and fills the record from
+// the catalog food or recipe. ErrRestricted comes with conflicting warnings.
+func (r *Record) prepareRecord(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	record models.Record,
+) (models.Record, []models.RestrictionWarning, error) {
+	var err error
+
+	record.AccountId = acc.Id
+	record.Value = acc.EnergyUnit.ToKcal(record.Value)
+
+	if err := food.ValidateNutrients(record.Nutrients); err != nil {
+		return models.Record{}, nil, err
+	}
+
+	record.Tags, err = tag.NormalizeNames(record.Tags)
+	if err != nil {
+		return models.Record{}, nil, err
+	}
+
+	if record.FoodId != nil && record.RecipeId != nil {
+		return models.Record{}, nil, ErrAmbiguousFood
+	}
+
+	var allergens []models.Allergen
+
+	if record.FoodId != nil {
+		f, err := r.catalogProvider.FoodById(ctx, acc.Id, *record.FoodId)
+		if err != nil {
+			if errors.Is(err, storage.ErrFoodNotFound) {
+				return models.Record{}, nil, ErrFoodNotFound
+			}
+
+			log.Error("failed to get food", slog.String("err", err.Error()))
+			return models.Record{}, nil, err
+		}
+
+		record = FromFood(record, f)
+		allergens = f.Allergens
+	}
+
+	if record.RecipeId != nil {
+		recipe, err := r.catalogProvider.RecipeById(ctx, acc.Id, *record.RecipeId)
+		if err != nil {
+			if errors.Is(err, storage.ErrRecipeNotFound) {
+				return models.Record{}, nil, ErrRecipeNotFound
+			}
+
+			log.Error("failed to get recipe", slog.String("err", err.Error()))
+			return models.Record{}, nil, err
+		}
+
+		record = FromRecipe(record, recipe)
+		allergens = recipe.Allergens
+	}
+
+	var warnings []models.RestrictionWarning
+
+	if len(allergens) > 0 {
+		restrictions, err := r.restrictions.RestrictionsByAccountId(ctx, acc.Id)
+		if err != nil {
+			log.Error("failed to get restrictions", slog.String("err", err.Error()))
+			return models.Record{}, nil, err
+		}
+
+		warnings = restriction.Conflicts(restrictions, allergens)
+
+		if len(warnings) > 0 && restrictions.Mode == models.RestrictionModeReject {
+			log.Info("record rejected by restrictions", slog.Int64("accountId", acc.Id))
+			return models.Record{}, warnings, ErrRestricted
+		}
+	}
+
+	if record.Meal == "" {
+		record.Meal = models.MealByHour(record.DateRecord.Hour())
+	}
+
+	return record, warnings, nil
+}
+
+// SetRecordTagsForCurrentUser replaces tags of the record of the version,
+// tags must exist. Zero version skips the check.
+func (r *Record) SetRecordTagsForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+	version int64,
+	tags []string,
+) (models.Record, error) {
+	const op = "services.record.SetRecordTagsForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not set record tags - incorrect token")
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tags, err = tag.NormalizeNames(tags)
+	if err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	old, err := r.accountRecord(ctx, acc, recordId)
+	if err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version == 0 {
+		version = old.Version
+	}
+
+	tagged := old
+	tagged.Tags = tags
+	revision := newRevision(acc, recordId, models.RecordUpdated, &old, &tagged)
+
+	if err := r.recordSaver.SetRecordTags(ctx, acc.Id, recordId, version, tags, revision); err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrRecordNotFound)
+		}
+
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("record version mismatch", slog.Int64("version", version))
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrTagNotFound)
+		}
+
+		log.Error("failed to set record tags", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record, err := r.recordProvider.RecordById(ctx, recordId)
+	if err != nil {
+		log.Error("failed to get record", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record.Value = acc.EnergyUnit.FromKcal(record.Value)
+
+	return record, nil
+}
+
+// UpdateRecordForCurrentUser replaces value, meal, description and date of
+// the record, catalog links, nutrients and tags are kept. Value is in the
+// account energy unit, missing meal is derived from the record hour. Zero
+// version skips the check.
+func (r *Record) UpdateRecordForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+	version int64,
+	update models.Record,
+) (models.Record, error) {
+	const op = "services.record.UpdateRecordForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not update record - incorrect token")
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	old, err := r.accountRecord(ctx, acc, recordId)
+	if err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record := old
+	record.Value = acc.EnergyUnit.ToKcal(update.Value)
+	record.Meal = update.Meal
+	record.Description = update.Description
+	record.DateRecord = update.DateRecord
+
+	if record.Meal == "" {
+		record.Meal = models.MealByHour(record.DateRecord.Hour())
+	}
+
+	record.FieldTimes = fieldTimesAt(time.Now())
+
+	if version != 0 {
+		record.Version = version
+	}
+
+	revision := newRevision(acc, recordId, models.RecordUpdated, &old, &record)
+
+	if err := r.recordSaver.UpdateRecord(ctx, record, revision); err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrRecordNotFound)
+		}
+
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("record version mismatch", slog.Int64("version", version))
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
+		log.Error("failed to update record", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record.Version++
+
+	r.refreshStreaks(ctx, log, acc, old.DateRecord, record.DateRecord)
+
+	record.Value = acc.EnergyUnit.FromKcal(record.Value)
+
+	return record, nil
+}
+
+// DeleteRecordForCurrentUser moves the record of the version to the trash,
+// it is restorable until purged. Zero version skips the check.
+func (r *Record) DeleteRecordForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+	version int64,
+) error {
 	const op = "services.record.DeleteRecordForCurrentUser"
 
 	log := r.log.With(slog.String("op", op))
@@ -127,9 +484,116 @@ func (r *Record) DeleteRecordForCurrentUser(ctx context.Context, recordId int64)
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := r.recordRemover.DeleteRecord(ctx, acc.Id, recordId); err != nil {
+	old, err := r.accountRecord(ctx, acc, recordId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if version == 0 {
+		version = old.Version
+	}
+
+	revision := newRevision(acc, recordId, models.RecordDeleted, &old, nil)
+
+	if err := r.recordRemover.DeleteRecord(ctx, acc.Id, recordId, version, revision); err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrRecordNotFound)
+		}
+
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Info("record version mismatch", slog.Int64("version", version))
+			return fmt.Errorf("%s: %w", op, ErrVersionMismatch)
+		}
+
 		log.Error("failed to delete record", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	r.refreshStreaks(ctx, log, acc, old.DateRecord)
+
 	return nil
 }
+
+// FromFood fills value, description and nutrients of a record missing them
+// from a catalog food scaled to the record grams.
+func FromFood(record models.Record, f models.Food) models.Record {
+	return fill(record, f.Name, f.Value, f.Nutrients, record.Grams/100)
+}
+
+// FromRecipe fills value, description and nutrients of a record missing them
+// from a recipe scaled to the record servings.
+func FromRecipe(record models.Record, recipe models.Recipe) models.Record {
+	return fill(record, recipe.Name, recipe.Value, recipe.Nutrients, record.Servings)
+}
+
+// fill keeps explicitly set record fields, explicit nutrients override
+// scaled ones.
+func fill(
+	record models.Record,
+	name string,
+	value int,
+	nutrients models.Nutrients,
+	factor float64,
+) models.Record {
+	if record.Value == 0 {
+		record.Value = int(math.Round(float64(value) * factor))
+	}
+
+	if record.Description == "" {
+		record.Description = name
+	}
+
+	scaled := nutrients.Scale(factor)
+	for nutrient, amount := range record.Nutrients {
+		if scaled == nil {
+			scaled = models.Nutrients{}
+		}
+		scaled[nutrient] = amount
+	}
+	record.Nutrients = scaled
+
+	return record
+}
+
+// refreshStreaks is best effort: the record change is already stored,
+// a stale streak snapshot is fixed by the next refresh. dates are dates of
+// the changed records before and after the change.
+func (r *Record) refreshStreaks(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	dates ...time.Time,
+) models.Streaks {
+	streaks, err := r.streakRefresher.RefreshStreaks(ctx, acc, dates...)
+	if err != nil {
+		log.Error("failed to refresh streaks", slog.String("err", err.Error()))
+	}
+
+	return streaks
+}
+
+// handleAchievements is best effort as well, missed achievements
+// are unlocked by the next matching event.
+func (r *Record) handleAchievements(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	streaks models.Streaks,
+) {
+	count, err := r.recordProvider.RecordsCountByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to count records", slog.String("err", err.Error()))
+		return
+	}
+
+	err = r.achievementHandler.HandleEvents(
+		ctx,
+		acc.Id,
+		models.AchievementEvent{Type: models.EventRecordCreated, Value: count},
+		models.AchievementEvent{Type: models.EventLoggingStreak, Value: streaks.Logging.Current},
+		models.AchievementEvent{Type: models.EventOnTargetStreak, Value: streaks.OnTarget.Current},
+	)
+	if err != nil {
+		log.Error("failed to handle achievements", slog.String("err", err.Error()))
+	}
+}
@@ -0,0 +1,286 @@
+package record_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/record"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFood(t *testing.T) {
+	food := models.Food{
+		Name:  "Oatmeal",
+		Value: 370,
+		Nutrients: models.Nutrients{
+			models.NutrientFiber: 10,
+			models.NutrientIron:  4,
+		},
+	}
+
+	result := record.FromFood(models.Record{Grams: 50}, food)
+
+	require.Equal(t, 185, result.Value)
+	require.Equal(t, "Oatmeal", result.Description)
+	require.Equal(t, models.Nutrients{
+		models.NutrientFiber: 5,
+		models.NutrientIron:  2,
+	}, result.Nutrients)
+
+	result = record.FromFood(models.Record{
+		Value:       200,
+		Description: "oatmeal with milk",
+		Grams:       50,
+		Nutrients:   models.Nutrients{models.NutrientCalcium: 120},
+	}, food)
+
+	require.Equal(t, 200, result.Value)
+	require.Equal(t, "oatmeal with milk", result.Description)
+	require.Equal(t, models.Nutrients{
+		models.NutrientFiber:   5,
+		models.NutrientIron:    2,
+		models.NutrientCalcium: 120,
+	}, result.Nutrients)
+}
+
+func TestFromRecipe(t *testing.T) {
+	recipe := models.Recipe{
+		Name:      "Pancakes",
+		Servings:  4,
+		Value:     240,
+		Nutrients: models.Nutrients{models.NutrientCalcium: 120},
+	}
+
+	result := record.FromRecipe(models.Record{Servings: 1.5}, recipe)
+
+	require.Equal(t, 360, result.Value)
+	require.Equal(t, "Pancakes", result.Description)
+	require.Equal(t, models.Nutrients{models.NutrientCalcium: 180}, result.Nutrients)
+}
+
+func TestQuickAddRecords(t *testing.T) {
+	day := time.Date(2024, 4, 10, 8, 0, 0, 0, time.UTC)
+	value := func(kcal int) *int {
+		return &kcal
+	}
+	id := func(value int64) *int64 {
+		return &value
+	}
+
+	egg := models.QuickAddItem{Text: "2 eggs", Name: "Egg", FoodId: id(1), Grams: 200}
+	curry := models.QuickAddItem{Text: "curry", Name: "Curry", RecipeId: id(2), Servings: 1}
+	pickles := models.QuickAddItem{Text: "pickles"}
+
+	// Total value makes one record
+	records, unmatched := record.QuickAddRecords(models.QuickAdd{
+		DateRecord: day,
+		Meal:       models.MealBreakfast,
+		Value:      value(350),
+		Items:      []models.QuickAddItem{egg, pickles},
+	})
+	require.Empty(t, unmatched)
+	require.Equal(t, []models.Record{{
+		Value:       350,
+		Meal:        models.MealBreakfast,
+		Description: "2 eggs, pickles",
+		DateRecord:  day,
+	}}, records)
+
+	// Single matched item keeps the catalog reference
+	records, _ = record.QuickAddRecords(models.QuickAdd{
+		DateRecord: day,
+		Value:      value(300),
+		Items:      []models.QuickAddItem{egg},
+	})
+	require.Equal(t, []models.Record{{
+		Value:      300,
+		FoodId:     id(1),
+		Grams:      200,
+		DateRecord: day,
+	}}, records)
+
+	// Record per item, unmatched items need a value
+	pickles.Value = value(20)
+	records, unmatched = record.QuickAddRecords(models.QuickAdd{
+		DateRecord: day,
+		Items:      []models.QuickAddItem{egg, curry, pickles, {Text: "chips"}},
+	})
+	require.Equal(t, []string{"chips"}, unmatched)
+	require.Equal(t, []models.Record{
+		{FoodId: id(1), Grams: 200, DateRecord: day},
+		{RecipeId: id(2), Servings: 1, DateRecord: day},
+		{Value: 20, Description: "pickles", DateRecord: day},
+	}, records)
+}
+
+func TestQuickAddAllergens(t *testing.T) {
+	id := func(value int64) *int64 {
+		return &value
+	}
+
+	foodsById := map[int64]models.Food{
+		1: {Id: 1, Name: "Egg", Allergens: []models.Allergen{models.AllergenEggs}},
+		2: {Id: 2, Name: "Toast", Allergens: []models.Allergen{models.AllergenGluten}},
+	}
+	recipesById := map[int64]models.Recipe{
+		3: {Id: 3, Name: "Pancakes", Allergens: []models.Allergen{
+			models.AllergenEggs,
+			models.AllergenMilk,
+		}},
+	}
+
+	// Items merged by a total value, "2 eggs and toast 350kcal"
+	allergens := record.QuickAddAllergens([]models.QuickAddItem{
+		{Text: "2 eggs", FoodId: id(1)},
+		{Text: "toast", FoodId: id(2)},
+		{Text: "pancakes", RecipeId: id(3)},
+		{Text: "pickles"},
+	}, foodsById, recipesById)
+
+	require.Equal(t, []models.Allergen{
+		models.AllergenEggs,
+		models.AllergenGluten,
+		models.AllergenMilk,
+	}, allergens)
+
+	require.Empty(t, record.QuickAddAllergens([]models.QuickAddItem{{Text: "pickles"}}, foodsById, recipesById))
+}
+
+func TestMatchQuery(t *testing.T) {
+	match, err := record.MatchQuery("Greek yog")
+	require.NoError(t, err)
+	require.Equal(t, `"greek*" "yog*"`, match)
+
+	match, err = record.MatchQuery(`"pizza" OR crème-brûlée*`)
+	require.NoError(t, err)
+	require.Equal(t, `"pizza*" "or*" "crème*" "brûlée*"`, match)
+
+	match, err = record.MatchQuery("a b c d e f g h i j")
+	require.NoError(t, err)
+	require.Equal(t, `"a*" "b*" "c*" "d*" "e*" "f*" "g*" "h*"`, match)
+
+	_, err = record.MatchQuery(" -*- ")
+	require.ErrorIs(t, err, record.ErrInvalidQuery)
+}
+
+func TestMergeChange(t *testing.T) {
+	morning := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+	noon := morning.Add(4 * time.Hour)
+	evening := morning.Add(10 * time.Hour)
+
+	stored := models.Record{
+		Value:       300,
+		Meal:        models.MealBreakfast,
+		Description: "Oatmeal",
+		DateRecord:  morning,
+		FieldTimes: models.RecordFieldTimes{
+			models.RecordFieldValue:       noon,
+			models.RecordFieldMeal:        morning,
+			models.RecordFieldDescription: morning,
+			models.RecordFieldDateRecord:  morning,
+		},
+	}
+
+	value := 450
+	description := "Oatmeal with honey"
+
+	// Fields changed after the stored ones win, older ones are dropped
+	merged, changed := record.MergeChange(stored, models.RecordChange{
+		UpdatedAt:   morning.Add(2 * time.Hour),
+		Value:       &value,
+		Description: &description,
+	})
+	require.True(t, changed)
+	require.Equal(t, 300, merged.Value)
+	require.Equal(t, "Oatmeal with honey", merged.Description)
+	require.Equal(t, morning.Add(2*time.Hour), merged.FieldTimes[models.RecordFieldDescription])
+	require.Equal(t, noon, merged.FieldTimes[models.RecordFieldValue])
+	require.Equal(t, morning, stored.FieldTimes[models.RecordFieldDescription])
+
+	// Repeated change is a no-op
+	_, changed = record.MergeChange(merged, models.RecordChange{
+		UpdatedAt:   morning.Add(2 * time.Hour),
+		Description: &description,
+	})
+	require.False(t, changed)
+
+	// Unchanged fields are kept
+	merged, changed = record.MergeChange(stored, models.RecordChange{
+		UpdatedAt: evening,
+		Value:     &value,
+	})
+	require.True(t, changed)
+	require.Equal(t, 450, merged.Value)
+	require.Equal(t, models.MealBreakfast, merged.Meal)
+	require.Equal(t, "Oatmeal", merged.Description)
+	require.Equal(t, evening, merged.FieldTimes[models.RecordFieldValue])
+
+	// Records without times take any change
+	meal := models.MealDinner
+	merged, changed = record.MergeChange(models.Record{}, models.RecordChange{
+		UpdatedAt:  morning,
+		Value:      &value,
+		Meal:       &meal,
+		DateRecord: &evening,
+	})
+	require.True(t, changed)
+	require.Equal(t, models.Record{
+		Value:      450,
+		Meal:       models.MealDinner,
+		DateRecord: evening,
+		FieldTimes: models.RecordFieldTimes{
+			models.RecordFieldValue:      morning,
+			models.RecordFieldMeal:       morning,
+			models.RecordFieldDateRecord: morning,
+		},
+	}, merged)
+}
+
+func TestParseSyncToken(t *testing.T) {
+	seq, err := record.ParseSyncToken("")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), seq)
+
+	seq, err = record.ParseSyncToken(record.FormatSyncToken(42))
+	require.NoError(t, err)
+	require.Equal(t, int64(42), seq)
+
+	_, err = record.ParseSyncToken("-1")
+	require.ErrorIs(t, err, record.ErrInvalidSyncToken)
+
+	_, err = record.ParseSyncToken("abc")
+	require.ErrorIs(t, err, record.ErrInvalidSyncToken)
+}
+
+func TestMarkDuplicates(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	lunch := time.Date(2024, 4, 19, 13, 0, 0, 0, loc)
+
+	coffee := models.Record{Value: 5, Meal: models.MealSnack, Description: "Coffee", DateRecord: lunch}
+	rice := models.Record{Value: 205, Meal: models.MealLunch, Description: "Rice", DateRecord: lunch}
+
+	// Stored records are in UTC, keys use days of loc
+	stored := coffee
+	stored.Description = " coffee"
+	stored.DateRecord = time.Date(2024, 4, 18, 22, 30, 0, 0, time.UTC)
+	require.Equal(t, record.ImportKey(coffee, loc), record.ImportKey(stored, loc))
+	require.NotEqual(t, record.ImportKey(coffee, time.UTC), record.ImportKey(stored, time.UTC))
+
+	rows := []models.ImportRow{
+		{Line: 2, Status: models.ImportRowNew, Record: &coffee},
+		{Line: 3, Status: models.ImportRowNew, Record: &rice},
+		{Line: 4, Status: models.ImportRowNew, Record: &coffee},
+		{Line: 5, Status: models.ImportRowInvalid, Errors: []string{"Food Name is required"}},
+	}
+
+	existing := map[string]int{record.ImportKey(stored, loc): 1}
+
+	record.MarkDuplicates(rows, existing, loc)
+
+	// The second coffee of the day has no stored record left to match
+	require.Equal(t, models.ImportRowDuplicate, rows[0].Status)
+	require.Equal(t, models.ImportRowNew, rows[1].Status)
+	require.Equal(t, models.ImportRowNew, rows[2].Status)
+	require.Equal(t, models.ImportRowInvalid, rows[3].Status)
+}
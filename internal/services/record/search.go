@@ -0,0 +1,84 @@
+package record
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+const (
+	// SearchLimit caps search results, the newest matches are returned
+	SearchLimit = 50
+	// maxQueryTerms keeps match expressions of long queries cheap
+	maxQueryTerms = 8
+)
+
+// SearchRecordsForCurrentUser returns records with descriptions, tags or
+// food names matching all words of q as prefixes, newest first. Zero from
+// or to leave the range open.
+func (r *Record) SearchRecordsForCurrentUser(
+	ctx context.Context,
+	q string,
+	from time.Time,
+	to time.Time,
+) ([]models.RecordSearchResult, error) {
+	const op = "services.record.SearchRecordsForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not search records - incorrect token")
+		return []models.RecordSearchResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	match, err := MatchQuery(q)
+	if err != nil {
+		return []models.RecordSearchResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	results, err := r.recordProvider.SearchRecords(ctx, acc.Id, match, from, to, SearchLimit)
+	if err != nil {
+		log.Error("failed to search records", slog.String("err", err.Error()))
+		return []models.RecordSearchResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(results) == 0 {
+		results = []models.RecordSearchResult{}
+	}
+
+	for i := range results {
+		results[i].Value = acc.EnergyUnit.FromKcal(results[i].Value)
+	}
+
+	return results, nil
+}
+
+// MatchQuery builds a full-text match expression requiring every word of q
+// as a prefix. Words are runs of letters and digits, FTS operators and
+// quotes in q are never passed through.
+func MatchQuery(q string) (string, error) {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(words) == 0 {
+		return "", ErrInvalidQuery
+	}
+
+	if len(words) > maxQueryTerms {
+		words = words[:maxQueryTerms]
+	}
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+word+`*"`)
+	}
+
+	return strings.Join(terms, " "), nil
+}
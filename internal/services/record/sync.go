@@ -0,0 +1,343 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/uuid"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+const (
+	// MaxSyncChanges limits client changes of a single sync.
+	MaxSyncChanges = 100
+	// SyncPageSize limits server changes returned by a single sync.
+	SyncPageSize = 500
+
+	// syncAttempts is how many times client changes are merged again when
+	// the records change concurrently.
+	syncAttempts = 3
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	ErrTooManyChanges   = errors.New("sync should have at most 100 changes")
+	ErrIncompleteChange = errors.New("new record requires value and dateRecord")
+	ErrInvalidUuid      = errors.New("invalid record uuid")
+)
+
+// FormatSyncToken returns the token of the change sequence position.
+func FormatSyncToken(seq int64) string {
+	return strconv.FormatInt(seq, 10)
+}
+
+// ParseSyncToken returns the change sequence position of the token, empty
+// token is the start of the sequence.
+func ParseSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
+
+// SyncForCurrentUser applies client changes and returns server changes
+// since the token, the applied changes included. Changes are merged per
+// field, the one changed last wins; a deletion wins over fields changed
+// before it and a deleted record is never brought back by a change. Client
+// times in the future are taken as now.
+func (r *Record) SyncForCurrentUser(
+	ctx context.Context,
+	token string,
+	changes []models.RecordChange,
+) (models.SyncResult, error) {
+	const op = "services.record.SyncForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not sync records - incorrect token")
+		return models.SyncResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	after, err := ParseSyncToken(token)
+	if err != nil {
+		return models.SyncResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(changes) > MaxSyncChanges {
+		return models.SyncResult{}, fmt.Errorf("%s: %w", op, ErrTooManyChanges)
+	}
+
+	if len(changes) > 0 {
+		if err := r.applyChanges(ctx, log, acc, changes); err != nil {
+			return models.SyncResult{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	state, err := r.recordProvider.RecordSyncState(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get sync state", slog.String("err", err.Error()))
+		return models.SyncResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := models.SyncResult{Changes: []models.SyncChange{}}
+
+	if after < state.Floor || after > state.Seq {
+		log.Info("sync token is outdated", slog.Int64("accountId", acc.Id))
+		result.Reset = true
+		after = 0
+	}
+
+	records, err := r.recordProvider.RecordChanges(ctx, acc.Id, after, state.Seq, SyncPageSize+1)
+	if err != nil {
+		log.Error("failed to get record changes", slog.String("err", err.Error()))
+		return models.SyncResult{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result.Token = FormatSyncToken(state.Seq)
+
+	if len(records) > SyncPageSize {
+		records = records[:SyncPageSize]
+		result.HasMore = true
+		result.Token = FormatSyncToken(records[len(records)-1].Seq)
+	}
+
+	for _, record := range records {
+		change := models.SyncChange{Uuid: record.Uuid}
+
+		if record.DeletedAt != nil {
+			change.Deleted = true
+		} else {
+			record := record
+			record.Value = acc.EnergyUnit.FromKcal(record.Value)
+			change.Record = &record
+		}
+
+		result.Changes = append(result.Changes, change)
+	}
+
+	return result, nil
+}
+
+// applyChanges merges client changes into records and applies them as one
+// batch, merging again when records changed in between.
+func (r *Record) applyChanges(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	changes []models.RecordChange,
+) error {
+	for attempt := 1; ; attempt++ {
+		operations, olds, indexes, err := r.mergeChanges(ctx, log, acc, changes)
+		if err != nil {
+			return err
+		}
+
+		if len(operations) == 0 {
+			return nil
+		}
+
+		withRevisions(acc, operations, olds)
+
+		ids, err := r.recordSaver.ApplyRecordBatch(ctx, acc.Id, operations)
+		if err == nil {
+			r.batchApplied(ctx, log, acc, operations, olds, ids)
+			return nil
+		}
+
+		var batchErr *storage.BatchError
+		if !errors.As(err, &batchErr) {
+			log.Error("failed to apply changes", slog.String("err", err.Error()))
+			return err
+		}
+
+		concurrent := errors.Is(batchErr.Err, storage.ErrVersionMismatch) ||
+			errors.Is(batchErr.Err, storage.ErrRecordExists)
+
+		if !concurrent || attempt == syncAttempts {
+			return &OperationError{
+				Index: indexes[batchErr.Index],
+				Err:   operationError(batchErr.Err),
+			}
+		}
+
+		log.Info("records changed during sync, merging again", slog.Int("attempt", attempt))
+	}
+}
+
+// mergeChanges returns operations for changes that modify records with
+// records before them and indexes of the changes.
+func (r *Record) mergeChanges(
+	ctx context.Context,
+	log *slog.Logger,
+	acc models.Account,
+	changes []models.RecordChange,
+) ([]models.RecordOperation, []models.Record, []int, error) {
+	var (
+		operations []models.RecordOperation
+		olds       []models.Record
+		indexes    []int
+		seen       = make(map[string]bool, len(changes))
+		now        = time.Now()
+	)
+
+	for i, change := range changes {
+		if !uuid.Valid(change.Uuid) {
+			return nil, nil, nil, &OperationError{Index: i, Err: ErrInvalidUuid}
+		}
+
+		if seen[change.Uuid] {
+			return nil, nil, nil, &OperationError{Index: i, Err: ErrDuplicateRecord}
+		}
+		seen[change.Uuid] = true
+
+		if change.UpdatedAt.After(now) {
+			change.UpdatedAt = now
+		}
+
+		old, err := r.recordProvider.RecordByUuid(ctx, acc.Id, change.Uuid)
+		if err != nil && !errors.Is(err, storage.ErrRecordNotFound) {
+			log.Error("failed to get record", slog.String("err", err.Error()))
+			return nil, nil, nil, err
+		}
+
+		if err != nil {
+			if change.Deleted {
+				continue
+			}
+
+			if change.Value == nil || change.DateRecord == nil {
+				return nil, nil, nil, &OperationError{Index: i, Err: ErrIncompleteChange}
+			}
+
+			record, _ := MergeChange(models.Record{Uuid: change.Uuid}, change)
+
+			record, warnings, err := r.prepareRecord(ctx, log, acc, record)
+			if err != nil {
+				return nil, nil, nil, &OperationError{Index: i, Err: err, Warnings: warnings}
+			}
+
+			operations = append(operations, models.RecordOperation{
+				Type:   models.RecordOperationCreate,
+				Record: record,
+			})
+			olds = append(olds, models.Record{})
+			indexes = append(indexes, i)
+			continue
+		}
+
+		if old.DeletedAt != nil {
+			continue
+		}
+
+		if change.Deleted {
+			if change.UpdatedAt.Before(latestFieldTime(old.FieldTimes)) {
+				continue
+			}
+
+			operations = append(operations, models.RecordOperation{
+				Type:     models.RecordOperationDelete,
+				RecordId: old.Id,
+				Version:  old.Version,
+			})
+		} else {
+			if change.Value != nil {
+				value := acc.EnergyUnit.ToKcal(*change.Value)
+				change.Value = &value
+			}
+
+			record, changed := MergeChange(old, change)
+			if !changed {
+				continue
+			}
+
+			operations = append(operations, models.RecordOperation{
+				Type:     models.RecordOperationUpdate,
+				RecordId: old.Id,
+				Version:  old.Version,
+				Record:   record,
+			})
+		}
+
+		olds = append(olds, old)
+		indexes = append(indexes, i)
+	}
+
+	return operations, olds, indexes, nil
+}
+
+// MergeChange applies fields of the change made after the record fields
+// were changed and reports whether any was applied.
+func MergeChange(record models.Record, change models.RecordChange) (models.Record, bool) {
+	fieldTimes := make(models.RecordFieldTimes, len(models.RecordFields))
+	for field, changedAt := range record.FieldTimes {
+		fieldTimes[field] = changedAt
+	}
+
+	changed := false
+
+	newer := func(field models.RecordField) bool {
+		if !change.UpdatedAt.After(fieldTimes[field]) {
+			return false
+		}
+
+		fieldTimes[field] = change.UpdatedAt
+		changed = true
+		return true
+	}
+
+	if change.Value != nil && newer(models.RecordFieldValue) {
+		record.Value = *change.Value
+	}
+
+	if change.Meal != nil && newer(models.RecordFieldMeal) {
+		record.Meal = *change.Meal
+	}
+
+	if change.Description != nil && newer(models.RecordFieldDescription) {
+		record.Description = *change.Description
+	}
+
+	if change.DateRecord != nil && newer(models.RecordFieldDateRecord) {
+		record.DateRecord = *change.DateRecord
+	}
+
+	record.FieldTimes = fieldTimes
+
+	return record, changed
+}
+
+// fieldTimesAt marks all fields as changed at the time, changes made
+// through the API win over offline changes made before them.
+func fieldTimesAt(changedAt time.Time) models.RecordFieldTimes {
+	fieldTimes := make(models.RecordFieldTimes, len(models.RecordFields))
+	for _, field := range models.RecordFields {
+		fieldTimes[field] = changedAt
+	}
+
+	return fieldTimes
+}
+
+func latestFieldTime(fieldTimes models.RecordFieldTimes) time.Time {
+	var latest time.Time
+
+	for _, changedAt := range fieldTimes {
+		if changedAt.After(latest) {
+			latest = changedAt
+		}
+	}
+
+	return latest
+}
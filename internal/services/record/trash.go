@@ -0,0 +1,109 @@
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+// GetTrashForCurrentUser returns deleted records not purged yet,
+// recently deleted first.
+func (r *Record) GetTrashForCurrentUser(ctx context.Context) ([]models.Record, error) {
+	const op = "services.record.GetTrashForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get trash - incorrect token")
+		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records, err := r.recordProvider.DeletedRecordsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get deleted records", slog.String("err", err.Error()))
+		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(records) == 0 {
+		records = []models.Record{}
+	}
+
+	for i := range records {
+		records[i].Value = acc.EnergyUnit.FromKcal(records[i].Value)
+	}
+
+	return records, nil
+}
+
+// RestoreRecordForCurrentUser moves the record out of the trash.
+func (r *Record) RestoreRecordForCurrentUser(
+	ctx context.Context,
+	recordId int64,
+) (models.Record, error) {
+	const op = "services.record.RestoreRecordForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not restore record - incorrect token")
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	trashed, err := r.recordProvider.DeletedRecordById(ctx, acc.Id, recordId)
+	if err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrRecordNotFound)
+		}
+
+		log.Error("failed to get deleted record", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revision := newRevision(acc, recordId, models.RecordRestored, nil, &trashed)
+
+	if err := r.recordRemover.RestoreRecord(ctx, acc.Id, recordId, revision); err != nil {
+		if errors.Is(err, storage.ErrRecordNotFound) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, ErrRecordNotFound)
+		}
+
+		log.Error("failed to restore record", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	record, err := r.recordProvider.RecordById(ctx, recordId)
+	if err != nil {
+		log.Error("failed to get record", slog.String("err", err.Error()))
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.refreshStreaks(ctx, log, acc, record.DateRecord)
+
+	record.Value = acc.EnergyUnit.FromKcal(record.Value)
+
+	return record, nil
+}
+
+// PurgeTrash permanently deletes records trashed before the time with
+// their photos and returns the number of purged records.
+func (r *Record) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	const op = "services.record.PurgeTrash"
+
+	log := r.log.With(slog.String("op", op))
+
+	records, photos, err := r.recordRemover.PurgeDeletedRecords(ctx, before)
+	if err != nil {
+		log.Error("failed to purge deleted records", slog.String("err", err.Error()))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.photoRemover.RemovePhotoBlobs(ctx, photos)
+
+	return len(records), nil
+}
@@ -0,0 +1,124 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/stats"
+)
+
+type Report struct {
+	log             *slog.Logger
+	totalsProvider  DailyTotalsProvider
+	weightProvider  WeightProvider
+	foodsProvider   TopFoodsProvider
+	accountProvider AccountProvider
+}
+
+type DailyTotalsProvider interface {
+	DailyTotalsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.DailyTotal, error)
+}
+
+type WeightProvider interface {
+	WeightsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.Weight, error)
+}
+
+type TopFoodsProvider interface {
+	TopFoodsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+		limit int,
+	) ([]models.FoodTotal, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	topFoodsLimit = 10
+)
+
+func New(
+	log *slog.Logger,
+	totalsProvider DailyTotalsProvider,
+	weightProvider WeightProvider,
+	foodsProvider TopFoodsProvider,
+	accountProvider AccountProvider,
+) *Report {
+	return &Report{
+		log:             log,
+		totalsProvider:  totalsProvider,
+		weightProvider:  weightProvider,
+		foodsProvider:   foodsProvider,
+		accountProvider: accountProvider,
+	}
+}
+
+// GetMonthlyReportForCurrentUser collects report data for the month containing month.
+func (r *Report) GetMonthlyReportForCurrentUser(
+	ctx context.Context,
+	month time.Time,
+) (models.MonthlyReport, error) {
+	const op = "services.report.GetMonthlyReportForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get report - incorrect token")
+		return models.MonthlyReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	next := from.AddDate(0, 1, 0)
+	to := next.AddDate(0, 0, -1)
+
+	totals, err := r.totalsProvider.DailyTotalsByAccountIdInRange(ctx, acc.Id, from, to)
+	if err != nil {
+		log.Error("failed to get daily totals", slog.String("err", err.Error()))
+		return models.MonthlyReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	weights, err := r.weightProvider.WeightsByAccountIdInRange(ctx, acc.Id, from, next)
+	if err != nil {
+		log.Error("failed to get weights", slog.String("err", err.Error()))
+		return models.MonthlyReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	foods, err := r.foodsProvider.TopFoodsByAccountIdInRange(ctx, acc.Id, from, next, topFoodsLimit)
+	if err != nil {
+		log.Error("failed to get top foods", slog.String("err", err.Error()))
+		return models.MonthlyReport{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(weights) == 0 {
+		weights = []models.Weight{}
+	}
+
+	if len(foods) == 0 {
+		foods = []models.FoodTotal{}
+	}
+
+	return models.MonthlyReport{
+		Month:    from,
+		Intake:   stats.IntakeStats(totals, from, to, acc.DailyLimit),
+		Weights:  weights,
+		TopFoods: foods,
+	}.In(acc.EnergyUnit), nil
+}
@@ -0,0 +1,185 @@
+package restriction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Restriction struct {
+	log                  *slog.Logger
+	restrictionsProvider RestrictionsProvider
+	restrictionsSaver    RestrictionsSaver
+	accountProvider      AccountProvider
+}
+
+type RestrictionsProvider interface {
+	RestrictionsByAccountId(ctx context.Context, accountId int64) (models.Restrictions, error)
+}
+
+type RestrictionsSaver interface {
+	SaveRestrictions(ctx context.Context, accountId int64, restrictions models.Restrictions) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	// Restriction of warnings caused by declared allergies
+	RestrictionAllergy = "allergy"
+)
+
+var (
+	ErrInvalidAllergens = errors.New("invalid allergens")
+	ErrInvalidDiets     = errors.New("invalid diets")
+)
+
+func New(
+	log *slog.Logger,
+	restrictionsProvider RestrictionsProvider,
+	restrictionsSaver RestrictionsSaver,
+	accountProvider AccountProvider,
+) *Restriction {
+	return &Restriction{
+		log:                  log,
+		restrictionsProvider: restrictionsProvider,
+		restrictionsSaver:    restrictionsSaver,
+		accountProvider:      accountProvider,
+	}
+}
+
+func (r *Restriction) GetRestrictionsForCurrentUser(ctx context.Context) (models.Restrictions, error) {
+	const op = "services.restriction.GetRestrictionsForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get restrictions - incorrect token")
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restrictions, err := r.restrictionsProvider.RestrictionsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get restrictions", slog.String("err", err.Error()))
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return normalize(restrictions), nil
+}
+
+// UpdateRestrictionsForCurrentUser replaces restrictions, empty mode means warn.
+func (r *Restriction) UpdateRestrictionsForCurrentUser(
+	ctx context.Context,
+	restrictions models.Restrictions,
+) (models.Restrictions, error) {
+	const op = "services.restriction.UpdateRestrictionsForCurrentUser"
+
+	log := r.log.With(slog.String("op", op))
+
+	acc, err := r.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not update restrictions - incorrect token")
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := ValidateAllergens(restrictions.Allergens); err != nil {
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := ValidateDiets(restrictions.Diets); err != nil {
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restrictions = normalize(restrictions)
+
+	if err := r.restrictionsSaver.SaveRestrictions(ctx, acc.Id, restrictions); err != nil {
+		log.Error("failed to save restrictions", slog.String("err", err.Error()))
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return restrictions, nil
+}
+
+func normalize(restrictions models.Restrictions) models.Restrictions {
+	if restrictions.Mode == "" {
+		restrictions.Mode = models.RestrictionModeWarn
+	}
+
+	if restrictions.Allergens == nil {
+		restrictions.Allergens = []models.Allergen{}
+	}
+
+	if restrictions.Diets == nil {
+		restrictions.Diets = []models.Diet{}
+	}
+
+	return restrictions
+}
+
+func ValidateAllergens(allergens []models.Allergen) error {
+	for _, allergen := range allergens {
+		if !isAllergen(allergen) {
+			return fmt.Errorf("%w: %s", ErrInvalidAllergens, allergen)
+		}
+	}
+
+	return nil
+}
+
+func ValidateDiets(diets []models.Diet) error {
+	for _, diet := range diets {
+		if _, ok := models.DietAllergens[diet]; !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidDiets, diet)
+		}
+	}
+
+	return nil
+}
+
+func isAllergen(allergen models.Allergen) bool {
+	for _, known := range models.Allergens {
+		if known == allergen {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Conflicts returns a warning for every food allergen excluded by
+// an account allergy or diet.
+func Conflicts(
+	restrictions models.Restrictions,
+	allergens []models.Allergen,
+) []models.RestrictionWarning {
+	var warnings []models.RestrictionWarning
+
+	for _, allergen := range allergens {
+		for _, allergy := range restrictions.Allergens {
+			if allergy == allergen {
+				warnings = append(warnings, models.RestrictionWarning{
+					Allergen:    allergen,
+					Restriction: RestrictionAllergy,
+				})
+			}
+		}
+
+		for _, diet := range restrictions.Diets {
+			for _, excluded := range models.DietAllergens[diet] {
+				if excluded == allergen {
+					warnings = append(warnings, models.RestrictionWarning{
+						Allergen:    allergen,
+						Restriction: string(diet),
+					})
+				}
+			}
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,41 @@
+package restriction_test
+
+import (
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/restriction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflicts(t *testing.T) {
+	restrictions := models.Restrictions{
+		Allergens: []models.Allergen{models.AllergenPeanuts, models.AllergenTreeNuts},
+		Diets:     []models.Diet{models.DietVegan, models.DietGlutenFree},
+		Mode:      models.RestrictionModeWarn,
+	}
+
+	warnings := restriction.Conflicts(restrictions, []models.Allergen{
+		models.AllergenGluten,
+		models.AllergenMilk,
+		models.AllergenPeanuts,
+		models.AllergenSesame,
+	})
+
+	require.Equal(t, []models.RestrictionWarning{
+		{Allergen: models.AllergenGluten, Restriction: string(models.DietGlutenFree)},
+		{Allergen: models.AllergenMilk, Restriction: string(models.DietVegan)},
+		{Allergen: models.AllergenPeanuts, Restriction: restriction.RestrictionAllergy},
+	}, warnings)
+
+	require.Empty(t, restriction.Conflicts(restrictions, []models.Allergen{models.AllergenSesame}))
+	require.Empty(t, restriction.Conflicts(models.Restrictions{}, []models.Allergen{models.AllergenMilk}))
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, restriction.ValidateAllergens([]models.Allergen{models.AllergenMeat}))
+	require.ErrorIs(t, restriction.ValidateAllergens([]models.Allergen{"kryptonite"}), restriction.ErrInvalidAllergens)
+
+	require.NoError(t, restriction.ValidateDiets([]models.Diet{models.DietPescatarian}))
+	require.ErrorIs(t, restriction.ValidateDiets([]models.Diet{"carnivore"}), restriction.ErrInvalidDiets)
+}
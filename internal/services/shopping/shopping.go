@@ -0,0 +1,329 @@
+package shopping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Shopping struct {
+	log             *slog.Logger
+	planProvider    PlanProvider
+	catalogProvider CatalogProvider
+	checkProvider   CheckProvider
+	checkSaver      CheckSaver
+	accountProvider AccountProvider
+}
+
+type PlanProvider interface {
+	MealPlansByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.MealPlan, error)
+}
+
+type CatalogProvider interface {
+	FoodById(ctx context.Context, accountId int64, foodId int64) (models.Food, error)
+	FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error)
+	RecipeById(ctx context.Context, accountId int64, recipeId int64) (models.Recipe, error)
+}
+
+type CheckProvider interface {
+	CheckedFoodIds(ctx context.Context, accountId int64) ([]int64, error)
+}
+
+type CheckSaver interface {
+	SaveShoppingCheck(ctx context.Context, accountId int64, check models.ShoppingCheck) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+var (
+	ErrFoodNotFound   = errors.New("food not found")
+	ErrRecipeNotFound = errors.New("recipe not found")
+)
+
+func New(
+	log *slog.Logger,
+	planProvider PlanProvider,
+	catalogProvider CatalogProvider,
+	checkProvider CheckProvider,
+	checkSaver CheckSaver,
+	accountProvider AccountProvider,
+) *Shopping {
+	return &Shopping{
+		log:             log,
+		planProvider:    planProvider,
+		catalogProvider: catalogProvider,
+		checkProvider:   checkProvider,
+		checkSaver:      checkSaver,
+		accountProvider: accountProvider,
+	}
+}
+
+// GetShoppingListForCurrentUser sums foods of meals planned on days in
+// [from, to] and of whole recipes with recipeIds. Recipes are expanded to
+// their ingredients scaled to planned servings.
+func (s *Shopping) GetShoppingListForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+	recipeIds []int64,
+) (models.ShoppingList, error) {
+	const op = "services.shopping.GetShoppingListForCurrentUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	acc, err := s.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get shopping list - incorrect token")
+		return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Planned days are calendar dates
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	plans, err := s.planProvider.MealPlansByAccountIdInRange(ctx, acc.Id, from, to)
+	if err != nil {
+		log.Error("failed to get meal plans", slog.String("err", err.Error()))
+		return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	meals := LatestPlannedMeals(plans, from, to)
+
+	recipes := make(map[int64]models.Recipe)
+
+	for _, recipeId := range recipeIds {
+		if err := s.loadRecipe(ctx, acc.Id, recipeId, recipes); err != nil {
+			if errors.Is(err, storage.ErrRecipeNotFound) {
+				return models.ShoppingList{}, fmt.Errorf("%s: %w", op, ErrRecipeNotFound)
+			}
+
+			log.Error("failed to get recipe", slog.String("err", err.Error()))
+			return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	for _, meal := range meals {
+		if meal.RecipeId == nil {
+			continue
+		}
+
+		err := s.loadRecipe(ctx, acc.Id, *meal.RecipeId, recipes)
+		if err != nil && !errors.Is(err, storage.ErrRecipeNotFound) {
+			log.Error("failed to get recipe", slog.String("err", err.Error()))
+			return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	foods, err := s.catalogProvider.FoodsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get foods", slog.String("err", err.Error()))
+		return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	checkedIds, err := s.checkProvider.CheckedFoodIds(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get shopping checks", slog.String("err", err.Error()))
+		return models.ShoppingList{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	checked := make(map[int64]bool, len(checkedIds))
+	for _, foodId := range checkedIds {
+		checked[foodId] = true
+	}
+
+	if recipeIds == nil {
+		recipeIds = []int64{}
+	}
+
+	return models.ShoppingList{
+		From:       from,
+		To:         to,
+		RecipeIds:  recipeIds,
+		Categories: List(FoodGrams(meals, recipes, recipeIds), foods, checked),
+	}, nil
+}
+
+// UpdateShoppingCheckForCurrentUser checks a catalog food off the shopping
+// list or unchecks it, the state is kept until changed.
+func (s *Shopping) UpdateShoppingCheckForCurrentUser(
+	ctx context.Context,
+	check models.ShoppingCheck,
+) (models.ShoppingCheck, error) {
+	const op = "services.shopping.UpdateShoppingCheckForCurrentUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	acc, err := s.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not update shopping check - incorrect token")
+		return models.ShoppingCheck{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := s.catalogProvider.FoodById(ctx, acc.Id, check.FoodId); err != nil {
+		if errors.Is(err, storage.ErrFoodNotFound) {
+			return models.ShoppingCheck{}, fmt.Errorf("%s: %w", op, ErrFoodNotFound)
+		}
+
+		log.Error("failed to get food", slog.String("err", err.Error()))
+		return models.ShoppingCheck{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.checkSaver.SaveShoppingCheck(ctx, acc.Id, check); err != nil {
+		log.Error("failed to save shopping check", slog.String("err", err.Error()))
+		return models.ShoppingCheck{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return check, nil
+}
+
+func (s *Shopping) loadRecipe(
+	ctx context.Context,
+	accountId int64,
+	recipeId int64,
+	recipes map[int64]models.Recipe,
+) error {
+	if _, ok := recipes[recipeId]; ok {
+		return nil
+	}
+
+	recipe, err := s.catalogProvider.RecipeById(ctx, accountId, recipeId)
+	if err != nil {
+		return err
+	}
+
+	recipes[recipeId] = recipe
+
+	return nil
+}
+
+// LatestPlannedMeals returns meals planned on days in [from, to]. A day
+// planned by several plans is taken from the newest plan, plans are
+// expected newest first.
+func LatestPlannedMeals(plans []models.MealPlan, from time.Time, to time.Time) []models.PlannedMeal {
+	var meals []models.PlannedMeal
+
+	planned := make(map[time.Time]bool)
+
+	for _, plan := range plans {
+		for _, day := range plan.Days {
+			if day.Day.Before(from) || day.Day.After(to) || planned[day.Day] {
+				continue
+			}
+
+			planned[day.Day] = true
+			meals = append(meals, day.Meals...)
+		}
+	}
+
+	return meals
+}
+
+// FoodGrams sums grams of catalog foods by food id. Meals and recipes
+// without catalog references are skipped.
+func FoodGrams(
+	meals []models.PlannedMeal,
+	recipes map[int64]models.Recipe,
+	recipeIds []int64,
+) map[int64]float64 {
+	grams := make(map[int64]float64)
+
+	addRecipe := func(recipeId int64, servings float64) {
+		recipe, ok := recipes[recipeId]
+		if !ok || recipe.Servings <= 0 {
+			return
+		}
+
+		factor := servings / float64(recipe.Servings)
+
+		for _, ingredient := range recipe.Ingredients {
+			grams[ingredient.FoodId] += ingredient.Grams * factor
+		}
+	}
+
+	for _, meal := range meals {
+		switch {
+		case meal.FoodId != nil:
+			grams[*meal.FoodId] += meal.Grams
+		case meal.RecipeId != nil:
+			addRecipe(*meal.RecipeId, meal.Servings)
+		}
+	}
+
+	for _, recipeId := range recipeIds {
+		addRecipe(recipeId, float64(recipes[recipeId].Servings))
+	}
+
+	return grams
+}
+
+// List groups foods in FoodCategories order, items keep the order of foods
+// (catalog is ordered by name). Foods without a category are listed as other.
+func List(
+	grams map[int64]float64,
+	foods []models.Food,
+	checked map[int64]bool,
+) []models.ShoppingCategory {
+	items := make(map[models.FoodCategory][]models.ShoppingItem)
+
+	for _, food := range foods {
+		amount, ok := grams[food.Id]
+		if !ok || amount <= 0 {
+			continue
+		}
+
+		category := food.Category
+		if category == "" {
+			category = models.FoodCategoryOther
+		}
+
+		quantity, unit := Normalize(amount)
+
+		items[category] = append(items[category], models.ShoppingItem{
+			FoodId:   food.Id,
+			Name:     food.Name,
+			Category: category,
+			Grams:    math.Round(amount*100) / 100,
+			Quantity: quantity,
+			Unit:     unit,
+			Checked:  checked[food.Id],
+		})
+	}
+
+	categories := []models.ShoppingCategory{}
+
+	for _, category := range models.FoodCategories {
+		if len(items[category]) == 0 {
+			continue
+		}
+
+		categories = append(categories, models.ShoppingCategory{
+			Category: category,
+			Items:    items[category],
+		})
+	}
+
+	return categories
+}
+
+// Normalize rounds grams up to whole grams, or to 10 g in kilograms
+// from 1 kg, so the bought amount is never short.
+func Normalize(grams float64) (float64, string) {
+	if rounded := math.Ceil(grams - 1e-9); rounded < 1000 {
+		return rounded, "g"
+	}
+
+	return math.Ceil(grams/10-1e-9) / 100, "kg"
+}
@@ -0,0 +1,127 @@
+package shopping_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/shopping"
+	"github.com/stretchr/testify/require"
+)
+
+func id(value int64) *int64 {
+	return &value
+}
+
+var (
+	rice    = models.Food{Id: 1, Name: "Rice", Category: models.FoodCategoryGrains}
+	chicken = models.Food{Id: 2, Name: "Chicken breast", Category: models.FoodCategoryMeat}
+	onion   = models.Food{Id: 3, Name: "Onion", Category: models.FoodCategoryProduce}
+	salt    = models.Food{Id: 4, Name: "Salt"}
+
+	pilaf = models.Recipe{
+		Id:       1,
+		Name:     "Pilaf",
+		Servings: 4,
+		Ingredients: []models.RecipeIngredient{
+			{FoodId: rice.Id, Grams: 400},
+			{FoodId: chicken.Id, Grams: 600},
+			{FoodId: onion.Id, Grams: 150},
+			{FoodId: salt.Id, Grams: 5},
+		},
+	}
+)
+
+func TestLatestPlannedMeals(t *testing.T) {
+	day1 := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+
+	plans := []models.MealPlan{
+		{Id: 2, Days: []models.MealPlanDay{
+			{Day: day2, Meals: []models.PlannedMeal{{Id: 5, Day: day2}}},
+		}},
+		{Id: 1, Days: []models.MealPlanDay{
+			{Day: day1, Meals: []models.PlannedMeal{{Id: 1, Day: day1}, {Id: 2, Day: day1}}},
+			{Day: day2, Meals: []models.PlannedMeal{{Id: 3, Day: day2}}},
+			{Day: day3, Meals: []models.PlannedMeal{{Id: 4, Day: day3}}},
+		}},
+	}
+
+	meals := shopping.LatestPlannedMeals(plans, day1, day2)
+
+	ids := []int64{}
+	for _, meal := range meals {
+		ids = append(ids, meal.Id)
+	}
+
+	// Day 2 of the older plan is replaced by the newer plan, day 3 is out of range
+	require.Equal(t, []int64{5, 1, 2}, ids)
+}
+
+func TestShoppingList(t *testing.T) {
+	meals := []models.PlannedMeal{
+		{FoodId: id(rice.Id), Grams: 250},
+		{FoodId: id(chicken.Id), Grams: 300},
+		{RecipeId: id(pilaf.Id), Servings: 2},
+		// Recipe is gone from the catalog
+		{RecipeId: id(9), Servings: 1},
+		// Food is gone from the catalog
+		{Name: "Cake", Value: 400},
+	}
+
+	recipes := map[int64]models.Recipe{pilaf.Id: pilaf}
+
+	grams := shopping.FoodGrams(meals, recipes, []int64{pilaf.Id})
+	require.Equal(t, map[int64]float64{
+		rice.Id:    250 + 200 + 400,
+		chicken.Id: 300 + 300 + 600,
+		onion.Id:   75 + 150,
+		salt.Id:    2.5 + 5,
+	}, grams)
+
+	categories := shopping.List(
+		grams,
+		[]models.Food{chicken, onion, rice, salt, {Id: 5, Name: "Tea"}},
+		map[int64]bool{onion.Id: true},
+	)
+
+	require.Equal(t, []models.ShoppingCategory{
+		{Category: models.FoodCategoryProduce, Items: []models.ShoppingItem{
+			{FoodId: onion.Id, Name: "Onion", Category: models.FoodCategoryProduce,
+				Grams: 225, Quantity: 225, Unit: "g", Checked: true},
+		}},
+		{Category: models.FoodCategoryMeat, Items: []models.ShoppingItem{
+			{FoodId: chicken.Id, Name: "Chicken breast", Category: models.FoodCategoryMeat,
+				Grams: 1200, Quantity: 1.2, Unit: "kg"},
+		}},
+		{Category: models.FoodCategoryGrains, Items: []models.ShoppingItem{
+			{FoodId: rice.Id, Name: "Rice", Category: models.FoodCategoryGrains,
+				Grams: 850, Quantity: 850, Unit: "g"},
+		}},
+		{Category: models.FoodCategoryOther, Items: []models.ShoppingItem{
+			{FoodId: salt.Id, Name: "Salt", Category: models.FoodCategoryOther,
+				Grams: 7.5, Quantity: 8, Unit: "g"},
+		}},
+	}, categories)
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		grams    float64
+		quantity float64
+		unit     string
+	}{
+		{grams: 0.4, quantity: 1, unit: "g"},
+		{grams: 250, quantity: 250, unit: "g"},
+		{grams: 999.2, quantity: 1, unit: "kg"},
+		{grams: 1000, quantity: 1, unit: "kg"},
+		{grams: 1234, quantity: 1.24, unit: "kg"},
+	}
+
+	for _, tc := range testCases {
+		quantity, unit := shopping.Normalize(tc.grams)
+		require.Equal(t, tc.quantity, quantity, tc.grams)
+		require.Equal(t, tc.unit, unit, tc.grams)
+	}
+}
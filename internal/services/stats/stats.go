@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Stats struct {
+	log             *slog.Logger
+	totalsProvider  DailyTotalsProvider
+	tagsProvider    TagTotalsProvider
+	accountProvider AccountProvider
+}
+
+type DailyTotalsProvider interface {
+	DailyTotalsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.DailyTotal, error)
+}
+
+type TagTotalsProvider interface {
+	TagDailyTotalsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.TagDailyTotal, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+func New(
+	log *slog.Logger,
+	totalsProvider DailyTotalsProvider,
+	tagsProvider TagTotalsProvider,
+	accountProvider AccountProvider,
+) *Stats {
+	return &Stats{
+		log:             log,
+		totalsProvider:  totalsProvider,
+		tagsProvider:    tagsProvider,
+		accountProvider: accountProvider,
+	}
+}
+
+// GetIntakeStatsForCurrentUser aggregates intake for days from..to inclusive,
+// with totals grouped by record tags.
+func (s *Stats) GetIntakeStatsForCurrentUser(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (models.IntakeStats, error) {
+	const op = "services.stats.GetIntakeStatsForCurrentUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	acc, err := s.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get stats - incorrect token")
+		return models.IntakeStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	totals, err := s.totalsProvider.DailyTotalsByAccountIdInRange(ctx, acc.Id, from, to)
+	if err != nil {
+		log.Error("failed to get daily totals", slog.String("err", err.Error()))
+		return models.IntakeStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tagTotals, err := s.tagsProvider.TagDailyTotalsByAccountIdInRange(ctx, acc.Id, from, to)
+	if err != nil {
+		log.Error("failed to get tag totals", slog.String("err", err.Error()))
+		return models.IntakeStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats := IntakeStats(totals, from, to, acc.DailyLimit)
+	stats.Tags = TagStats(stats.Days, tagTotals)
+
+	return stats.In(acc.EnergyUnit), nil
+}
+
+// IntakeStats builds stats with one entry per day from..to,
+// days without records are filled with zero totals.
+func IntakeStats(
+	totals []models.DailyTotal,
+	from time.Time,
+	to time.Time,
+	dailyLimit int,
+) models.IntakeStats {
+	from = truncateDay(from)
+	to = truncateDay(to)
+
+	stats := models.IntakeStats{
+		From:       from,
+		To:         to,
+		DailyLimit: dailyLimit,
+		Days:       []models.DailyTotal{},
+	}
+
+	byDay := make(map[time.Time]models.DailyTotal, len(totals))
+	for _, total := range totals {
+		byDay[truncateDay(total.Day)] = total
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		total, ok := byDay[day]
+		if !ok {
+			total = models.DailyTotal{Day: day}
+		}
+
+		if total.RecordsCount > 0 {
+			stats.DaysLogged++
+			stats.Total += total.Value
+
+			if total.Value <= dailyLimit {
+				stats.DaysOnTarget++
+			}
+		}
+
+		stats.Days = append(stats.Days, total)
+	}
+
+	if stats.DaysLogged > 0 {
+		stats.Average = stats.Total / stats.DaysLogged
+	}
+
+	return stats
+}
+
+// TagStats groups tag totals by tag in the order given. DayAverage averages
+// whole day totals from days over days with tagged records, so eating out
+// days compare with the overall average.
+func TagStats(days []models.DailyTotal, tagTotals []models.TagDailyTotal) []models.TagStats {
+	byDay := make(map[time.Time]int, len(days))
+	for _, day := range days {
+		byDay[truncateDay(day.Day)] = day.Value
+	}
+
+	result := []models.TagStats{}
+	index := make(map[string]int)
+	dayTotals := make(map[string]int)
+
+	for _, total := range tagTotals {
+		i, ok := index[total.Tag]
+		if !ok {
+			i = len(result)
+			index[total.Tag] = i
+			result = append(result, models.TagStats{Tag: total.Tag})
+		}
+
+		result[i].Total += total.Value
+		result[i].RecordsCount += total.RecordsCount
+		result[i].DaysLogged++
+		dayTotals[total.Tag] += byDay[truncateDay(total.Day)]
+	}
+
+	for i := range result {
+		result[i].DayAverage = dayTotals[result[i].Tag] / result[i].DaysLogged
+	}
+
+	return result
+}
+
+// truncateDay maps a date to UTC midnight, daily totals are kept per UTC day.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
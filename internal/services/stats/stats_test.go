@@ -0,0 +1,96 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/stats"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestIntakeStats(t *testing.T) {
+	totals := []models.DailyTotal{
+		{Day: day(2), Value: 1800, RecordsCount: 3},
+		{Day: day(3), Value: 2500, RecordsCount: 4},
+		{Day: day(5), Value: 1500, RecordsCount: 2},
+	}
+
+	result := stats.IntakeStats(totals, day(1), day(5), 2000)
+
+	require.Equal(t, day(1), result.From)
+	require.Equal(t, day(5), result.To)
+	require.Equal(t, 2000, result.DailyLimit)
+	require.Equal(t, 5800, result.Total)
+	require.Equal(t, 1933, result.Average)
+	require.Equal(t, 3, result.DaysLogged)
+	require.Equal(t, 2, result.DaysOnTarget)
+
+	require.Len(t, result.Days, 5)
+	require.Equal(t, models.DailyTotal{Day: day(1)}, result.Days[0])
+	require.Equal(t, totals[1], result.Days[2])
+	require.Equal(t, models.DailyTotal{Day: day(4)}, result.Days[3])
+}
+
+func TestIntakeStatsEmpty(t *testing.T) {
+	result := stats.IntakeStats(nil, day(1), day(1), 2000)
+
+	require.Equal(t, 0, result.Average)
+	require.Equal(t, 0, result.DaysLogged)
+	require.Len(t, result.Days, 1)
+}
+
+func TestIntakeStatsInKilojoules(t *testing.T) {
+	totals := []models.DailyTotal{
+		{Day: day(2), Value: 1800, RecordsCount: 3},
+		{Day: day(3), Value: 2500, RecordsCount: 4},
+	}
+
+	result := stats.IntakeStats(totals, day(2), day(3), 2000).In(models.EnergyUnitKj)
+
+	require.Equal(t, models.EnergyUnitKj, result.EnergyUnit)
+	require.Equal(t, 8368, result.DailyLimit)
+	require.Equal(t, 17991, result.Total)
+	require.Equal(t, 8996, result.Average)
+	require.Equal(t, 1, result.DaysOnTarget)
+	require.Equal(t, 7531, result.Days[0].Value)
+	require.Equal(t, 10460, result.Days[1].Value)
+
+	// Conversion does not touch the source days
+	require.Equal(t, 1800, totals[0].Value)
+
+	require.Equal(t, 2000, models.EnergyUnitKj.ToKcal(8368))
+	require.Equal(t, 2000, models.EnergyUnitKcal.ToKcal(2000))
+	require.Equal(t, models.EnergyUnitKcal, stats.IntakeStats(nil, day(1), day(1), 2000).In("").EnergyUnit)
+}
+
+func TestTagStats(t *testing.T) {
+	days := stats.IntakeStats([]models.DailyTotal{
+		{Day: day(2), Value: 1800, RecordsCount: 3},
+		{Day: day(3), Value: 2600, RecordsCount: 4},
+		{Day: day(4), Value: 3000, RecordsCount: 3},
+	}, day(1), day(4), 2000).Days
+
+	tagTotals := []models.TagDailyTotal{
+		{Tag: "restaurant", Day: day(3), Value: 1200, RecordsCount: 1},
+		{Tag: "restaurant", Day: day(4), Value: 1500, RecordsCount: 2},
+		{Tag: "travel", Day: day(2), Value: 400, RecordsCount: 1},
+	}
+
+	result := stats.TagStats(days, tagTotals)
+
+	require.Equal(t, []models.TagStats{
+		{Tag: "restaurant", Total: 2700, RecordsCount: 3, DaysLogged: 2, DayAverage: 2800},
+		{Tag: "travel", Total: 400, RecordsCount: 1, DaysLogged: 1, DayAverage: 1800},
+	}, result)
+
+	require.Equal(t, []models.TagStats{}, stats.TagStats(days, nil))
+
+	converted := models.IntakeStats{Tags: result}.In(models.EnergyUnitKj)
+	require.Equal(t, 11297, converted.Tags[0].Total)
+	require.Equal(t, 2700, result[0].Total)
+}
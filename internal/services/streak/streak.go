@@ -0,0 +1,317 @@
+package streak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Streak struct {
+	log             *slog.Logger
+	totalsProvider  DailyTotalsProvider
+	streaksProvider StreaksProvider
+	streaksSaver    StreaksSaver
+	accountProvider AccountProvider
+}
+
+type DailyTotalsProvider interface {
+	DailyTotalsByAccountId(ctx context.Context, accountId int64) ([]models.DailyTotal, error)
+	DailyTotalsByAccountIdInRange(
+		ctx context.Context,
+		accountId int64,
+		from time.Time,
+		to time.Time,
+	) ([]models.DailyTotal, error)
+}
+
+type StreaksProvider interface {
+	StreaksByAccountId(ctx context.Context, accountId int64) (models.Streaks, error)
+}
+
+type StreaksSaver interface {
+	SaveStreaks(ctx context.Context, streaks models.Streaks) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+func New(
+	log *slog.Logger,
+	totalsProvider DailyTotalsProvider,
+	streaksProvider StreaksProvider,
+	streaksSaver StreaksSaver,
+	accountProvider AccountProvider,
+) *Streak {
+	return &Streak{
+		log:             log,
+		totalsProvider:  totalsProvider,
+		streaksProvider: streaksProvider,
+		streaksSaver:    streaksSaver,
+		accountProvider: accountProvider,
+	}
+}
+
+func (s *Streak) GetStreaksForCurrentUser(ctx context.Context) (models.Streaks, error) {
+	const op = "services.streak.GetStreaksForCurrentUser"
+
+	log := s.log.With(slog.String("op", op))
+
+	acc, err := s.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get streaks - incorrect token")
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	streaks, err := s.streaksProvider.StreaksByAccountId(ctx, acc.Id)
+	if err != nil {
+		if !errors.Is(err, storage.ErrStreaksNotFound) {
+			log.Error("failed to get streaks", slog.String("err", err.Error()))
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		// Accounts that had records before streaks were introduced
+		// get their snapshot built on first access
+		streaks, err = s.rebuildStreaks(ctx, acc)
+		if err != nil {
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return expire(streaks, today()), nil
+}
+
+// RefreshStreaks updates the stored streaks snapshot after records dated at
+// dates were changed. It is called by the record service after every change
+// of the account records. Days after the last logged one extend or restart
+// the current streaks and a changed last day keeps them unless it moves on
+// or off target, other changes rebuild the snapshot from all daily totals.
+func (s *Streak) RefreshStreaks(
+	ctx context.Context,
+	acc models.Account,
+	dates ...time.Time,
+) (models.Streaks, error) {
+	const op = "services.streak.RefreshStreaks"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("account_id", acc.Id))
+
+	streaks, err := s.streaksProvider.StreaksByAccountId(ctx, acc.Id)
+	if err != nil {
+		if !errors.Is(err, storage.ErrStreaksNotFound) {
+			log.Error("failed to get streaks", slog.String("err", err.Error()))
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		streaks, err = s.rebuildStreaks(ctx, acc)
+		if err != nil {
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return streaks, nil
+	}
+
+	days := recordDays(dates)
+	if len(days) == 0 {
+		return streaks, nil
+	}
+
+	totals, err := s.totalsProvider.DailyTotalsByAccountIdInRange(ctx, acc.Id, days[0], days[len(days)-1])
+	if err != nil {
+		log.Error("failed to get daily totals", slog.String("err", err.Error()))
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, ok := Update(streaks, days, totals, acc.DailyLimit)
+	if !ok {
+		streaks, err = s.rebuildStreaks(ctx, acc)
+		if err != nil {
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return streaks, nil
+	}
+
+	updated.AccountId = acc.Id
+
+	if err := s.streaksSaver.SaveStreaks(ctx, updated); err != nil {
+		log.Error("failed to save streaks", slog.String("err", err.Error()))
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return updated, nil
+}
+
+// rebuildStreaks saves the streaks snapshot computed from all daily totals.
+func (s *Streak) rebuildStreaks(ctx context.Context, acc models.Account) (models.Streaks, error) {
+	const op = "services.streak.rebuildStreaks"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("account_id", acc.Id))
+
+	totals, err := s.totalsProvider.DailyTotalsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get daily totals", slog.String("err", err.Error()))
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	streaks := Compute(totals, acc.DailyLimit)
+	streaks.AccountId = acc.Id
+
+	if err := s.streaksSaver.SaveStreaks(ctx, streaks); err != nil {
+		log.Error("failed to save streaks", slog.String("err", err.Error()))
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return streaks, nil
+}
+
+// recordDays returns days of daily totals records dated at dates count to,
+// sorted and each day once.
+func recordDays(dates []time.Time) []time.Time {
+	days := make([]time.Time, 0, len(dates))
+
+	for _, date := range dates {
+		days = append(days, models.RecordDay(date))
+	}
+
+	slices.SortFunc(days, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	return slices.CompactFunc(days, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+}
+
+// Compute calculates logging and on-target streaks from daily totals sorted by day.
+// Current streaks are the runs ending at the last logged day,
+// use expire to drop them once they are no longer continued.
+func Compute(totals []models.DailyTotal, dailyLimit int) models.Streaks {
+	var (
+		streaks  models.Streaks
+		prevDay  time.Time
+		logging  int
+		onTarget int
+	)
+
+	for i, total := range totals {
+		day := total.Day
+
+		if i > 0 && day.Equal(prevDay.AddDate(0, 0, 1)) {
+			logging++
+		} else {
+			logging = 1
+			onTarget = 0
+		}
+
+		if total.Value <= dailyLimit {
+			onTarget++
+			streaks.OnTarget.LastDay = &day
+		} else {
+			onTarget = 0
+		}
+
+		streaks.Logging.Longest = max(streaks.Logging.Longest, logging)
+		streaks.OnTarget.Longest = max(streaks.OnTarget.Longest, onTarget)
+
+		prevDay = day
+	}
+
+	if len(totals) > 0 {
+		lastDay := totals[len(totals)-1].Day
+		streaks.Logging.LastDay = &lastDay
+	}
+
+	streaks.Logging.Current = logging
+	streaks.OnTarget.Current = onTarget
+
+	return streaks
+}
+
+// Update applies changed days, sorted and each once, to streaks made by
+// Compute. totals are daily totals of the changed days range. It reports
+// false when the change needs the whole history: a past day changed or
+// the last logged day was removed or moved on or off target.
+func Update(
+	streaks models.Streaks,
+	days []time.Time,
+	totals []models.DailyTotal,
+	dailyLimit int,
+) (models.Streaks, bool) {
+	totalsByDay := make(map[time.Time]models.DailyTotal, len(totals))
+	for _, total := range totals {
+		totalsByDay[models.RecordDay(total.Day)] = total
+	}
+
+	for _, day := range days {
+		total, logged := totalsByDay[day]
+		lastDay := streaks.Logging.LastDay
+
+		switch {
+		case lastDay == nil || day.After(*lastDay):
+			if logged {
+				streaks = extend(streaks, total, dailyLimit)
+			}
+		case day.Equal(*lastDay):
+			wasOnTarget := streaks.OnTarget.LastDay != nil && streaks.OnTarget.LastDay.Equal(day)
+			if !logged || (total.Value <= dailyLimit) != wasOnTarget {
+				return streaks, false
+			}
+		default:
+			return streaks, false
+		}
+	}
+
+	return streaks, true
+}
+
+// extend adds the total of a day after the last logged one to streaks,
+// the same way Compute adds the next total.
+func extend(streaks models.Streaks, total models.DailyTotal, dailyLimit int) models.Streaks {
+	day := models.RecordDay(total.Day)
+
+	if lastDay := streaks.Logging.LastDay; lastDay != nil && day.Equal(lastDay.AddDate(0, 0, 1)) {
+		streaks.Logging.Current++
+	} else {
+		streaks.Logging.Current = 1
+		streaks.OnTarget.Current = 0
+	}
+
+	if total.Value <= dailyLimit {
+		streaks.OnTarget.Current++
+		streaks.OnTarget.LastDay = &day
+	} else {
+		streaks.OnTarget.Current = 0
+	}
+
+	streaks.Logging.Longest = max(streaks.Logging.Longest, streaks.Logging.Current)
+	streaks.OnTarget.Longest = max(streaks.OnTarget.Longest, streaks.OnTarget.Current)
+	streaks.Logging.LastDay = &day
+
+	return streaks
+}
+
+// expire resets current streaks which were not continued yesterday or today.
+func expire(streaks models.Streaks, today time.Time) models.Streaks {
+	yesterday := today.AddDate(0, 0, -1)
+
+	if streaks.Logging.LastDay == nil || streaks.Logging.LastDay.Before(yesterday) {
+		streaks.Logging.Current = 0
+	}
+
+	if streaks.OnTarget.LastDay == nil || streaks.OnTarget.LastDay.Before(yesterday) {
+		streaks.OnTarget.Current = 0
+	}
+
+	return streaks
+}
+
+// today is the day of daily totals records dated now count to.
+func today() time.Time {
+	return models.RecordDay(time.Now())
+}
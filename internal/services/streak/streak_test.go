@@ -0,0 +1,200 @@
+package streak_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/services/streak"
+	"github.com/stretchr/testify/require"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 4, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCompute(t *testing.T) {
+	const dailyLimit = 2000
+
+	testCases := []struct {
+		name             string
+		totals           []models.DailyTotal
+		expectedLogging  [2]int
+		expectedOnTarget [2]int
+	}{
+		{
+			name:             "no records",
+			totals:           nil,
+			expectedLogging:  [2]int{0, 0},
+			expectedOnTarget: [2]int{0, 0},
+		},
+		{
+			name: "consecutive days within limit",
+			totals: []models.DailyTotal{
+				{Day: day(1), Value: 1500},
+				{Day: day(2), Value: 2000},
+				{Day: day(3), Value: 1800},
+			},
+			expectedLogging:  [2]int{3, 3},
+			expectedOnTarget: [2]int{3, 3},
+		},
+		{
+			name: "gap breaks both streaks",
+			totals: []models.DailyTotal{
+				{Day: day(1), Value: 1500},
+				{Day: day(2), Value: 1500},
+				{Day: day(3), Value: 1500},
+				{Day: day(5), Value: 1500},
+			},
+			expectedLogging:  [2]int{1, 3},
+			expectedOnTarget: [2]int{1, 3},
+		},
+		{
+			name: "over limit day breaks only on-target streak",
+			totals: []models.DailyTotal{
+				{Day: day(1), Value: 1500},
+				{Day: day(2), Value: 1500},
+				{Day: day(3), Value: 2500},
+				{Day: day(4), Value: 1500},
+			},
+			expectedLogging:  [2]int{4, 4},
+			expectedOnTarget: [2]int{1, 2},
+		},
+		{
+			name: "last day over limit",
+			totals: []models.DailyTotal{
+				{Day: day(1), Value: 1500},
+				{Day: day(2), Value: 2500},
+			},
+			expectedLogging:  [2]int{2, 2},
+			expectedOnTarget: [2]int{0, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			result := streak.Compute(tc.totals, dailyLimit)
+
+			require.Equal(t, tc.expectedLogging, [2]int{result.Logging.Current, result.Logging.Longest})
+			require.Equal(t, tc.expectedOnTarget, [2]int{result.OnTarget.Current, result.OnTarget.Longest})
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	const dailyLimit = 2000
+
+	history := []models.DailyTotal{
+		{Day: day(1), Value: 1500},
+		{Day: day(2), Value: 2500},
+		{Day: day(3), Value: 1500},
+		{Day: day(4), Value: 1800},
+	}
+
+	testCases := []struct {
+		name       string
+		changed    []models.DailyTotal
+		days       []time.Time
+		expectedOk bool
+	}{
+		{
+			name:       "next day on target",
+			changed:    append(history[:4:4], models.DailyTotal{Day: day(5), Value: 1500}),
+			days:       []time.Time{day(5)},
+			expectedOk: true,
+		},
+		{
+			name:       "next day over limit",
+			changed:    append(history[:4:4], models.DailyTotal{Day: day(5), Value: 2500}),
+			days:       []time.Time{day(5)},
+			expectedOk: true,
+		},
+		{
+			name: "next days after a gap",
+			changed: append(
+				history[:4:4],
+				models.DailyTotal{Day: day(7), Value: 1500},
+				models.DailyTotal{Day: day(8), Value: 1900},
+			),
+			days:       []time.Time{day(7), day(8)},
+			expectedOk: true,
+		},
+		{
+			name: "last day still on target",
+			changed: []models.DailyTotal{
+				history[0], history[1], history[2],
+				{Day: day(4), Value: 1900},
+			},
+			days:       []time.Time{day(4)},
+			expectedOk: true,
+		},
+		{
+			name:       "removed day after the last one",
+			changed:    history,
+			days:       []time.Time{day(6)},
+			expectedOk: true,
+		},
+		{
+			name: "last day over limit",
+			changed: []models.DailyTotal{
+				history[0], history[1], history[2],
+				{Day: day(4), Value: 2100},
+			},
+			days:       []time.Time{day(4)},
+			expectedOk: false,
+		},
+		{
+			name:       "last day removed",
+			changed:    history[:3],
+			days:       []time.Time{day(4)},
+			expectedOk: false,
+		},
+		{
+			name: "past day changed",
+			changed: []models.DailyTotal{
+				history[0],
+				{Day: day(2), Value: 1500},
+				history[2], history[3],
+			},
+			days:       []time.Time{day(2)},
+			expectedOk: false,
+		},
+	}
+
+	for _, tc := range testCases {
+
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+
+			t.Parallel()
+
+			var inRange []models.DailyTotal
+			for _, total := range tc.changed {
+				if !total.Day.Before(tc.days[0]) && !total.Day.After(tc.days[len(tc.days)-1]) {
+					inRange = append(inRange, total)
+				}
+			}
+
+			result, ok := streak.Update(streak.Compute(history, dailyLimit), tc.days, inRange, dailyLimit)
+
+			require.Equal(t, tc.expectedOk, ok)
+			if ok {
+				require.Equal(t, streak.Compute(tc.changed, dailyLimit), result)
+			}
+		})
+	}
+}
+
+func TestUpdateFirstDay(t *testing.T) {
+	total := models.DailyTotal{Day: day(1), Value: 1500}
+
+	result, ok := streak.Update(models.Streaks{}, []time.Time{day(1)}, []models.DailyTotal{total}, 2000)
+
+	require.True(t, ok)
+	require.Equal(t, streak.Compute([]models.DailyTotal{total}, 2000), result)
+}
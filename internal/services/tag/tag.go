@@ -0,0 +1,228 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Tag struct {
+	log             *slog.Logger
+	tagProvider     TagProvider
+	tagSaver        TagSaver
+	tagRemover      TagRemover
+	accountProvider AccountProvider
+}
+
+type TagProvider interface {
+	TagsByAccountId(ctx context.Context, accountId int64) ([]models.Tag, error)
+}
+
+type TagSaver interface {
+	SaveTag(ctx context.Context, tag models.Tag) (int64, error)
+	UpdateTag(ctx context.Context, tag models.Tag) error
+}
+
+type TagRemover interface {
+	DeleteTag(ctx context.Context, accountId int64, tagId int64) error
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+const (
+	MaxNameLength = 32
+	// MaxRecordTags limits tags of a single record
+	MaxRecordTags = 10
+)
+
+// nameRe allows words joined by single spaces, dashes or underscores,
+// e.g. "cheat-day" or "eating out".
+var nameRe = regexp.MustCompile(`^[\p{L}\p{N}]+(?:[ _-][\p{L}\p{N}]+)*$`)
+
+var (
+	ErrInvalidTagName = errors.New("invalid tag name")
+	ErrTooManyTags    = errors.New("too many tags")
+	ErrTagExists      = errors.New("tag exists")
+	ErrTagNotFound    = errors.New("tag not found")
+)
+
+func New(
+	log *slog.Logger,
+	tagProvider TagProvider,
+	tagSaver TagSaver,
+	tagRemover TagRemover,
+	accountProvider AccountProvider,
+) *Tag {
+	return &Tag{
+		log:             log,
+		tagProvider:     tagProvider,
+		tagSaver:        tagSaver,
+		tagRemover:      tagRemover,
+		accountProvider: accountProvider,
+	}
+}
+
+func (t *Tag) GetTagsForCurrentUser(ctx context.Context) ([]models.Tag, error) {
+	const op = "services.tag.GetTagsForCurrentUser"
+
+	log := t.log.With(slog.String("op", op))
+
+	acc, err := t.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get tags - incorrect token")
+		return []models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tags, err := t.tagProvider.TagsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get tags", slog.String("err", err.Error()))
+		return []models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tags) == 0 {
+		tags = []models.Tag{}
+	}
+
+	return tags, nil
+}
+
+func (t *Tag) CreateTagForCurrentUser(ctx context.Context, name string) (models.Tag, error) {
+	const op = "services.tag.CreateTagForCurrentUser"
+
+	log := t.log.With(slog.String("op", op))
+
+	acc, err := t.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not create tag - incorrect token")
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag := models.Tag{AccountId: acc.Id}
+
+	tag.Name, err = NormalizeName(name)
+	if err != nil {
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag.Id, err = t.tagSaver.SaveTag(ctx, tag)
+	if err != nil {
+		if errors.Is(err, storage.ErrTagExists) {
+			return models.Tag{}, fmt.Errorf("%s: %w", op, ErrTagExists)
+		}
+
+		log.Error("failed to save tag", slog.String("err", err.Error()))
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
+
+// RenameTagForCurrentUser renames the tag on all tagged records.
+func (t *Tag) RenameTagForCurrentUser(
+	ctx context.Context,
+	tagId int64,
+	name string,
+) (models.Tag, error) {
+	const op = "services.tag.RenameTagForCurrentUser"
+
+	log := t.log.With(slog.String("op", op))
+
+	acc, err := t.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not rename tag - incorrect token")
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag := models.Tag{Id: tagId, AccountId: acc.Id}
+
+	tag.Name, err = NormalizeName(name)
+	if err != nil {
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := t.tagSaver.UpdateTag(ctx, tag); err != nil {
+		if errors.Is(err, storage.ErrTagExists) {
+			return models.Tag{}, fmt.Errorf("%s: %w", op, ErrTagExists)
+		}
+
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return models.Tag{}, fmt.Errorf("%s: %w", op, ErrTagNotFound)
+		}
+
+		log.Error("failed to update tag", slog.String("err", err.Error()))
+		return models.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag, nil
+}
+
+// DeleteTagForCurrentUser deletes the tag, tagged records are kept.
+func (t *Tag) DeleteTagForCurrentUser(ctx context.Context, tagId int64) error {
+	const op = "services.tag.DeleteTagForCurrentUser"
+
+	log := t.log.With(slog.String("op", op))
+
+	acc, err := t.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not delete tag - incorrect token")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := t.tagRemover.DeleteTag(ctx, acc.Id, tagId); err != nil {
+		if errors.Is(err, storage.ErrTagNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrTagNotFound)
+		}
+
+		log.Error("failed to delete tag", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// NormalizeName lowercases the name and collapses spaces,
+// names are matched case-insensitively.
+func NormalizeName(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+
+	if len([]rune(name)) > MaxNameLength || !nameRe.MatchString(name) {
+		return "", ErrInvalidTagName
+	}
+
+	return name, nil
+}
+
+// NormalizeNames normalizes, deduplicates and sorts record tag names.
+func NormalizeNames(names []string) ([]string, error) {
+	unique := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		normalized, err := NormalizeName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		unique[normalized] = true
+	}
+
+	if len(unique) > MaxRecordTags {
+		return nil, ErrTooManyTags
+	}
+
+	result := make([]string, 0, len(unique))
+	for name := range unique {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
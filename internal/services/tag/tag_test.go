@@ -0,0 +1,59 @@
+package tag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/services/tag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "lowercase", input: "Restaurant", expected: "restaurant"},
+		{name: "dash", input: "cheat-day", expected: "cheat-day"},
+		{name: "spaces collapsed", input: "  Eating   Out ", expected: "eating out"},
+		{name: "unicode", input: "Путешествие", expected: "путешествие"},
+		{name: "digits", input: "trip 2024", expected: "trip 2024"},
+		{name: "empty", input: "   ", expectedErr: tag.ErrInvalidTagName},
+		{name: "punctuation", input: "eating out!", expectedErr: tag.ErrInvalidTagName},
+		{name: "leading dash", input: "-day", expectedErr: tag.ErrInvalidTagName},
+		{name: "double dash", input: "cheat--day", expectedErr: tag.ErrInvalidTagName},
+		{name: "too long", input: strings.Repeat("a", 33), expectedErr: tag.ErrInvalidTagName},
+		{name: "max length", input: strings.Repeat("я", 32), expected: strings.Repeat("я", 32)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := tag.NormalizeName(tc.input)
+
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	names, err := tag.NormalizeNames([]string{"Travel", "restaurant", "travel"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"restaurant", "travel"}, names)
+
+	names, err = tag.NormalizeNames(nil)
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	_, err = tag.NormalizeNames([]string{"ok", "not ok!"})
+	require.ErrorIs(t, err, tag.ErrInvalidTagName)
+
+	_, err = tag.NormalizeNames(strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","))
+	require.ErrorIs(t, err, tag.ErrTooManyTags)
+
+	// Duplicates count once towards the limit
+	_, err = tag.NormalizeNames(strings.Split("a,b,c,d,e,f,g,h,i,j,J", ","))
+	require.NoError(t, err)
+}
@@ -0,0 +1,131 @@
+package weight
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+)
+
+type Weight struct {
+	log                *slog.Logger
+	weightProvider     WeightProvider
+	weightSaver        WeightSaver
+	accountProvider    AccountProvider
+	achievementHandler AchievementHandler
+}
+
+type WeightProvider interface {
+	WeightsByAccountId(ctx context.Context, accountId int64) ([]models.Weight, error)
+}
+
+type WeightSaver interface {
+	SaveWeight(
+		ctx context.Context,
+		accountId int64,
+		value float64,
+		dateRecord time.Time,
+	) (int64, error)
+}
+
+type AccountProvider interface {
+	GetAccountByContextJWT(ctx context.Context) (models.Account, error)
+}
+
+type AchievementHandler interface {
+	HandleEvents(ctx context.Context, accountId int64, events ...models.AchievementEvent) error
+}
+
+func New(
+	log *slog.Logger,
+	weightProvider WeightProvider,
+	weightSaver WeightSaver,
+	accountProvider AccountProvider,
+	achievementHandler AchievementHandler,
+) *Weight {
+	return &Weight{
+		log:                log,
+		weightProvider:     weightProvider,
+		weightSaver:        weightSaver,
+		accountProvider:    accountProvider,
+		achievementHandler: achievementHandler,
+	}
+}
+
+func (w *Weight) GetWeightsForCurrentUser(ctx context.Context) ([]models.Weight, error) {
+	const op = "services.weight.GetWeightsForCurrentUser"
+
+	log := w.log.With(slog.String("op", op))
+
+	acc, err := w.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not get weights - incorrect token")
+		return []models.Weight{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	weights, err := w.weightProvider.WeightsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("can not get weights", slog.String("err", err.Error()))
+		return []models.Weight{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(weights) == 0 {
+		weights = []models.Weight{}
+	}
+
+	return weights, nil
+}
+
+func (w *Weight) CreateWeightForCurrentUser(
+	ctx context.Context,
+	dateRecord time.Time,
+	value float64,
+) error {
+	const op = "services.weight.CreateWeightForCurrentUser"
+
+	log := w.log.With(slog.String("op", op))
+
+	acc, err := w.accountProvider.GetAccountByContextJWT(ctx)
+	if err != nil {
+		log.Error("can not create weight - incorrect token")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := w.weightSaver.SaveWeight(ctx, acc.Id, value, dateRecord); err != nil {
+		log.Error("failed to save weight", slog.String("err", err.Error()))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	w.handleMilestone(ctx, log, acc)
+
+	return nil
+}
+
+// handleMilestone reports whole kilograms lost since the first weigh-in.
+func (w *Weight) handleMilestone(ctx context.Context, log *slog.Logger, acc models.Account) {
+	weights, err := w.weightProvider.WeightsByAccountId(ctx, acc.Id)
+	if err != nil {
+		log.Error("failed to get weights", slog.String("err", err.Error()))
+		return
+	}
+
+	if len(weights) < 2 {
+		return
+	}
+
+	lost := int(weights[0].Value - weights[len(weights)-1].Value)
+	if lost <= 0 {
+		return
+	}
+
+	err = w.achievementHandler.HandleEvents(
+		ctx,
+		acc.Id,
+		models.AchievementEvent{Type: models.EventWeightMilestone, Value: lost},
+	)
+	if err != nil {
+		log.Error("failed to handle achievements", slog.String("err", err.Error()))
+	}
+}
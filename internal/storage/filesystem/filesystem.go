@@ -0,0 +1,110 @@
+// Package filesystem is a blob store keeping every blob as a file
+// under the root directory, keys are slash separated relative paths.
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+)
+
+type Storage struct {
+	root string
+}
+
+func New(root string) (*Storage, error) {
+	const op = "storage.filesystem.New"
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{root: root}, nil
+}
+
+// SaveBlob writes to a temporary file renamed into place,
+// so readers never see a partially written blob.
+func (s *Storage) SaveBlob(ctx context.Context, key string, r io.Reader) error {
+	const op = "storage.filesystem.SaveBlob"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Blob(ctx context.Context, key string) (io.ReadCloser, error) {
+	const op = "storage.filesystem.Blob"
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrBlobNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return f, nil
+}
+
+// DeleteBlob is a no-op for missing blobs.
+func (s *Storage) DeleteBlob(ctx context.Context, key string) error {
+	const op = "storage.filesystem.DeleteBlob"
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// path rejects keys escaping the root directory or naming the root itself.
+func (s *Storage) path(key string) (string, error) {
+	local := filepath.FromSlash(key)
+	if !filepath.IsLocal(local) || filepath.Clean(local) == "." {
+		return "", storage.ErrInvalidBlobKey
+	}
+
+	return filepath.Join(s.root, local), nil
+}
@@ -0,0 +1,140 @@
+package filesystem_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStorage returns a storage rooted in a temp dir, next to a file
+// named outside that escaping keys would reach.
+func newStorage(t *testing.T) (*filesystem.Storage, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	root := filepath.Join(dir, "blobs")
+
+	outside := filepath.Join(dir, "outside")
+	require.NoError(t, os.WriteFile(outside, []byte("secret"), 0o600))
+
+	s, err := filesystem.New(root)
+	require.NoError(t, err)
+
+	return s, root
+}
+
+func readBlob(t *testing.T, s *filesystem.Storage, key string) string {
+	t.Helper()
+
+	rc, err := s.Blob(context.Background(), key)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestBlobKeys(t *testing.T) {
+	cases := []struct {
+		name    string
+		key     string
+		invalid bool
+	}{
+		{name: "nested", key: "photos/1/2.jpg"},
+		{name: "plain", key: "blob"},
+		{name: "dot segment inside root", key: "photos/../blob"},
+		{name: "empty", key: "", invalid: true},
+		{name: "parent", key: "../outside", invalid: true},
+		{name: "parent after segment", key: "photos/../../outside", invalid: true},
+		{name: "absolute", key: "/etc/passwd", invalid: true},
+		{name: "dot", key: ".", invalid: true},
+		{name: "root after segment", key: "photos/..", invalid: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, root := newStorage(t)
+
+			err := s.SaveBlob(ctx, tc.key, strings.NewReader("data"))
+			if !tc.invalid {
+				require.NoError(t, err)
+				assert.Equal(t, "data", readBlob(t, s, tc.key))
+
+				_, err = os.Stat(filepath.Join(root, filepath.FromSlash(tc.key)))
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, storage.ErrInvalidBlobKey)
+
+			_, err = s.Blob(ctx, tc.key)
+			assert.ErrorIs(t, err, storage.ErrInvalidBlobKey)
+
+			assert.ErrorIs(t, s.DeleteBlob(ctx, tc.key), storage.ErrInvalidBlobKey)
+
+			data, err := os.ReadFile(filepath.Join(filepath.Dir(root), "outside"))
+			require.NoError(t, err)
+			assert.Equal(t, "secret", string(data))
+		})
+	}
+}
+
+func TestBlobLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, root := newStorage(t)
+
+	const key = "photos/1/2.jpg"
+
+	_, err := s.Blob(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+
+	require.NoError(t, s.SaveBlob(ctx, key, strings.NewReader("first")))
+	assert.Equal(t, "first", readBlob(t, s, key))
+
+	require.NoError(t, s.SaveBlob(ctx, key, strings.NewReader("second")))
+	assert.Equal(t, "second", readBlob(t, s, key))
+
+	entries, err := os.ReadDir(filepath.Join(root, "photos", "1"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary upload files are left behind")
+	assert.Equal(t, "2.jpg", entries[0].Name())
+
+	require.NoError(t, s.DeleteBlob(ctx, key))
+
+	_, err = s.Blob(ctx, key)
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+
+	assert.NoError(t, s.DeleteBlob(ctx, key), "deleting a missing blob")
+}
+
+func TestSaveBlobReadError(t *testing.T) {
+	ctx := context.Background()
+	s, root := newStorage(t)
+
+	r := io.MultiReader(strings.NewReader("partial"), errReader{})
+	require.Error(t, s.SaveBlob(ctx, "blob", r))
+
+	_, err := s.Blob(ctx, "blob")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary upload files are left behind")
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
@@ -3,11 +3,15 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/lib/uuid"
 	"github.com/karmaplush/simple-diet-tracker/internal/storage"
 	"github.com/mattn/go-sqlite3"
 )
@@ -64,17 +68,18 @@ func (s *Storage) SaveAccount(
 func (s *Storage) AccountById(ctx context.Context, accountID int64) (models.Account, error) {
 	const op = "storage.sqlite.AccountById"
 
-	stmt, err := s.db.Prepare("SELECT id, user_id, daily_limit FROM accounts WHERE id = ?")
+	stmt, err := s.db.Prepare(`
+		SELECT id, user_id, daily_limit, sex, birth_date, energy_unit,
+			carbs_target, protein_target, fat_target, version
+		FROM accounts
+		WHERE id = ?
+	`)
 	if err != nil {
 		return models.Account{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	row := stmt.QueryRowContext(ctx, accountID)
-
-	var account models.Account
-
-	err = row.Scan(&account.Id, &account.UserId, &account.DailyLimit)
+	account, err := scanAccount(stmt.QueryRowContext(ctx, accountID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Account{}, fmt.Errorf("%s: %w", op, storage.ErrAccountNotFound)
@@ -88,17 +93,18 @@ func (s *Storage) AccountById(ctx context.Context, accountID int64) (models.Acco
 func (s *Storage) AccountByUserId(ctx context.Context, userId int64) (models.Account, error) {
 	const op = "storage.sqlite.AccountByUserId"
 
-	stmt, err := s.db.Prepare("SELECT id, user_id, daily_limit FROM accounts WHERE user_id = ?")
+	stmt, err := s.db.Prepare(`
+		SELECT id, user_id, daily_limit, sex, birth_date, energy_unit,
+			carbs_target, protein_target, fat_target, version
+		FROM accounts
+		WHERE user_id = ?
+	`)
 	if err != nil {
 		return models.Account{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	row := stmt.QueryRowContext(ctx, userId)
-
-	var account models.Account
-
-	err = row.Scan(&account.Id, &account.UserId, &account.DailyLimit)
+	account, err := scanAccount(stmt.QueryRowContext(ctx, userId))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Account{}, fmt.Errorf("%s: %w", op, storage.ErrAccountNotFound)
@@ -109,41 +115,319 @@ func (s *Storage) AccountByUserId(ctx context.Context, userId int64) (models.Acc
 	return account, nil
 }
 
-func (s *Storage) SaveRecord(
+func scanAccount(row scanner) (models.Account, error) {
+	var (
+		account   models.Account
+		birthDate sql.NullTime
+	)
+
+	err := row.Scan(
+		&account.Id,
+		&account.UserId,
+		&account.DailyLimit,
+		&account.Sex,
+		&birthDate,
+		&account.EnergyUnit,
+		&account.MacroTargets.Carbs,
+		&account.MacroTargets.Protein,
+		&account.MacroTargets.Fat,
+		&account.Version,
+	)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	if birthDate.Valid {
+		account.BirthDate = &birthDate.Time
+	}
+
+	return account, nil
+}
+
+// UpdateAccountProfile updates the account of the version and bumps it.
+func (s *Storage) UpdateAccountProfile(
 	ctx context.Context,
 	accountId int64,
-	value int,
-	dateRecord time.Time,
+	version int64,
+	profile models.Profile,
+) error {
+	const op = "storage.sqlite.UpdateAccountProfile"
+
+	stmt, err := s.db.Prepare(`
+		UPDATE accounts SET sex = ?, birth_date = ?, version = version + 1
+		WHERE id = ? AND version = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(
+		ctx,
+		profile.Sex,
+		profile.BirthDate.Format(time.DateOnly),
+		accountId,
+		version,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, s.accountUpdateError(ctx, accountId))
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateAccountEnergyUnit(
+	ctx context.Context,
+	accountId int64,
+	version int64,
+	unit models.EnergyUnit,
+) error {
+	const op = "storage.sqlite.UpdateAccountEnergyUnit"
+
+	stmt, err := s.db.Prepare(`
+		UPDATE accounts SET energy_unit = ?, version = version + 1
+		WHERE id = ? AND version = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, unit, accountId, version)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, s.accountUpdateError(ctx, accountId))
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateAccountMacroTargets(
+	ctx context.Context,
+	accountId int64,
+	version int64,
+	targets models.MacroTargets,
+) error {
+	const op = "storage.sqlite.UpdateAccountMacroTargets"
+
+	stmt, err := s.db.Prepare(`
+		UPDATE accounts
+		SET carbs_target = ?, protein_target = ?, fat_target = ?, version = version + 1
+		WHERE id = ? AND version = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(
+		ctx,
+		targets.Carbs,
+		targets.Protein,
+		targets.Fat,
+		accountId,
+		version,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, s.accountUpdateError(ctx, accountId))
+	}
+
+	return nil
+}
+
+// accountUpdateError tells a missing account from a stale version
+// after a versioned update affected no rows.
+func (s *Storage) accountUpdateError(ctx context.Context, accountId int64) error {
+	var exists int
+
+	err := s.db.QueryRowContext(ctx, "SELECT 1 FROM accounts WHERE id = ?", accountId).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrAccountNotFound
+		}
+		return err
+	}
+
+	return storage.ErrVersionMismatch
+}
+
+// SaveRecord saves the record with its revision, the revision gets
+// the record id.
+func (s *Storage) SaveRecord(
+	ctx context.Context,
+	record models.Record,
+	revision models.RecordRevision,
 ) (int64, error) {
 	const op = "storage.sqlite.SaveRecord"
 
-	stmt, err := s.db.Prepare(
-		"INSERT INTO records(account_id, value, date_record, date_created) VALUES (?, ?, ?, ?)",
-	)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	res, err := stmt.ExecContext(ctx, accountId, value, dateRecord, time.Now())
+	id, err := insertRecord(ctx, tx, record)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revision.RecordId = id
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// insertRecord saves the record with nutrients and tags, indexes it and
+// adds it to the daily total. Records without uuid get a random one.
+func insertRecord(ctx context.Context, tx *sql.Tx, record models.Record) (int64, error) {
+	if record.Uuid == "" {
+		record.Uuid = uuid.New()
+	}
+
+	fieldTimes, err := recordFieldTimes(record)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO records(
+			uuid, account_id, value, meal, description, food_id, grams, recipe_id, servings,
+			date_record, date_created, field_times
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.Uuid,
+		record.AccountId,
+		record.Value,
+		record.Meal,
+		record.Description,
+		record.FoodId,
+		nullableAmount(record.Grams),
+		record.RecipeId,
+		nullableAmount(record.Servings),
+		record.DateRecord,
+		time.Now(),
+		fieldTimes,
+	)
 	if err != nil {
 		var sqliteErr sqlite3.Error
 
 		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
-			return 0, fmt.Errorf("%s: %w", op, storage.ErrAccountExists)
+			return 0, storage.ErrRecordExists
 		}
 
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return 0, err
 	}
 
 	id, err := res.LastInsertId()
 	if err != nil {
-		return 0, fmt.Errorf("%s: %w", op, err)
+		return 0, err
+	}
+
+	if err := saveNutrients(ctx, tx, "record_nutrients", "record_id", id, record.Nutrients); err != nil {
+		return 0, err
+	}
+
+	if err := saveRecordTags(ctx, tx, record.AccountId, id, record.Tags); err != nil {
+		return 0, err
+	}
+
+	if err := indexRecords(ctx, tx, []int64{id}); err != nil {
+		return 0, err
+	}
+
+	if err := addToDailyTotal(ctx, tx, record.AccountId, record.DateRecord, record.Value, 1); err != nil {
+		return 0, err
+	}
+
+	if err := touchRecords(ctx, tx, record.AccountId, []int64{id}); err != nil {
+		return 0, err
 	}
 
 	return id, nil
 }
 
+// recordFieldTimes encodes times the record fields were changed at, fields
+// changed through the API without times are stamped with the current time.
+func recordFieldTimes(record models.Record) (string, error) {
+	fieldTimes := record.FieldTimes
+
+	if fieldTimes == nil {
+		now := time.Now()
+
+		fieldTimes = make(models.RecordFieldTimes, len(models.RecordFields))
+		for _, field := range models.RecordFields {
+			fieldTimes[field] = now
+		}
+	}
+
+	data, err := json.Marshal(fieldTimes)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// touchRecords moves the changed records of the account to the end of its
+// change sequence, clients sync records changed after their last seq.
+func touchRecords(ctx context.Context, tx *sql.Tx, accountId int64, recordIds []int64) error {
+	for _, recordId := range recordIds {
+		_, err := tx.ExecContext(
+			ctx,
+			"UPDATE accounts SET sync_seq = sync_seq + 1 WHERE id = ?",
+			accountId,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE records SET seq = (SELECT sync_seq FROM accounts WHERE id = ?)
+			WHERE account_id = ? AND id = ?
+		`,
+			accountId, accountId, recordId,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Storage) RecordById(
 	ctx context.Context,
 	recordId int64,
@@ -151,23 +435,14 @@ func (s *Storage) RecordById(
 	const op = "storage.sqlite.RecordById"
 
 	stmt, err := s.db.Prepare(
-		"SELECT id, account_id, value, date_record, date_created FROM records WHERE record_id = ?",
+		"SELECT " + recordColumns + " FROM records WHERE id = ? AND deleted_at IS NULL",
 	)
 	if err != nil {
 		return models.Record{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer stmt.Close()
 
-	row := stmt.QueryRowContext(ctx, recordId)
-
-	var record models.Record
-
-	err = row.Scan(
-		&record.Id,
-		&record.AccountId,
-		&record.Value,
-		&record.DateRecord,
-		&record.DateCreated,
-	)
+	record, err := scanRecord(stmt.QueryRowContext(ctx, recordId))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Record{}, fmt.Errorf("%s: %w", op, storage.ErrRecordNotFound)
@@ -175,31 +450,58 @@ func (s *Storage) RecordById(
 		return models.Record{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return record, nil
+	records := []models.Record{record}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records[0], nil
 
 }
 
+// RecordsByUserId returns records of the day, with tags only records
+// having any of them.
 func (s *Storage) RecordsByUserId(
 	ctx context.Context,
 	userId int64,
 	date time.Time,
+	tags []string,
 ) ([]models.Record, error) {
 	const op = "storage.sqlite.RecordsByUserId"
 
-	stmt, err := s.db.Prepare(`
-		SELECT records.id, account_id, value, date_record, date_created
+	query := `
+		SELECT ` + recordColumns + `
 		FROM records
 		JOIN accounts ON records.account_id = accounts.id
-		WHERE accounts.user_id = ? AND date(records.date_record) = date(?)
-		ORDER BY date_created DESC
-	`,
-	)
+		WHERE accounts.user_id = ?
+			AND date(records.date_record) = date(?)
+			AND records.deleted_at IS NULL
+	`
+	args := []any{userId, date}
+
+	if len(tags) > 0 {
+		query += `AND records.id IN (
+			SELECT record_tags.record_id
+			FROM record_tags
+			JOIN tags ON record_tags.tag_id = tags.id
+			WHERE tags.name IN (` + placeholders(len(tags)) + `)
+		)
+		`
+		args = append(args, stringArgs(tags)...)
+	}
+
+	stmt, err := s.db.Prepare(query + "ORDER BY date_created DESC")
 	if err != nil {
 		return []models.Record{}, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.QueryContext(ctx, userId, date)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -207,15 +509,7 @@ func (s *Storage) RecordsByUserId(
 	var records []models.Record
 
 	for rows.Next() {
-		var record models.Record
-
-		err := rows.Scan(
-			&record.Id,
-			&record.AccountId,
-			&record.Value,
-			&record.DateRecord,
-			&record.DateCreated,
-		)
+		record, err := scanRecord(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -227,22 +521,3847 @@ func (s *Storage) RecordsByUserId(
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return records, nil
 }
 
-func (s *Storage) DeleteRecord(ctx context.Context, accountId int64, recordId int64) error {
-	const op = "storage.sqlite.DeleteRecord"
+// RecordsByAccountIdInRange returns records with date_record in [from, to),
+// bounds are compared as absolute instants regardless of stored offsets.
+func (s *Storage) RecordsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.Record, error) {
+	const op = "storage.sqlite.RecordsByAccountIdInRange"
 
-	stmt, err := s.db.Prepare("DELETE FROM records WHERE account_id = ? AND id = ?")
+	stmt, err := s.db.Prepare(`
+		SELECT ` + recordColumns + `
+		FROM records
+		WHERE account_id = ?
+			AND deleted_at IS NULL
+			AND julianday(date_record) >= julianday(?)
+			AND julianday(date_record) < julianday(?)
+		ORDER BY julianday(date_record) ASC, id ASC
+	`,
+	)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, accountId, recordId)
+	rows, err := stmt.QueryContext(ctx, accountId, from, to)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	return nil
+	var records []models.Record
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, nil
+}
+
+// recordsPageSize is how many records EachRecordByAccountIdInRange loads
+// at a time.
+const recordsPageSize = 500
+
+// EachRecordByAccountIdInRange calls fn for records with date_record in
+// [from, to) ordered by date, zero bounds leave the range open. Records are
+// loaded page by page and no query is open while fn runs, so fn may be slow.
+func (s *Storage) EachRecordByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+	fn func(models.Record) error,
+) error {
+	const op = "storage.sqlite.EachRecordByAccountIdInRange"
+
+	query := `
+		SELECT ` + recordColumns + `, julianday(date_record)
+		FROM records
+		WHERE account_id = ? AND deleted_at IS NULL
+	`
+	args := []any{accountId}
+
+	if !from.IsZero() {
+		query += "AND julianday(date_record) >= julianday(?) "
+		args = append(args, from)
+	}
+
+	if !to.IsZero() {
+		query += "AND julianday(date_record) < julianday(?) "
+		args = append(args, to)
+	}
+
+	var (
+		lastDay float64
+		lastId  int64
+		first   = true
+	)
+
+	for {
+		pageQuery := query
+		pageArgs := args
+
+		if !first {
+			pageQuery += "AND (julianday(date_record) > ? OR (julianday(date_record) = ? AND id > ?)) "
+			pageArgs = append(pageArgs[:len(pageArgs):len(pageArgs)], lastDay, lastDay, lastId)
+		}
+
+		pageQuery += "ORDER BY julianday(date_record) ASC, id ASC LIMIT ?"
+		pageArgs = append(pageArgs[:len(pageArgs):len(pageArgs)], recordsPageSize)
+
+		rows, err := s.db.QueryContext(ctx, pageQuery, pageArgs...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		var records []models.Record
+
+		for rows.Next() {
+			record, err := scanRecord(scannerFunc(func(dest ...any) error {
+				return rows.Scan(append(dest, &lastDay)...)
+			}))
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			records = append(records, record)
+		}
+
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+
+		if err := s.loadRecordNutrients(ctx, records); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := s.loadRecordTags(ctx, records); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if len(records) < recordsPageSize {
+			return nil
+		}
+
+		lastId = records[len(records)-1].Id
+		first = false
+	}
+}
+
+// DeleteRecord moves the record of the version to the trash with the
+// revision, trashed records are excluded everywhere until restored or purged.
+func (s *Storage) DeleteRecord(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+	version int64,
+	revision models.RecordRevision,
+) error {
+	const op = "storage.sqlite.DeleteRecord"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := trashRecord(ctx, tx, accountId, recordId, version); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func trashRecord(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId int64,
+	recordId int64,
+	version int64,
+) error {
+	value, dateRecord, err := liveRecord(ctx, tx, accountId, recordId, version)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE records SET deleted_at = ?, version = version + 1
+		WHERE account_id = ? AND id = ?`,
+		time.Now(), accountId, recordId,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM records_search WHERE docid = ?", recordId)
+	if err != nil {
+		return err
+	}
+
+	if err := addToDailyTotal(ctx, tx, accountId, dateRecord, -value, -1); err != nil {
+		return err
+	}
+
+	return touchRecords(ctx, tx, accountId, []int64{recordId})
+}
+
+// UpdateRecord updates value, meal, description and date of the record
+// of record.Version, bumps the version and saves the revision.
+func (s *Storage) UpdateRecord(
+	ctx context.Context,
+	record models.Record,
+	revision models.RecordRevision,
+) error {
+	const op = "storage.sqlite.UpdateRecord"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := updateRecord(ctx, tx, record); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func updateRecord(ctx context.Context, tx *sql.Tx, record models.Record) error {
+	value, dateRecord, err := liveRecord(ctx, tx, record.AccountId, record.Id, record.Version)
+	if err != nil {
+		return err
+	}
+
+	fieldTimes, err := recordFieldTimes(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE records
+		SET value = ?, meal = ?, description = ?, date_record = ?, field_times = ?,
+			version = version + 1
+		WHERE account_id = ? AND id = ?
+	`,
+		record.Value,
+		record.Meal,
+		record.Description,
+		record.DateRecord,
+		fieldTimes,
+		record.AccountId,
+		record.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := indexRecords(ctx, tx, []int64{record.Id}); err != nil {
+		return err
+	}
+
+	if err := addToDailyTotal(ctx, tx, record.AccountId, dateRecord, -value, -1); err != nil {
+		return err
+	}
+
+	if err := addToDailyTotal(ctx, tx, record.AccountId, record.DateRecord, record.Value, 1); err != nil {
+		return err
+	}
+
+	return touchRecords(ctx, tx, record.AccountId, []int64{record.Id})
+}
+
+// ApplyRecordBatch applies the operations of the account in one transaction
+// and returns ids of the changed records in operation order. A failed
+// operation is reported with BatchError and rolls the whole batch back.
+func (s *Storage) ApplyRecordBatch(
+	ctx context.Context,
+	accountId int64,
+	operations []models.RecordOperation,
+) ([]int64, error) {
+	const op = "storage.sqlite.ApplyRecordBatch"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(operations))
+
+	for i, operation := range operations {
+		record := operation.Record
+		record.AccountId = accountId
+		record.Id = operation.RecordId
+		record.Version = operation.Version
+
+		ids[i] = operation.RecordId
+
+		switch operation.Type {
+		case models.RecordOperationCreate:
+			ids[i], err = insertRecord(ctx, tx, record)
+		case models.RecordOperationUpdate:
+			err = updateRecord(ctx, tx, record)
+		case models.RecordOperationDelete:
+			err = trashRecord(ctx, tx, accountId, record.Id, record.Version)
+		default:
+			err = fmt.Errorf("unknown operation %q", operation.Type)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, &storage.BatchError{Index: i, Err: err})
+		}
+
+		revision := operation.Revision
+		revision.RecordId = ids[i]
+		if err := insertRevision(ctx, tx, revision); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// liveRecord returns value and date of a record not in the trash,
+// ErrVersionMismatch when the record has another version.
+func liveRecord(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId int64,
+	recordId int64,
+	version int64,
+) (int, time.Time, error) {
+	var (
+		value      int
+		dateRecord time.Time
+		current    int64
+	)
+
+	err := tx.QueryRowContext(
+		ctx,
+		`SELECT value, date_record, version FROM records
+		WHERE account_id = ? AND id = ? AND deleted_at IS NULL`,
+		accountId, recordId,
+	).Scan(&value, &dateRecord, &current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, time.Time{}, storage.ErrRecordNotFound
+		}
+		return 0, time.Time{}, err
+	}
+
+	if current != version {
+		return 0, time.Time{}, storage.ErrVersionMismatch
+	}
+
+	return value, dateRecord, nil
+}
+
+// RestoreRecord moves the record out of the trash with the revision.
+func (s *Storage) RestoreRecord(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+	revision models.RecordRevision,
+) error {
+	const op = "storage.sqlite.RestoreRecord"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var (
+		value      int
+		dateRecord time.Time
+	)
+
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT value, date_record FROM records
+		WHERE account_id = ? AND id = ? AND deleted_at IS NOT NULL`,
+		accountId, recordId,
+	).Scan(&value, &dateRecord)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrRecordNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE records SET deleted_at = NULL, version = version + 1 WHERE account_id = ? AND id = ?",
+		accountId, recordId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := indexRecords(ctx, tx, []int64{recordId}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := addToDailyTotal(ctx, tx, accountId, dateRecord, value, 1); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := touchRecords(ctx, tx, accountId, []int64{recordId}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeletedRecordById returns the trashed record of the account with its
+// nutrients and tags.
+func (s *Storage) DeletedRecordById(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+) (models.Record, error) {
+	const op = "storage.sqlite.DeletedRecordById"
+
+	record, err := scanRecord(s.db.QueryRowContext(
+		ctx,
+		"SELECT "+recordColumns+" FROM records WHERE account_id = ? AND id = ? AND deleted_at IS NOT NULL",
+		accountId, recordId,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, storage.ErrRecordNotFound)
+		}
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	records := []models.Record{record}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records[0], nil
+}
+
+// DeletedRecordsByAccountId returns trashed records, recently deleted first.
+func (s *Storage) DeletedRecordsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.Record, error) {
+	const op = "storage.sqlite.DeletedRecordsByAccountId"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+recordColumns+`
+		FROM records
+		WHERE account_id = ? AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+	`,
+		accountId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var records []models.Record
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, nil
+}
+
+// PurgeDeletedRecords permanently deletes records trashed before the time
+// with their nutrients, tags and photos, planned meals logged as them are
+// unlinked. Revisions are kept, the history is append-only. Purged records
+// are returned with their photos, whose blobs are left to the caller.
+func (s *Storage) PurgeDeletedRecords(
+	ctx context.Context,
+	before time.Time,
+) ([]models.Record, []models.RecordPhoto, error) {
+	const op = "storage.sqlite.PurgeDeletedRecords"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+recordColumns+`
+		FROM records
+		WHERE deleted_at IS NOT NULL AND julianday(deleted_at) < julianday(?)
+	`,
+		before,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var records []models.Record
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		records = append(records, record)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+
+		// Tombstones up to the floor are gone, clients synced before it
+		// have to start over.
+		_, err := tx.ExecContext(
+			ctx,
+			"UPDATE accounts SET sync_floor = MAX(sync_floor, ?) WHERE id = ?",
+			record.Seq, record.AccountId,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Photos are read here, record_photos rows go along with records
+	// when foreign keys are on
+	photoRows, err := tx.QueryContext(
+		ctx,
+		"SELECT "+recordPhotoColumns+" FROM record_photos WHERE record_id IN ("+placeholders(len(ids))+") ORDER BY id",
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var photos []models.RecordPhoto
+
+	for photoRows.Next() {
+		photo, err := scanRecordPhoto(photoRows)
+		if err != nil {
+			photoRows.Close()
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		photos = append(photos, photo)
+	}
+
+	photoRows.Close()
+
+	if err := photoRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE planned_meals SET record_id = NULL WHERE record_id IN ("+placeholders(len(ids))+")",
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, table := range []struct{ name, idColumn string }{
+		{"record_nutrients", "record_id"},
+		{"record_tags", "record_id"},
+		{"record_photos", "record_id"},
+		{"records", "id"},
+	} {
+		_, err := tx.ExecContext(
+			ctx,
+			"DELETE FROM "+table.name+" WHERE "+table.idColumn+" IN ("+placeholders(len(ids))+")",
+			int64Args(ids)...,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, photos, nil
+}
+
+// RecordByUuid returns the record of the account including trashed ones.
+func (s *Storage) RecordByUuid(
+	ctx context.Context,
+	accountId int64,
+	recordUuid string,
+) (models.Record, error) {
+	const op = "storage.sqlite.RecordByUuid"
+
+	record, err := scanRecord(s.db.QueryRowContext(
+		ctx,
+		"SELECT "+recordColumns+" FROM records WHERE account_id = ? AND uuid = ?",
+		accountId, recordUuid,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Record{}, fmt.Errorf("%s: %w", op, storage.ErrRecordNotFound)
+		}
+		return models.Record{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return record, nil
+}
+
+// RecordSyncState returns the change sequence of the account.
+func (s *Storage) RecordSyncState(ctx context.Context, accountId int64) (models.SyncState, error) {
+	const op = "storage.sqlite.RecordSyncState"
+
+	var state models.SyncState
+
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT sync_seq, sync_floor FROM accounts WHERE id = ?",
+		accountId,
+	).Scan(&state.Seq, &state.Floor)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SyncState{}, fmt.Errorf("%s: %w", op, storage.ErrAccountNotFound)
+		}
+		return models.SyncState{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return state, nil
+}
+
+// RecordChanges returns records of the account last changed in (after, upTo]
+// of the change sequence in change order, trashed records included.
+func (s *Storage) RecordChanges(
+	ctx context.Context,
+	accountId int64,
+	after int64,
+	upTo int64,
+	limit int,
+) ([]models.Record, error) {
+	const op = "storage.sqlite.RecordChanges"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+recordColumns+`
+		FROM records
+		WHERE account_id = ? AND seq > ? AND seq <= ?
+		ORDER BY seq ASC
+		LIMIT ?
+	`,
+		accountId, after, upTo, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var records []models.Record
+
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, nil
+}
+
+const recordColumns = `records.id, records.uuid, records.account_id, records.value,
+	records.meal, records.description, records.food_id, records.grams, records.recipe_id,
+	records.servings, records.date_record, records.date_created, records.deleted_at,
+	records.version, records.seq, records.field_times`
+
+// scanRecord scans recordColumns, nutrients are loaded separately.
+func scanRecord(row scanner) (models.Record, error) {
+	var (
+		record     models.Record
+		foodId     sql.NullInt64
+		grams      sql.NullFloat64
+		recipeId   sql.NullInt64
+		servings   sql.NullFloat64
+		deletedAt  sql.NullTime
+		fieldTimes string
+	)
+
+	err := row.Scan(
+		&record.Id,
+		&record.Uuid,
+		&record.AccountId,
+		&record.Value,
+		&record.Meal,
+		&record.Description,
+		&foodId,
+		&grams,
+		&recipeId,
+		&servings,
+		&record.DateRecord,
+		&record.DateCreated,
+		&deletedAt,
+		&record.Version,
+		&record.Seq,
+		&fieldTimes,
+	)
+	if err != nil {
+		return models.Record{}, err
+	}
+
+	if err := json.Unmarshal([]byte(fieldTimes), &record.FieldTimes); err != nil {
+		return models.Record{}, err
+	}
+
+	if deletedAt.Valid {
+		record.DeletedAt = &deletedAt.Time
+	}
+
+	if foodId.Valid {
+		record.FoodId = &foodId.Int64
+	}
+
+	if recipeId.Valid {
+		record.RecipeId = &recipeId.Int64
+	}
+
+	record.Grams = grams.Float64
+	record.Servings = servings.Float64
+
+	return record, nil
+}
+
+func nullableAmount(amount float64) any {
+	if amount == 0 {
+		return nil
+	}
+	return amount
+}
+
+// loadRecordNutrients fills nutrients of records in place.
+func (s *Storage) loadRecordNutrients(ctx context.Context, records []models.Record) error {
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+	}
+
+	nutrients, err := s.nutrientsByIds(ctx, "record_nutrients", "record_id", ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range records {
+		records[i].Nutrients = nutrients[records[i].Id]
+	}
+
+	return nil
+}
+
+// nutrientsByIds reads nutrients of records, foods or recipes from table.
+func (s *Storage) nutrientsByIds(
+	ctx context.Context,
+	table string,
+	idColumn string,
+	ids []int64,
+) (map[int64]models.Nutrients, error) {
+	result := make(map[int64]models.Nutrients, len(ids))
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT "+idColumn+", nutrient, amount FROM "+table+
+			" WHERE "+idColumn+" IN ("+placeholders(len(ids))+")",
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id       int64
+			nutrient models.Nutrient
+			amount   float64
+		)
+
+		if err := rows.Scan(&id, &nutrient, &amount); err != nil {
+			return nil, err
+		}
+
+		if result[id] == nil {
+			result[id] = models.Nutrients{}
+		}
+		result[id][nutrient] = amount
+	}
+
+	return result, rows.Err()
+}
+
+// allergensByIds reads allergens of foods or recipes from table.
+func (s *Storage) allergensByIds(
+	ctx context.Context,
+	table string,
+	idColumn string,
+	ids []int64,
+) (map[int64][]models.Allergen, error) {
+	result := make(map[int64][]models.Allergen, len(ids))
+
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT "+idColumn+", allergen FROM "+table+
+			" WHERE "+idColumn+" IN ("+placeholders(len(ids))+") ORDER BY allergen",
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id       int64
+			allergen models.Allergen
+		)
+
+		if err := rows.Scan(&id, &allergen); err != nil {
+			return nil, err
+		}
+
+		result[id] = append(result[id], allergen)
+	}
+
+	return result, rows.Err()
+}
+
+// saveAllergens inserts allergens of a food or a recipe into table.
+func saveAllergens(
+	ctx context.Context,
+	tx *sql.Tx,
+	table string,
+	idColumn string,
+	id int64,
+	allergens []models.Allergen,
+) error {
+	for _, allergen := range allergens {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT OR IGNORE INTO "+table+"("+idColumn+", allergen) VALUES (?, ?)",
+			id, allergen,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func stringArgs(values []string) []any {
+	args := make([]any, 0, len(values))
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return args
+}
+
+func int64Args(ids []int64) []any {
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	return args
+}
+
+// saveNutrients inserts nutrients of a record or a food into table.
+func saveNutrients(
+	ctx context.Context,
+	tx *sql.Tx,
+	table string,
+	idColumn string,
+	id int64,
+	nutrients models.Nutrients,
+) error {
+	for nutrient, amount := range nutrients {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO "+table+"("+idColumn+", nutrient, amount) VALUES (?, ?, ?)",
+			id, nutrient, amount,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// addToDailyTotal keeps daily_totals in sync with records, so aggregates
+// (streaks, stats) never need to scan the records table.
+func addToDailyTotal(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId int64,
+	dateRecord time.Time,
+	value int,
+	count int,
+) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO daily_totals(account_id, day, value, records_count)
+		VALUES (?, date(?), ?, ?)
+		ON CONFLICT(account_id, day) DO UPDATE SET
+			value = value + excluded.value,
+			records_count = records_count + excluded.records_count
+	`,
+		accountId, dateRecord, value, count,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM daily_totals WHERE account_id = ? AND day = date(?) AND records_count <= 0",
+		accountId, dateRecord,
+	)
+
+	return err
+}
+
+func (s *Storage) DailyTotalsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.DailyTotal, error) {
+	const op = "storage.sqlite.DailyTotalsByAccountId"
+
+	stmt, err := s.db.Prepare(`
+		SELECT day, value, records_count
+		FROM daily_totals
+		WHERE account_id = ?
+		ORDER BY day ASC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totals []models.DailyTotal
+
+	for rows.Next() {
+		var total models.DailyTotal
+
+		if err := rows.Scan(&total.Day, &total.Value, &total.RecordsCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return totals, nil
+}
+
+// DailyTotalsByAccountIdInRange returns daily totals for days from..to inclusive.
+func (s *Storage) DailyTotalsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.DailyTotal, error) {
+	const op = "storage.sqlite.DailyTotalsByAccountIdInRange"
+
+	stmt, err := s.db.Prepare(`
+		SELECT day, value, records_count
+		FROM daily_totals
+		WHERE account_id = ? AND day >= ? AND day <= ?
+		ORDER BY day ASC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(
+		ctx,
+		accountId,
+		from.Format(time.DateOnly),
+		to.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totals []models.DailyTotal
+
+	for rows.Next() {
+		var total models.DailyTotal
+
+		if err := rows.Scan(&total.Day, &total.Value, &total.RecordsCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return totals, nil
+}
+
+func (s *Storage) StreaksByAccountId(
+	ctx context.Context,
+	accountId int64,
+) (models.Streaks, error) {
+	const op = "storage.sqlite.StreaksByAccountId"
+
+	stmt, err := s.db.Prepare(`
+		SELECT
+			account_id,
+			logging_current, logging_longest, logging_last_day,
+			on_target_current, on_target_longest, on_target_last_day
+		FROM streaks
+		WHERE account_id = ?
+	`,
+	)
+	if err != nil {
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var (
+		streaks         models.Streaks
+		loggingLastDay  sql.NullTime
+		onTargetLastDay sql.NullTime
+	)
+
+	err = stmt.QueryRowContext(ctx, accountId).Scan(
+		&streaks.AccountId,
+		&streaks.Logging.Current,
+		&streaks.Logging.Longest,
+		&loggingLastDay,
+		&streaks.OnTarget.Current,
+		&streaks.OnTarget.Longest,
+		&onTargetLastDay,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Streaks{}, fmt.Errorf("%s: %w", op, storage.ErrStreaksNotFound)
+		}
+		return models.Streaks{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if loggingLastDay.Valid {
+		streaks.Logging.LastDay = &loggingLastDay.Time
+	}
+	if onTargetLastDay.Valid {
+		streaks.OnTarget.LastDay = &onTargetLastDay.Time
+	}
+
+	return streaks, nil
+}
+
+func (s *Storage) SaveStreaks(ctx context.Context, streaks models.Streaks) error {
+	const op = "storage.sqlite.SaveStreaks"
+
+	stmt, err := s.db.Prepare(`
+		INSERT INTO streaks(
+			account_id,
+			logging_current, logging_longest, logging_last_day,
+			on_target_current, on_target_longest, on_target_last_day
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_id) DO UPDATE SET
+			logging_current = excluded.logging_current,
+			logging_longest = excluded.logging_longest,
+			logging_last_day = excluded.logging_last_day,
+			on_target_current = excluded.on_target_current,
+			on_target_longest = excluded.on_target_longest,
+			on_target_last_day = excluded.on_target_last_day
+	`,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(
+		ctx,
+		streaks.AccountId,
+		streaks.Logging.Current,
+		streaks.Logging.Longest,
+		nullableDay(streaks.Logging.LastDay),
+		streaks.OnTarget.Current,
+		streaks.OnTarget.Longest,
+		nullableDay(streaks.OnTarget.LastDay),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// nullableDay stores days in the same YYYY-MM-DD form that sqlite's date() produces.
+func nullableDay(day *time.Time) any {
+	if day == nil {
+		return nil
+	}
+	return day.Format(time.DateOnly)
+}
+
+func (s *Storage) RecordsCountByAccountId(ctx context.Context, accountId int64) (int, error) {
+	const op = "storage.sqlite.RecordsCountByAccountId"
+
+	stmt, err := s.db.Prepare(
+		"SELECT COALESCE(SUM(records_count), 0) FROM daily_totals WHERE account_id = ?",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var count int
+
+	if err := stmt.QueryRowContext(ctx, accountId).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+func (s *Storage) SaveWeight(
+	ctx context.Context,
+	accountId int64,
+	value float64,
+	dateRecord time.Time,
+) (int64, error) {
+	const op = "storage.sqlite.SaveWeight"
+
+	stmt, err := s.db.Prepare(
+		"INSERT INTO weights(account_id, value, date_record, date_created) VALUES (?, ?, ?, ?)",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, accountId, value, dateRecord, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) WeightsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.Weight, error) {
+	const op = "storage.sqlite.WeightsByAccountId"
+
+	stmt, err := s.db.Prepare(`
+		SELECT id, account_id, value, date_record, date_created
+		FROM weights
+		WHERE account_id = ?
+		ORDER BY julianday(date_record) ASC, id ASC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var weights []models.Weight
+
+	for rows.Next() {
+		var weight models.Weight
+
+		err := rows.Scan(
+			&weight.Id,
+			&weight.AccountId,
+			&weight.Value,
+			&weight.DateRecord,
+			&weight.DateCreated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		weights = append(weights, weight)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return weights, nil
+}
+
+func (s *Storage) UnlockedAchievements(
+	ctx context.Context,
+	accountId int64,
+) (map[string]time.Time, error) {
+	const op = "storage.sqlite.UnlockedAchievements"
+
+	stmt, err := s.db.Prepare("SELECT code, unlocked_at FROM achievements WHERE account_id = ?")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	unlocked := make(map[string]time.Time)
+
+	for rows.Next() {
+		var (
+			code       string
+			unlockedAt time.Time
+		)
+
+		if err := rows.Scan(&code, &unlockedAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		unlocked[code] = unlockedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return unlocked, nil
+}
+
+// SaveAchievements unlocks achievements by codes. Already unlocked ones keep
+// their original unlock time.
+func (s *Storage) SaveAchievements(
+	ctx context.Context,
+	accountId int64,
+	codes []string,
+	unlockedAt time.Time,
+) error {
+	const op = "storage.sqlite.SaveAchievements"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		"INSERT OR IGNORE INTO achievements(account_id, code, unlocked_at) VALUES (?, ?, ?)",
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, code := range codes {
+		if _, err := stmt.ExecContext(ctx, accountId, code, unlockedAt); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// WeightsByAccountIdInRange returns weights with date_record in [from, to).
+func (s *Storage) WeightsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.Weight, error) {
+	const op = "storage.sqlite.WeightsByAccountIdInRange"
+
+	stmt, err := s.db.Prepare(`
+		SELECT id, account_id, value, date_record, date_created
+		FROM weights
+		WHERE account_id = ?
+			AND julianday(date_record) >= julianday(?)
+			AND julianday(date_record) < julianday(?)
+		ORDER BY julianday(date_record) ASC, id ASC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var weights []models.Weight
+
+	for rows.Next() {
+		var weight models.Weight
+
+		err := rows.Scan(
+			&weight.Id,
+			&weight.AccountId,
+			&weight.Value,
+			&weight.DateRecord,
+			&weight.DateCreated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		weights = append(weights, weight)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return weights, nil
+}
+
+// TopFoodsByAccountIdInRange groups records in [from, to) by description
+// (case-insensitive) and returns the groups with the largest intake.
+func (s *Storage) TopFoodsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]models.FoodTotal, error) {
+	const op = "storage.sqlite.TopFoodsByAccountIdInRange"
+
+	stmt, err := s.db.Prepare(`
+		SELECT MIN(description), SUM(value), COUNT(*)
+		FROM records
+		WHERE account_id = ?
+			AND deleted_at IS NULL
+			AND description <> ''
+			AND julianday(date_record) >= julianday(?)
+			AND julianday(date_record) < julianday(?)
+		GROUP BY lower(description)
+		ORDER BY SUM(value) DESC
+		LIMIT ?
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var foods []models.FoodTotal
+
+	for rows.Next() {
+		var food models.FoodTotal
+
+		if err := rows.Scan(&food.Name, &food.Value, &food.RecordsCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		foods = append(foods, food)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return foods, nil
+}
+
+func (s *Storage) SaveFastingSession(
+	ctx context.Context,
+	session models.FastingSession,
+) (int64, error) {
+	const op = "storage.sqlite.SaveFastingSession"
+
+	stmt, err := s.db.Prepare(`
+		INSERT INTO fasting_sessions(account_id, protocol, planned_minutes, started_at)
+		VALUES (?, ?, ?, ?)
+	`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(
+		ctx,
+		session.AccountId,
+		session.Protocol,
+		session.PlannedMinutes,
+		session.StartedAt,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrFastingSessionExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) ActiveFastingSession(
+	ctx context.Context,
+	accountId int64,
+) (models.FastingSession, error) {
+	const op = "storage.sqlite.ActiveFastingSession"
+
+	stmt, err := s.db.Prepare(`
+		SELECT id, account_id, protocol, planned_minutes, started_at, ended_at
+		FROM fasting_sessions
+		WHERE account_id = ? AND ended_at IS NULL
+	`,
+	)
+	if err != nil {
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	session, err := scanFastingSession(stmt.QueryRowContext(ctx, accountId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.FastingSession{}, fmt.Errorf("%s: %w", op, storage.ErrFastingSessionNotFound)
+		}
+		return models.FastingSession{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return session, nil
+}
+
+func (s *Storage) FinishFastingSession(
+	ctx context.Context,
+	accountId int64,
+	sessionId int64,
+	endedAt time.Time,
+) error {
+	const op = "storage.sqlite.FinishFastingSession"
+
+	stmt, err := s.db.Prepare(`
+		UPDATE fasting_sessions SET ended_at = ?
+		WHERE account_id = ? AND id = ? AND ended_at IS NULL
+	`,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.ExecContext(ctx, endedAt, accountId, sessionId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrFastingSessionNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) FastingSessionsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.FastingSession, error) {
+	const op = "storage.sqlite.FastingSessionsByAccountId"
+
+	stmt, err := s.db.Prepare(`
+		SELECT id, account_id, protocol, planned_minutes, started_at, ended_at
+		FROM fasting_sessions
+		WHERE account_id = ?
+		ORDER BY started_at DESC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var sessions []models.FastingSession
+
+	for rows.Next() {
+		session, err := scanFastingSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sessions, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scannerFunc adapts a function to scanner, e.g. to scan extra columns
+// after the ones a scan helper knows of.
+type scannerFunc func(dest ...any) error
+
+func (f scannerFunc) Scan(dest ...any) error {
+	return f(dest...)
+}
+
+func scanFastingSession(row scanner) (models.FastingSession, error) {
+	var (
+		session models.FastingSession
+		endedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&session.Id,
+		&session.AccountId,
+		&session.Protocol,
+		&session.PlannedMinutes,
+		&session.StartedAt,
+		&endedAt,
+	)
+	if err != nil {
+		return models.FastingSession{}, err
+	}
+
+	if endedAt.Valid {
+		session.EndedAt = &endedAt.Time
+	}
+
+	return session, nil
+}
+
+func (s *Storage) SaveFood(ctx context.Context, food models.Food) (int64, error) {
+	const op = "storage.sqlite.SaveFood"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO foods(account_id, name, value, favorite, category) VALUES (?, ?, ?, ?, ?)",
+		food.AccountId, food.Name, food.Value, food.Favorite, food.Category,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrFoodExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveNutrients(ctx, tx, "food_nutrients", "food_id", id, food.Nutrients); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveAllergens(ctx, tx, "food_allergens", "food_id", id, food.Allergens); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) FoodById(
+	ctx context.Context,
+	accountId int64,
+	foodId int64,
+) (models.Food, error) {
+	const op = "storage.sqlite.FoodById"
+
+	foods, err := s.foods(ctx, "foods.account_id = ? AND foods.id = ?", accountId, foodId)
+	if err != nil {
+		return models.Food{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(foods) == 0 {
+		return models.Food{}, fmt.Errorf("%s: %w", op, storage.ErrFoodNotFound)
+	}
+
+	return foods[0], nil
+}
+
+func (s *Storage) FoodsByAccountId(ctx context.Context, accountId int64) ([]models.Food, error) {
+	const op = "storage.sqlite.FoodsByAccountId"
+
+	foods, err := s.foods(ctx, "foods.account_id = ?", accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return foods, nil
+}
+
+// foods returns foods matching where with their nutrients, ordered by name.
+func (s *Storage) foods(ctx context.Context, where string, args ...any) ([]models.Food, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT foods.id, foods.account_id, foods.name, foods.value, foods.favorite, foods.category,
+			food_nutrients.nutrient, food_nutrients.amount
+		FROM foods
+		LEFT JOIN food_nutrients ON food_nutrients.food_id = foods.id
+		WHERE `+where+`
+		ORDER BY foods.name COLLATE NOCASE, foods.id
+	`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var foods []models.Food
+
+	for rows.Next() {
+		var (
+			food     models.Food
+			nutrient sql.NullString
+			amount   sql.NullFloat64
+		)
+
+		err := rows.Scan(
+			&food.Id,
+			&food.AccountId,
+			&food.Name,
+			&food.Value,
+			&food.Favorite,
+			&food.Category,
+			&nutrient,
+			&amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		last := len(foods) - 1
+		if last < 0 || foods[last].Id != food.Id {
+			foods = append(foods, food)
+			last++
+		}
+
+		if nutrient.Valid {
+			if foods[last].Nutrients == nil {
+				foods[last].Nutrients = models.Nutrients{}
+			}
+			foods[last].Nutrients[models.Nutrient(nutrient.String)] = amount.Float64
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(foods))
+	for _, food := range foods {
+		ids = append(ids, food.Id)
+	}
+
+	allergens, err := s.allergensByIds(ctx, "food_allergens", "food_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range foods {
+		foods[i].Allergens = allergens[foods[i].Id]
+	}
+
+	return foods, nil
+}
+
+// NutrientAmountsByAccountIdInRange returns nutrient amounts of records
+// with date_record in [from, to).
+func (s *Storage) NutrientAmountsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.NutrientAmount, error) {
+	const op = "storage.sqlite.NutrientAmountsByAccountIdInRange"
+
+	stmt, err := s.db.Prepare(`
+		SELECT records.id, records.date_record, record_nutrients.nutrient, record_nutrients.amount
+		FROM record_nutrients
+		JOIN records ON records.id = record_nutrients.record_id
+		WHERE records.account_id = ?
+			AND records.deleted_at IS NULL
+			AND julianday(records.date_record) >= julianday(?)
+			AND julianday(records.date_record) < julianday(?)
+		ORDER BY julianday(records.date_record) ASC, records.id ASC
+	`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, accountId, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var amounts []models.NutrientAmount
+
+	for rows.Next() {
+		var amount models.NutrientAmount
+
+		err := rows.Scan(&amount.RecordId, &amount.DateRecord, &amount.Nutrient, &amount.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		amounts = append(amounts, amount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return amounts, nil
+}
+
+func (s *Storage) RestrictionsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) (models.Restrictions, error) {
+	const op = "storage.sqlite.RestrictionsByAccountId"
+
+	var restrictions models.Restrictions
+
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT restriction_mode FROM accounts WHERE id = ?",
+		accountId,
+	).Scan(&restrictions.Mode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Restrictions{}, fmt.Errorf("%s: %w", op, storage.ErrAccountNotFound)
+		}
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	allergens, err := s.allergensByIds(ctx, "account_allergens", "account_id", []int64{accountId})
+	if err != nil {
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restrictions.Allergens = allergens[accountId]
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT diet FROM account_diets WHERE account_id = ? ORDER BY diet",
+		accountId,
+	)
+	if err != nil {
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var diet models.Diet
+
+		if err := rows.Scan(&diet); err != nil {
+			return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		restrictions.Diets = append(restrictions.Diets, diet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.Restrictions{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return restrictions, nil
+}
+
+// SaveRestrictions replaces account restrictions.
+func (s *Storage) SaveRestrictions(
+	ctx context.Context,
+	accountId int64,
+	restrictions models.Restrictions,
+) error {
+	const op = "storage.sqlite.SaveRestrictions"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"UPDATE accounts SET restriction_mode = ? WHERE id = ?",
+		restrictions.Mode, accountId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAccountNotFound)
+	}
+
+	for _, table := range []string{"account_allergens", "account_diets"} {
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE account_id = ?", accountId)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = saveAllergens(ctx, tx, "account_allergens", "account_id", accountId, restrictions.Allergens)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, diet := range restrictions.Diets {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT OR IGNORE INTO account_diets(account_id, diet) VALUES (?, ?)",
+			accountId, diet,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveRecipe(ctx context.Context, recipe models.Recipe) (int64, error) {
+	const op = "storage.sqlite.SaveRecipe"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO recipes(account_id, name, servings, value, favorite) VALUES (?, ?, ?, ?, ?)",
+		recipe.AccountId, recipe.Name, recipe.Servings, recipe.Value, recipe.Favorite,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrRecipeExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for position, ingredient := range recipe.Ingredients {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO recipe_ingredients(recipe_id, position, food_id, grams) VALUES (?, ?, ?, ?)",
+			id, position, ingredient.FoodId, ingredient.Grams,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := saveNutrients(ctx, tx, "recipe_nutrients", "recipe_id", id, recipe.Nutrients); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveAllergens(ctx, tx, "recipe_allergens", "recipe_id", id, recipe.Allergens); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) RecipeById(
+	ctx context.Context,
+	accountId int64,
+	recipeId int64,
+) (models.Recipe, error) {
+	const op = "storage.sqlite.RecipeById"
+
+	recipes, err := s.recipes(ctx, "account_id = ? AND id = ?", accountId, recipeId)
+	if err != nil {
+		return models.Recipe{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(recipes) == 0 {
+		return models.Recipe{}, fmt.Errorf("%s: %w", op, storage.ErrRecipeNotFound)
+	}
+
+	return recipes[0], nil
+}
+
+func (s *Storage) RecipesByAccountId(ctx context.Context, accountId int64) ([]models.Recipe, error) {
+	const op = "storage.sqlite.RecipesByAccountId"
+
+	recipes, err := s.recipes(ctx, "account_id = ?", accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recipes, nil
+}
+
+// recipes returns recipes matching where with ingredients, nutrients
+// and allergens, ordered by name.
+func (s *Storage) recipes(ctx context.Context, where string, args ...any) ([]models.Recipe, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, account_id, name, servings, value, favorite
+		FROM recipes
+		WHERE `+where+`
+		ORDER BY name COLLATE NOCASE, id
+	`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		recipes []models.Recipe
+		ids     []int64
+	)
+
+	index := make(map[int64]int)
+
+	for rows.Next() {
+		var recipe models.Recipe
+
+		err := rows.Scan(
+			&recipe.Id,
+			&recipe.AccountId,
+			&recipe.Name,
+			&recipe.Servings,
+			&recipe.Value,
+			&recipe.Favorite,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		index[recipe.Id] = len(recipes)
+		ids = append(ids, recipe.Id)
+		recipes = append(recipes, recipe)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(recipes) == 0 {
+		return nil, nil
+	}
+
+	ingredients, err := s.db.QueryContext(ctx, `
+		SELECT recipe_ingredients.recipe_id, recipe_ingredients.food_id, foods.name,
+			recipe_ingredients.grams
+		FROM recipe_ingredients
+		JOIN foods ON foods.id = recipe_ingredients.food_id
+		WHERE recipe_ingredients.recipe_id IN (`+placeholders(len(ids))+`)
+		ORDER BY recipe_ingredients.recipe_id, recipe_ingredients.position
+	`,
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer ingredients.Close()
+
+	for ingredients.Next() {
+		var (
+			recipeId   int64
+			ingredient models.RecipeIngredient
+		)
+
+		err := ingredients.Scan(&recipeId, &ingredient.FoodId, &ingredient.Name, &ingredient.Grams)
+		if err != nil {
+			return nil, err
+		}
+
+		recipe := &recipes[index[recipeId]]
+		recipe.Ingredients = append(recipe.Ingredients, ingredient)
+	}
+
+	if err := ingredients.Err(); err != nil {
+		return nil, err
+	}
+
+	nutrients, err := s.nutrientsByIds(ctx, "recipe_nutrients", "recipe_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	allergens, err := s.allergensByIds(ctx, "recipe_allergens", "recipe_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recipes {
+		recipes[i].Nutrients = nutrients[recipes[i].Id]
+		recipes[i].Allergens = allergens[recipes[i].Id]
+	}
+
+	return recipes, nil
+}
+
+func (s *Storage) InsulinSettingsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) (models.InsulinSettings, error) {
+	const op = "storage.sqlite.InsulinSettingsByAccountId"
+
+	stmt, err := s.db.Prepare(`
+		SELECT carb_ratio, correction_factor, target_glucose, glucose_unit, dose_increment
+		FROM insulin_settings
+		WHERE account_id = ?
+	`,
+	)
+	if err != nil {
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var settings models.InsulinSettings
+
+	err = stmt.QueryRowContext(ctx, accountId).Scan(
+		&settings.CarbRatio,
+		&settings.CorrectionFactor,
+		&settings.TargetGlucose,
+		&settings.GlucoseUnit,
+		&settings.DoseIncrement,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, storage.ErrInsulinSettingsNotFound)
+		}
+		return models.InsulinSettings{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return settings, nil
+}
+
+func (s *Storage) SaveInsulinSettings(
+	ctx context.Context,
+	accountId int64,
+	settings models.InsulinSettings,
+) error {
+	const op = "storage.sqlite.SaveInsulinSettings"
+
+	stmt, err := s.db.Prepare(`
+		INSERT INTO insulin_settings(
+			account_id, carb_ratio, correction_factor, target_glucose, glucose_unit, dose_increment
+		)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(account_id) DO UPDATE SET
+			carb_ratio = excluded.carb_ratio,
+			correction_factor = excluded.correction_factor,
+			target_glucose = excluded.target_glucose,
+			glucose_unit = excluded.glucose_unit,
+			dose_increment = excluded.dose_increment
+	`,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(
+		ctx,
+		accountId,
+		settings.CarbRatio,
+		settings.CorrectionFactor,
+		settings.TargetGlucose,
+		settings.GlucoseUnit,
+		settings.DoseIncrement,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveBolusCalculation(
+	ctx context.Context,
+	calculation models.BolusCalculation,
+) (int64, error) {
+	const op = "storage.sqlite.SaveBolusCalculation"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var glucose any
+	if calculation.Glucose != nil {
+		glucose = *calculation.Glucose
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO bolus_calculations(
+			account_id, created_at, carbs, carbs_source, meal, day, uncounted_records,
+			glucose, insulin_on_board,
+			carb_ratio, correction_factor, target_glucose, glucose_unit, dose_increment,
+			carb_dose, correction_dose, suggested_dose
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		calculation.AccountId,
+		calculation.CreatedAt,
+		calculation.Carbs,
+		calculation.CarbsSource,
+		calculation.Meal,
+		nullableDay(calculation.Day),
+		calculation.UncountedRecords,
+		glucose,
+		calculation.InsulinOnBoard,
+		calculation.Settings.CarbRatio,
+		calculation.Settings.CorrectionFactor,
+		calculation.Settings.TargetGlucose,
+		calculation.Settings.GlucoseUnit,
+		calculation.Settings.DoseIncrement,
+		calculation.CarbDose,
+		calculation.CorrectionDose,
+		calculation.SuggestedDose,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, recordId := range calculation.RecordIds {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO bolus_calculation_records(calculation_id, record_id) VALUES (?, ?)",
+			id, recordId,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) BolusCalculationsByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.BolusCalculation, error) {
+	const op = "storage.sqlite.BolusCalculationsByAccountId"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, account_id, created_at, carbs, carbs_source, meal, day, uncounted_records,
+			glucose, insulin_on_board,
+			carb_ratio, correction_factor, target_glucose, glucose_unit, dose_increment,
+			carb_dose, correction_dose, suggested_dose
+		FROM bolus_calculations
+		WHERE account_id = ?
+		ORDER BY created_at DESC, id DESC
+	`,
+		accountId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		calculations []models.BolusCalculation
+		ids          []int64
+	)
+
+	for rows.Next() {
+		var (
+			calculation models.BolusCalculation
+			day         sql.NullTime
+			glucose     sql.NullFloat64
+		)
+
+		err := rows.Scan(
+			&calculation.Id,
+			&calculation.AccountId,
+			&calculation.CreatedAt,
+			&calculation.Carbs,
+			&calculation.CarbsSource,
+			&calculation.Meal,
+			&day,
+			&calculation.UncountedRecords,
+			&glucose,
+			&calculation.InsulinOnBoard,
+			&calculation.Settings.CarbRatio,
+			&calculation.Settings.CorrectionFactor,
+			&calculation.Settings.TargetGlucose,
+			&calculation.Settings.GlucoseUnit,
+			&calculation.Settings.DoseIncrement,
+			&calculation.CarbDose,
+			&calculation.CorrectionDose,
+			&calculation.SuggestedDose,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if day.Valid {
+			calculation.Day = &day.Time
+		}
+		if glucose.Valid {
+			calculation.Glucose = &glucose.Float64
+		}
+
+		ids = append(ids, calculation.Id)
+		calculations = append(calculations, calculation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(calculations) == 0 {
+		return nil, nil
+	}
+
+	recordRows, err := s.db.QueryContext(ctx, `
+		SELECT calculation_id, record_id
+		FROM bolus_calculation_records
+		WHERE calculation_id IN (`+placeholders(len(ids))+`)
+		ORDER BY record_id
+	`,
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer recordRows.Close()
+
+	recordIds := make(map[int64][]int64)
+
+	for recordRows.Next() {
+		var calculationId, recordId int64
+
+		if err := recordRows.Scan(&calculationId, &recordId); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		recordIds[calculationId] = append(recordIds[calculationId], recordId)
+	}
+
+	if err := recordRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range calculations {
+		calculations[i].RecordIds = recordIds[calculations[i].Id]
+		if calculations[i].RecordIds == nil {
+			calculations[i].RecordIds = []int64{}
+		}
+	}
+
+	return calculations, nil
+}
+
+func (s *Storage) SaveMealPlan(ctx context.Context, plan models.MealPlan) (int64, error) {
+	const op = "storage.sqlite.SaveMealPlan"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO meal_plans(
+			account_id, created_at, start_day, daily_limit,
+			carbs_target, protein_target, fat_target
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		plan.AccountId,
+		plan.CreatedAt,
+		plan.StartDay.Format(time.DateOnly),
+		plan.DailyLimit,
+		plan.MacroTargets.Carbs,
+		plan.MacroTargets.Protein,
+		plan.MacroTargets.Fat,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for position, meal := range plan.Meals() {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO planned_meals(
+				plan_id, day, position, meal, food_id, recipe_id, name, grams, servings, value
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			id,
+			meal.Day.Format(time.DateOnly),
+			position,
+			meal.Meal,
+			meal.FoodId,
+			meal.RecipeId,
+			meal.Name,
+			nullableAmount(meal.Grams),
+			nullableAmount(meal.Servings),
+			meal.Value,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		mealId, err := res.LastInsertId()
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = saveNutrients(ctx, tx, "planned_meal_nutrients", "planned_meal_id", mealId, meal.Nutrients)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) MealPlanById(
+	ctx context.Context,
+	accountId int64,
+	planId int64,
+) (models.MealPlan, error) {
+	const op = "storage.sqlite.MealPlanById"
+
+	plans, err := s.mealPlans(ctx, "account_id = ? AND id = ?", accountId, planId)
+	if err != nil {
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(plans) == 0 {
+		return models.MealPlan{}, fmt.Errorf("%s: %w", op, storage.ErrMealPlanNotFound)
+	}
+
+	return plans[0], nil
+}
+
+func (s *Storage) MealPlansByAccountId(
+	ctx context.Context,
+	accountId int64,
+) ([]models.MealPlan, error) {
+	const op = "storage.sqlite.MealPlansByAccountId"
+
+	plans, err := s.mealPlans(ctx, "account_id = ?", accountId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return plans, nil
+}
+
+// MealPlansByAccountIdInRange returns plans with planned meals on days
+// in [from, to], every meal of such plans is returned.
+func (s *Storage) MealPlansByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.MealPlan, error) {
+	const op = "storage.sqlite.MealPlansByAccountIdInRange"
+
+	plans, err := s.mealPlans(
+		ctx,
+		"account_id = ? AND id IN (SELECT plan_id FROM planned_meals WHERE day BETWEEN ? AND ?)",
+		accountId, from.Format(time.DateOnly), to.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return plans, nil
+}
+
+// mealPlans returns plans matching where with planned meals grouped
+// by day, newest plans first.
+func (s *Storage) mealPlans(ctx context.Context, where string, args ...any) ([]models.MealPlan, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, account_id, created_at, start_day, daily_limit,
+			carbs_target, protein_target, fat_target
+		FROM meal_plans
+		WHERE `+where+`
+		ORDER BY created_at DESC, id DESC
+	`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		plans []models.MealPlan
+		ids   []int64
+	)
+
+	index := make(map[int64]int)
+
+	for rows.Next() {
+		var plan models.MealPlan
+
+		err := rows.Scan(
+			&plan.Id,
+			&plan.AccountId,
+			&plan.CreatedAt,
+			&plan.StartDay,
+			&plan.DailyLimit,
+			&plan.MacroTargets.Carbs,
+			&plan.MacroTargets.Protein,
+			&plan.MacroTargets.Fat,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		index[plan.Id] = len(plans)
+		ids = append(ids, plan.Id)
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(plans) == 0 {
+		return nil, nil
+	}
+
+	mealRows, err := s.db.QueryContext(ctx, `
+		SELECT plan_id, id, day, meal, food_id, recipe_id, name, grams, servings, value, record_id
+		FROM planned_meals
+		WHERE plan_id IN (`+placeholders(len(ids))+`)
+		ORDER BY plan_id, day, position
+	`,
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer mealRows.Close()
+
+	var (
+		mealIds []int64
+		meals   = make(map[int64][]models.PlannedMeal)
+	)
+
+	for mealRows.Next() {
+		var (
+			planId   int64
+			meal     models.PlannedMeal
+			foodId   sql.NullInt64
+			recipeId sql.NullInt64
+			grams    sql.NullFloat64
+			servings sql.NullFloat64
+			recordId sql.NullInt64
+		)
+
+		err := mealRows.Scan(
+			&planId,
+			&meal.Id,
+			&meal.Day,
+			&meal.Meal,
+			&foodId,
+			&recipeId,
+			&meal.Name,
+			&grams,
+			&servings,
+			&meal.Value,
+			&recordId,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if foodId.Valid {
+			meal.FoodId = &foodId.Int64
+		}
+		if recipeId.Valid {
+			meal.RecipeId = &recipeId.Int64
+		}
+		if recordId.Valid {
+			meal.RecordId = &recordId.Int64
+		}
+		meal.Grams = grams.Float64
+		meal.Servings = servings.Float64
+
+		mealIds = append(mealIds, meal.Id)
+		meals[planId] = append(meals[planId], meal)
+	}
+
+	if err := mealRows.Err(); err != nil {
+		return nil, err
+	}
+
+	nutrients, err := s.nutrientsByIds(ctx, "planned_meal_nutrients", "planned_meal_id", mealIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for planId, planMeals := range meals {
+		for i := range planMeals {
+			planMeals[i].Nutrients = nutrients[planMeals[i].Id]
+		}
+
+		plans[index[planId]].Days = models.MealPlanDays(planMeals)
+	}
+
+	for i := range plans {
+		if plans[i].Days == nil {
+			plans[i].Days = []models.MealPlanDay{}
+		}
+	}
+
+	return plans, nil
+}
+
+// SetPlannedMealRecord links a planned meal of a plan to the record
+// it was logged as, a meal is logged only once.
+func (s *Storage) SetPlannedMealRecord(
+	ctx context.Context,
+	planId int64,
+	mealId int64,
+	recordId int64,
+) error {
+	const op = "storage.sqlite.SetPlannedMealRecord"
+
+	res, err := s.db.ExecContext(
+		ctx,
+		"UPDATE planned_meals SET record_id = ? WHERE plan_id = ? AND id = ? AND record_id IS NULL",
+		recordId, planId, mealId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected > 0 {
+		return nil
+	}
+
+	var exists bool
+
+	err = s.db.QueryRowContext(
+		ctx,
+		"SELECT EXISTS(SELECT 1 FROM planned_meals WHERE plan_id = ? AND id = ?)",
+		planId, mealId,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storage.ErrPlannedMealNotFound)
+	}
+
+	return fmt.Errorf("%s: %w", op, storage.ErrPlannedMealLogged)
+}
+
+// CheckedFoodIds returns ids of foods checked off the shopping list.
+func (s *Storage) CheckedFoodIds(ctx context.Context, accountId int64) ([]int64, error) {
+	const op = "storage.sqlite.CheckedFoodIds"
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT food_id FROM shopping_checks WHERE account_id = ? ORDER BY food_id",
+		accountId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+func (s *Storage) SaveShoppingCheck(
+	ctx context.Context,
+	accountId int64,
+	check models.ShoppingCheck,
+) error {
+	const op = "storage.sqlite.SaveShoppingCheck"
+
+	query := "DELETE FROM shopping_checks WHERE account_id = ? AND food_id = ?"
+	if check.Checked {
+		query = "INSERT OR IGNORE INTO shopping_checks(account_id, food_id) VALUES (?, ?)"
+	}
+
+	if _, err := s.db.ExecContext(ctx, query, accountId, check.FoodId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveRecordPhoto(ctx context.Context, photo models.RecordPhoto) (int64, error) {
+	const op = "storage.sqlite.SaveRecordPhoto"
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO record_photos(
+			account_id, record_id, content_type, size, width, height,
+			blob_key, thumbnail_key, created_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		photo.AccountId,
+		photo.RecordId,
+		photo.ContentType,
+		photo.Size,
+		photo.Width,
+		photo.Height,
+		photo.BlobKey,
+		photo.ThumbnailKey,
+		photo.CreatedAt,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+const recordPhotoColumns = `id, account_id, record_id, content_type, size, width, height,
+	blob_key, thumbnail_key, created_at`
+
+func scanRecordPhoto(row scanner) (models.RecordPhoto, error) {
+	var photo models.RecordPhoto
+
+	err := row.Scan(
+		&photo.Id,
+		&photo.AccountId,
+		&photo.RecordId,
+		&photo.ContentType,
+		&photo.Size,
+		&photo.Width,
+		&photo.Height,
+		&photo.BlobKey,
+		&photo.ThumbnailKey,
+		&photo.CreatedAt,
+	)
+
+	return photo, err
+}
+
+func (s *Storage) RecordPhotoById(
+	ctx context.Context,
+	accountId int64,
+	photoId int64,
+) (models.RecordPhoto, error) {
+	const op = "storage.sqlite.RecordPhotoById"
+
+	photo, err := scanRecordPhoto(s.db.QueryRowContext(
+		ctx,
+		"SELECT "+recordPhotoColumns+" FROM record_photos WHERE account_id = ? AND id = ?",
+		accountId, photoId,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, storage.ErrPhotoNotFound)
+		}
+		return models.RecordPhoto{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return photo, nil
+}
+
+func (s *Storage) RecordPhotosByRecordId(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+) ([]models.RecordPhoto, error) {
+	const op = "storage.sqlite.RecordPhotosByRecordId"
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT "+recordPhotoColumns+` FROM record_photos
+		WHERE account_id = ? AND record_id = ? ORDER BY id`,
+		accountId, recordId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var photos []models.RecordPhoto
+
+	for rows.Next() {
+		photo, err := scanRecordPhoto(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		photos = append(photos, photo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return photos, nil
+}
+
+// saveRecordTags links the record to account tags by names,
+// every name must be an existing tag.
+func saveRecordTags(
+	ctx context.Context,
+	tx *sql.Tx,
+	accountId int64,
+	recordId int64,
+	names []string,
+) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		"SELECT id FROM tags WHERE account_id = ? AND name IN ("+placeholders(len(names))+")",
+		append([]any{accountId}, stringArgs(names)...)...,
+	)
+	if err != nil {
+		return err
+	}
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+
+		ids = append(ids, id)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	unique := make(map[string]bool, len(names))
+	for _, name := range names {
+		unique[name] = true
+	}
+
+	if len(ids) != len(unique) {
+		return storage.ErrTagNotFound
+	}
+
+	for _, id := range ids {
+		_, err := tx.ExecContext(
+			ctx,
+			"INSERT OR IGNORE INTO record_tags(record_id, tag_id) VALUES (?, ?)",
+			recordId, id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadRecordTags sets tag names of records, sorted by name.
+func (s *Storage) loadRecordTags(ctx context.Context, records []models.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.Id)
+	}
+
+	rows, err := s.db.QueryContext(
+		ctx,
+		`SELECT record_tags.record_id, tags.name
+		FROM record_tags
+		JOIN tags ON record_tags.tag_id = tags.id
+		WHERE record_tags.record_id IN (`+placeholders(len(ids))+`)
+		ORDER BY tags.name`,
+		int64Args(ids)...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	tags := make(map[int64][]string, len(ids))
+
+	for rows.Next() {
+		var (
+			recordId int64
+			name     string
+		)
+
+		if err := rows.Scan(&recordId, &name); err != nil {
+			return err
+		}
+
+		tags[recordId] = append(tags[recordId], name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range records {
+		records[i].Tags = tags[records[i].Id]
+	}
+
+	return nil
+}
+
+// SetRecordTags replaces tags of the record of the version, bumps it
+// and saves the revision.
+func (s *Storage) SetRecordTags(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+	version int64,
+	names []string,
+	revision models.RecordRevision,
+) error {
+	const op = "storage.sqlite.SetRecordTags"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, _, err := liveRecord(ctx, tx, accountId, recordId, version); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE records SET version = version + 1 WHERE id = ?", recordId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM record_tags WHERE record_id = ?", recordId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := saveRecordTags(ctx, tx, accountId, recordId, names); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := indexRecords(ctx, tx, []int64{recordId}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := touchRecords(ctx, tx, accountId, []int64{recordId}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertRevision(ctx, tx, revision); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveTag(ctx context.Context, tag models.Tag) (int64, error) {
+	const op = "storage.sqlite.SaveTag"
+
+	res, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO tags(account_id, name) VALUES (?, ?)",
+		tag.AccountId, tag.Name,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTagExists)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) TagsByAccountId(ctx context.Context, accountId int64) ([]models.Tag, error) {
+	const op = "storage.sqlite.TagsByAccountId"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tags.id, tags.account_id, tags.name, COUNT(records.id)
+		FROM tags
+		LEFT JOIN record_tags ON record_tags.tag_id = tags.id
+		LEFT JOIN records ON records.id = record_tags.record_id AND records.deleted_at IS NULL
+		WHERE tags.account_id = ?
+		GROUP BY tags.id
+		ORDER BY tags.name
+	`,
+		accountId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+
+	for rows.Next() {
+		var tag models.Tag
+
+		if err := rows.Scan(&tag.Id, &tag.AccountId, &tag.Name, &tag.RecordsCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// UpdateTag renames the tag, tagged records keep it.
+func (s *Storage) UpdateTag(ctx context.Context, tag models.Tag) error {
+	const op = "storage.sqlite.UpdateTag"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"UPDATE tags SET name = ? WHERE account_id = ? AND id = ?",
+		tag.Name, tag.AccountId, tag.Id,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return fmt.Errorf("%s: %w", op, storage.ErrTagExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTagNotFound)
+	}
+
+	recordIds, err := taggedRecordIds(ctx, tx, tag.Id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := indexRecords(ctx, tx, recordIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := touchRecords(ctx, tx, tag.AccountId, recordIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteTag removes the tag from all records.
+func (s *Storage) DeleteTag(ctx context.Context, accountId int64, tagId int64) error {
+	const op = "storage.sqlite.DeleteTag"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM tags WHERE account_id = ? AND id = ?",
+		accountId, tagId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTagNotFound)
+	}
+
+	recordIds, err := taggedRecordIds(ctx, tx, tagId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM record_tags WHERE tag_id = ?", tagId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := indexRecords(ctx, tx, recordIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := touchRecords(ctx, tx, accountId, recordIds); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func taggedRecordIds(ctx context.Context, tx *sql.Tx, tagId int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT record_id FROM record_tags WHERE tag_id = ?", tagId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// indexRecords rebuilds records_search rows of records, the index has
+// descriptions, tag names and linked food or recipe names.
+func indexRecords(ctx context.Context, tx *sql.Tx, recordIds []int64) error {
+	if len(recordIds) == 0 {
+		return nil
+	}
+
+	args := int64Args(recordIds)
+
+	_, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM records_search WHERE docid IN ("+placeholders(len(recordIds))+")",
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO records_search(docid, description, tags, food)
+		SELECT
+			records.id,
+			records.description,
+			COALESCE((
+				SELECT group_concat(tags.name, ' ')
+				FROM record_tags
+				JOIN tags ON record_tags.tag_id = tags.id
+				WHERE record_tags.record_id = records.id
+			), ''),
+			COALESCE(foods.name, recipes.name, '')
+		FROM records
+		LEFT JOIN foods ON records.food_id = foods.id
+		LEFT JOIN recipes ON records.recipe_id = recipes.id
+		WHERE records.id IN (`+placeholders(len(recordIds))+`) AND records.deleted_at IS NULL
+	`,
+		args...,
+	)
+
+	return err
+}
+
+// SearchRecords returns account records matching an FTS match expression,
+// newest first. Zero from or to leave the range open, bounds are
+// compared like RecordsByAccountIdInRange.
+func (s *Storage) SearchRecords(
+	ctx context.Context,
+	accountId int64,
+	match string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) ([]models.RecordSearchResult, error) {
+	const op = "storage.sqlite.SearchRecords"
+
+	query := `
+		SELECT ` + recordColumns + `,
+			snippet(records_search, char(2), char(3), '…', -1, 12)
+		FROM records_search
+		JOIN records ON records.id = records_search.docid
+		WHERE records_search MATCH ?
+			AND records.account_id = ?
+			AND records.deleted_at IS NULL
+	`
+	args := []any{match, accountId}
+
+	if !from.IsZero() {
+		query += "AND julianday(records.date_record) >= julianday(?)\n"
+		args = append(args, from)
+	}
+
+	if !to.IsZero() {
+		query += "AND julianday(records.date_record) < julianday(?)\n"
+		args = append(args, to)
+	}
+
+	query += "ORDER BY julianday(records.date_record) DESC, records.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		records  []models.Record
+		snippets []string
+	)
+
+	for rows.Next() {
+		var snippet string
+
+		record, err := scanRecord(scannerFunc(func(dest ...any) error {
+			return rows.Scan(append(dest, &snippet)...)
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		records = append(records, record)
+		snippets = append(snippets, snippet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordNutrients(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.loadRecordTags(ctx, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	results := make([]models.RecordSearchResult, 0, len(records))
+	for i, record := range records {
+		results = append(results, models.RecordSearchResult{Record: record, Snippet: highlight(snippets[i])})
+	}
+
+	return results, nil
+}
+
+// highlight escapes the snippet for HTML and turns matches delimited by
+// STX and ETX into <mark> tags, so user text never becomes markup.
+func highlight(snippet string) string {
+	return strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>").Replace(html.EscapeString(snippet))
+}
+
+// TagDailyTotalsByAccountIdInRange sums tagged records per tag and UTC day
+// for days from..to inclusive, like daily_totals.
+func (s *Storage) TagDailyTotalsByAccountIdInRange(
+	ctx context.Context,
+	accountId int64,
+	from time.Time,
+	to time.Time,
+) ([]models.TagDailyTotal, error) {
+	const op = "storage.sqlite.TagDailyTotalsByAccountIdInRange"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tags.name, date(records.date_record) AS day, SUM(records.value), COUNT(records.id)
+		FROM record_tags
+		JOIN tags ON record_tags.tag_id = tags.id
+		JOIN records ON record_tags.record_id = records.id
+		WHERE tags.account_id = ? AND records.deleted_at IS NULL AND day >= ? AND day <= ?
+		GROUP BY tags.id, day
+		ORDER BY tags.name, day
+	`,
+		accountId,
+		from.Format(time.DateOnly),
+		to.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var totals []models.TagDailyTotal
+
+	for rows.Next() {
+		var (
+			total models.TagDailyTotal
+			day   string
+		)
+
+		if err := rows.Scan(&total.Tag, &day, &total.Value, &total.RecordsCount); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		total.Day, err = time.Parse(time.DateOnly, day)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return totals, nil
+}
+
+func (s *Storage) SaveMeasurement(ctx context.Context, measurement models.Measurement) (int64, error) {
+	const op = "storage.sqlite.SaveMeasurement"
+
+	res, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO measurements(account_id, type, value, unit, date_record, date_created)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		measurement.AccountId,
+		measurement.Type,
+		measurement.Value,
+		measurement.Unit,
+		measurement.DateRecord,
+		measurement.DateCreated,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// MeasurementsByAccountId returns measurements of the type oldest first,
+// measurements of all types for an empty type.
+func (s *Storage) MeasurementsByAccountId(
+	ctx context.Context,
+	accountId int64,
+	measurementType models.MeasurementType,
+) ([]models.Measurement, error) {
+	const op = "storage.sqlite.MeasurementsByAccountId"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, account_id, type, value, unit, date_record, date_created
+		FROM measurements
+		WHERE account_id = ? AND (? = '' OR type = ?)
+		ORDER BY julianday(date_record) ASC, id ASC
+	`,
+		accountId, measurementType, measurementType,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var measurements []models.Measurement
+
+	for rows.Next() {
+		var measurement models.Measurement
+
+		err := rows.Scan(
+			&measurement.Id,
+			&measurement.AccountId,
+			&measurement.Type,
+			&measurement.Value,
+			&measurement.Unit,
+			&measurement.DateRecord,
+			&measurement.DateCreated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		measurements = append(measurements, measurement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return measurements, nil
+}
+
+func (s *Storage) DeleteMeasurement(ctx context.Context, accountId int64, measurementId int64) error {
+	const op = "storage.sqlite.DeleteMeasurement"
+
+	res, err := s.db.ExecContext(
+		ctx,
+		"DELETE FROM measurements WHERE account_id = ? AND id = ?",
+		accountId, measurementId,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrMeasurementNotFound)
+	}
+
+	return nil
+}
+
+// insertRevision appends the revision in the transaction of the record
+// change, so the history never misses a change.
+func insertRevision(ctx context.Context, tx *sql.Tx, revision models.RecordRevision) error {
+	oldValues, err := nullableJSON(revision.Old)
+	if err != nil {
+		return err
+	}
+
+	newValues, err := nullableJSON(revision.New)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO record_revisions(
+			record_id, account_id, actor_id, action, old_values, new_values, date_created
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		revision.RecordId,
+		revision.AccountId,
+		revision.ActorId,
+		revision.Action,
+		oldValues,
+		newValues,
+		time.Now(),
+	)
+
+	return err
+}
+
+// RecordRevisionsByRecordId returns the record history, oldest first.
+func (s *Storage) RecordRevisionsByRecordId(
+	ctx context.Context,
+	accountId int64,
+	recordId int64,
+) ([]models.RecordRevision, error) {
+	const op = "storage.sqlite.RecordRevisionsByRecordId"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, record_id, account_id, actor_id, action, old_values, new_values, date_created
+		FROM record_revisions
+		WHERE account_id = ? AND record_id = ?
+		ORDER BY id ASC
+	`,
+		accountId, recordId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var revisions []models.RecordRevision
+
+	for rows.Next() {
+		var (
+			revision  models.RecordRevision
+			oldValues sql.NullString
+			newValues sql.NullString
+		)
+
+		err := rows.Scan(
+			&revision.Id,
+			&revision.RecordId,
+			&revision.AccountId,
+			&revision.ActorId,
+			&revision.Action,
+			&oldValues,
+			&newValues,
+			&revision.DateCreated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if revision.Old, err = recordValues(oldValues); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if revision.New, err = recordValues(newValues); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+// nullableJSON encodes values as JSON, nil values are stored as NULL.
+func nullableJSON(values *models.RecordValues) (any, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func recordValues(data sql.NullString) (*models.RecordValues, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+
+	var values models.RecordValues
+
+	if err := json.Unmarshal([]byte(data.String), &values); err != nil {
+		return nil, err
+	}
+
+	return &values, nil
+}
+
+// SaveIdempotencyKey reserves the key for a request in progress.
+func (s *Storage) SaveIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	const op = "storage.sqlite.SaveIdempotencyKey"
+
+	_, err := s.db.ExecContext(
+		ctx,
+		`INSERT INTO idempotency_keys(account_id, key, fingerprint, date_created)
+		VALUES (?, ?, ?, ?)`,
+		key.AccountId, key.Key, key.Fingerprint, key.DateCreated,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+			return fmt.Errorf("%s: %w", op, storage.ErrIdempotencyKeyExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// CompleteIdempotencyKey stores the response of the request the key was
+// reserved for.
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	const op = "storage.sqlite.CompleteIdempotencyKey"
+
+	header, err := json.Marshal(key.Header)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := s.db.ExecContext(
+		ctx,
+		`UPDATE idempotency_keys SET status = ?, header = ?, body = ?
+		WHERE account_id = ? AND key = ?`,
+		key.Status, string(header), key.Body, key.AccountId, key.Key,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrIdempotencyKeyNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) IdempotencyKey(
+	ctx context.Context,
+	accountId int64,
+	key string,
+) (models.IdempotencyKey, error) {
+	const op = "storage.sqlite.IdempotencyKey"
+
+	var (
+		idempotencyKey models.IdempotencyKey
+		header         string
+	)
+
+	err := s.db.QueryRowContext(
+		ctx,
+		`SELECT account_id, key, fingerprint, status, header, body, date_created
+		FROM idempotency_keys WHERE account_id = ? AND key = ?`,
+		accountId, key,
+	).Scan(
+		&idempotencyKey.AccountId,
+		&idempotencyKey.Key,
+		&idempotencyKey.Fingerprint,
+		&idempotencyKey.Status,
+		&header,
+		&idempotencyKey.Body,
+		&idempotencyKey.DateCreated,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, storage.ErrIdempotencyKeyNotFound)
+		}
+		return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := json.Unmarshal([]byte(header), &idempotencyKey.Header); err != nil {
+		return models.IdempotencyKey{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return idempotencyKey, nil
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, accountId int64, key string) error {
+	const op = "storage.sqlite.DeleteIdempotencyKey"
+
+	_, err := s.db.ExecContext(
+		ctx,
+		"DELETE FROM idempotency_keys WHERE account_id = ? AND key = ?",
+		accountId, key,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeIdempotencyKeys deletes keys created before the time and returns
+// the number of deleted keys.
+func (s *Storage) PurgeIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.sqlite.PurgeIdempotencyKeys"
+
+	res, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE date_created < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
 }
@@ -0,0 +1,379 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/karmaplush/simple-diet-tracker/internal/domain/models"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage"
+	"github.com/karmaplush/simple-diet-tracker/internal/storage/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+// newStorage returns a storage on a migrated database in a temp dir.
+func newStorage(t *testing.T) *sqlite.Storage {
+	t.Helper()
+
+	storagePath := filepath.Join(t.TempDir(), "storage.db")
+
+	m, err := migrate.New("file://../../../migrations", "sqlite3://"+storagePath)
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+
+	srcErr, dbErr := m.Close()
+	require.NoError(t, srcErr)
+	require.NoError(t, dbErr)
+
+	s, err := sqlite.New(storagePath)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestRecordsByAccountIdInRangeOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	dates, expected := mixedOffsets()
+
+	for _, date := range dates {
+		_, err := s.SaveRecord(ctx, models.Record{
+			AccountId:  accountId,
+			Value:      100,
+			Meal:       models.MealBreakfast,
+			DateRecord: date,
+		}, created(accountId))
+		require.NoError(t, err)
+	}
+
+	records, err := s.RecordsByAccountIdInRange(
+		ctx,
+		accountId,
+		time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+
+	var actual []time.Time
+	for _, record := range records {
+		actual = append(actual, record.DateRecord)
+	}
+	requireOrder(t, expected, actual)
+}
+
+// created is the revision of a record created by the account.
+func created(accountId int64) models.RecordRevision {
+	return models.RecordRevision{
+		AccountId: accountId,
+		ActorId:   accountId,
+		Action:    models.RecordCreated,
+	}
+}
+
+// mixedOffsets returns dates stored as text with their offsets, which sorts
+// them by wall time, and the same dates by instant: 07:00 UTC twice in
+// insertion order, then 08:00 and 09:30 UTC.
+func mixedOffsets() ([]time.Time, []time.Time) {
+	moscow := time.FixedZone("UTC+3", 3*60*60)
+	newYork := time.FixedZone("UTC-4", -4*60*60)
+
+	dates := []time.Time{
+		time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 19, 10, 0, 0, 0, moscow),
+		time.Date(2024, 4, 19, 5, 30, 0, 0, newYork),
+		time.Date(2024, 4, 19, 7, 0, 0, 0, time.UTC),
+	}
+
+	return dates, []time.Time{dates[1], dates[3], dates[0], dates[2]}
+}
+
+func requireOrder(t *testing.T, expected []time.Time, actual []time.Time) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+	for i := range actual {
+		require.True(t, expected[i].Equal(actual[i]), "item %d: %s", i, actual[i])
+	}
+}
+
+func TestWeightsOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	dates, expected := mixedOffsets()
+	for _, date := range dates {
+		_, err := s.SaveWeight(ctx, accountId, 80, date)
+		require.NoError(t, err)
+	}
+
+	weights, err := s.WeightsByAccountId(ctx, accountId)
+	require.NoError(t, err)
+
+	var actual []time.Time
+	for _, weight := range weights {
+		actual = append(actual, weight.DateRecord)
+	}
+	requireOrder(t, expected, actual)
+
+	weights, err = s.WeightsByAccountIdInRange(
+		ctx,
+		accountId,
+		time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+
+	actual = nil
+	for _, weight := range weights {
+		actual = append(actual, weight.DateRecord)
+	}
+	requireOrder(t, expected, actual)
+}
+
+func TestMeasurementsOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	dates, expected := mixedOffsets()
+	for _, date := range dates {
+		_, err := s.SaveMeasurement(ctx, models.Measurement{
+			AccountId:   accountId,
+			Type:        models.MeasurementWaist,
+			Value:       80,
+			Unit:        models.MeasurementUnitCm,
+			DateRecord:  date,
+			DateCreated: date,
+		})
+		require.NoError(t, err)
+	}
+
+	measurements, err := s.MeasurementsByAccountId(ctx, accountId, models.MeasurementWaist)
+	require.NoError(t, err)
+
+	var actual []time.Time
+	for _, measurement := range measurements {
+		actual = append(actual, measurement.DateRecord)
+	}
+	requireOrder(t, expected, actual)
+}
+
+func TestRecordsOrder(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	dates, expected := mixedOffsets()
+	for _, date := range dates {
+		_, err := s.SaveRecord(ctx, models.Record{
+			AccountId:   accountId,
+			Value:       100,
+			Meal:        models.MealBreakfast,
+			Description: "oatmeal",
+			Nutrients:   models.Nutrients{models.NutrientFiber: 5},
+			DateRecord:  date,
+		}, created(accountId))
+		require.NoError(t, err)
+	}
+
+	from := time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	amounts, err := s.NutrientAmountsByAccountIdInRange(ctx, accountId, from, to)
+	require.NoError(t, err)
+
+	var actual []time.Time
+	for _, amount := range amounts {
+		actual = append(actual, amount.DateRecord)
+	}
+	requireOrder(t, expected, actual)
+
+	// Search lists the latest records first
+	results, err := s.SearchRecords(ctx, accountId, `"oatmeal*"`, from, to, 10)
+	require.NoError(t, err)
+
+	actual = nil
+	for _, result := range results {
+		actual = append(actual, result.DateRecord)
+	}
+	requireOrder(t, []time.Time{expected[3], expected[2], expected[1], expected[0]}, actual)
+}
+
+func TestPurgeDeletedRecords(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	day := time.Date(2024, 4, 19, 0, 0, 0, 0, time.UTC)
+
+	record := models.Record{
+		AccountId:  accountId,
+		Value:      300,
+		Meal:       models.MealLunch,
+		DateRecord: day.Add(13 * time.Hour),
+	}
+	values := record.Values()
+
+	recordId, err := s.SaveRecord(ctx, record, models.RecordRevision{
+		AccountId: accountId,
+		ActorId:   accountId,
+		Action:    models.RecordCreated,
+		New:       &values,
+	})
+	require.NoError(t, err)
+
+	_, err = s.SaveRecordPhoto(ctx, models.RecordPhoto{
+		AccountId:    accountId,
+		RecordId:     recordId,
+		ContentType:  "image/png",
+		Size:         100,
+		Width:        10,
+		Height:       10,
+		BlobKey:      "photos/1/original",
+		ThumbnailKey: "photos/1/thumbnail",
+		CreatedAt:    day,
+	})
+	require.NoError(t, err)
+
+	planId, err := s.SaveMealPlan(ctx, models.MealPlan{
+		AccountId:  accountId,
+		CreatedAt:  day,
+		StartDay:   day,
+		DailyLimit: 2000,
+		Days: []models.MealPlanDay{{
+			Day:   day,
+			Meals: []models.PlannedMeal{{Day: day, Meal: models.MealLunch, Name: "Soup", Value: 300}},
+		}},
+	})
+	require.NoError(t, err)
+
+	plan, err := s.MealPlanById(ctx, accountId, planId)
+	require.NoError(t, err)
+
+	mealId := plan.Meals()[0].Id
+	require.NoError(t, s.SetPlannedMealRecord(ctx, planId, mealId, recordId))
+
+	require.NoError(t, s.DeleteRecord(ctx, accountId, recordId, 1, models.RecordRevision{
+		RecordId:  recordId,
+		AccountId: accountId,
+		ActorId:   accountId,
+		Action:    models.RecordDeleted,
+		Old:       &values,
+	}))
+
+	records, photos, err := s.PurgeDeletedRecords(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Len(t, photos, 1)
+	require.Equal(t, "photos/1/original", photos[0].BlobKey)
+	require.Equal(t, "photos/1/thumbnail", photos[0].ThumbnailKey)
+
+	remaining, err := s.RecordPhotosByRecordId(ctx, accountId, recordId)
+	require.NoError(t, err)
+	require.Empty(t, remaining)
+
+	// The history is kept after the record is gone
+	revisions, err := s.RecordRevisionsByRecordId(ctx, accountId, recordId)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	plan, err = s.MealPlanById(ctx, accountId, planId)
+	require.NoError(t, err)
+	require.Nil(t, plan.Meals()[0].RecordId)
+}
+
+func TestRevisionsSavedWithChanges(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	record := models.Record{
+		AccountId:  accountId,
+		Value:      300,
+		Meal:       models.MealLunch,
+		DateRecord: time.Date(2024, 4, 19, 13, 0, 0, 0, time.UTC),
+	}
+
+	recordId, err := s.SaveRecord(ctx, record, created(accountId))
+	require.NoError(t, err)
+
+	record.Id = recordId
+	record.Version = 2
+	record.Value = 400
+
+	updated := models.RecordRevision{
+		RecordId:  recordId,
+		AccountId: accountId,
+		ActorId:   accountId,
+		Action:    models.RecordUpdated,
+	}
+
+	// A failed change saves no revision
+	require.ErrorIs(t, s.UpdateRecord(ctx, record, updated), storage.ErrVersionMismatch)
+
+	_, err = s.ApplyRecordBatch(ctx, accountId, []models.RecordOperation{
+		{Type: models.RecordOperationCreate, Record: record, Revision: created(accountId)},
+		{Type: models.RecordOperationUpdate, RecordId: recordId, Version: 2, Record: record, Revision: updated},
+	})
+	require.ErrorIs(t, err, storage.ErrVersionMismatch)
+
+	revisions, err := s.RecordRevisionsByRecordId(ctx, accountId, recordId)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, models.RecordCreated, revisions[0].Action)
+
+	record.Version = 1
+	require.NoError(t, s.UpdateRecord(ctx, record, updated))
+
+	revisions, err = s.RecordRevisionsByRecordId(ctx, accountId, recordId)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, models.RecordUpdated, revisions[1].Action)
+}
+
+func TestSearchRecordsSnippet(t *testing.T) {
+	ctx := context.Background()
+	s := newStorage(t)
+
+	accountId, err := s.SaveAccount(ctx, 7)
+	require.NoError(t, err)
+
+	_, err = s.SaveRecord(ctx, models.Record{
+		AccountId:   accountId,
+		Value:       800,
+		Meal:        models.MealDinner,
+		Description: `<img src=x onerror="alert(1)"> pizza & beer`,
+		DateRecord:  time.Date(2024, 4, 19, 19, 0, 0, 0, time.UTC),
+	}, created(accountId))
+	require.NoError(t, err)
+
+	results, err := s.SearchRecords(ctx, accountId, `"pizza*"`, time.Time{}, time.Time{}, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	// User text is escaped, only matches become markup
+	require.Equal(
+		t,
+		`&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>pizza</mark> &amp; beer`,
+		results[0].Snippet,
+	)
+}
@@ -1,9 +1,57 @@
 package storage
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrAccountNotFound = errors.New("account not found")
 	ErrAccountExists   = errors.New("account exists")
 	ErrRecordNotFound  = errors.New("record not found")
+	ErrRecordExists    = errors.New("record exists")
+	ErrStreaksNotFound = errors.New("streaks not found")
+	ErrVersionMismatch = errors.New("version mismatch")
+
+	ErrFastingSessionNotFound = errors.New("fasting session not found")
+	ErrFastingSessionExists   = errors.New("fasting session exists")
+
+	ErrFoodNotFound = errors.New("food not found")
+	ErrFoodExists   = errors.New("food exists")
+
+	ErrRecipeNotFound = errors.New("recipe not found")
+	ErrRecipeExists   = errors.New("recipe exists")
+
+	ErrInsulinSettingsNotFound = errors.New("insulin settings not found")
+
+	ErrMealPlanNotFound    = errors.New("meal plan not found")
+	ErrPlannedMealNotFound = errors.New("planned meal not found")
+	ErrPlannedMealLogged   = errors.New("planned meal logged")
+
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag exists")
+
+	ErrMeasurementNotFound = errors.New("measurement not found")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists   = errors.New("idempotency key exists")
+
+	ErrPhotoNotFound  = errors.New("photo not found")
+	ErrBlobNotFound   = errors.New("blob not found")
+	ErrInvalidBlobKey = errors.New("invalid blob key")
 )
+
+// BatchError reports the operation of a batch that failed, no operation
+// of the batch is applied.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d: %s", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,2 @@
+-- Preferred unit of energy values in the API, storage stays in kcal
+ALTER TABLE accounts ADD COLUMN energy_unit TEXT NOT NULL DEFAULT 'kcal';
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS planned_meal_nutrients;
+DROP TABLE IF EXISTS planned_meals;
+DROP TABLE IF EXISTS meal_plans;
+
+ALTER TABLE recipes DROP COLUMN favorite;
+ALTER TABLE foods DROP COLUMN favorite;
+
+ALTER TABLE accounts DROP COLUMN fat_target;
+ALTER TABLE accounts DROP COLUMN protein_target;
+ALTER TABLE accounts DROP COLUMN carbs_target;
@@ -0,0 +1,49 @@
+ALTER TABLE accounts ADD COLUMN carbs_target REAL NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN protein_target REAL NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN fat_target REAL NOT NULL DEFAULT 0;
+
+ALTER TABLE foods ADD COLUMN favorite INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE recipes ADD COLUMN favorite INTEGER NOT NULL DEFAULT 0;
+
+-- Daily limit and macro targets are a snapshot of the account on generation
+CREATE TABLE IF NOT EXISTS meal_plans (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    start_day DATE NOT NULL,
+    daily_limit INTEGER NOT NULL,
+    carbs_target REAL NOT NULL,
+    protein_target REAL NOT NULL,
+    fat_target REAL NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_meal_plans_account_created
+ON meal_plans (account_id, created_at);
+
+-- Name, value and nutrients are copied from the catalog on generation
+CREATE TABLE IF NOT EXISTS planned_meals (
+    id INTEGER PRIMARY KEY,
+    plan_id INTEGER NOT NULL,
+    day DATE NOT NULL,
+    position INTEGER NOT NULL,
+    meal TEXT NOT NULL,
+    food_id INTEGER REFERENCES foods (id) ON DELETE SET NULL,
+    recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    grams REAL,
+    servings REAL,
+    value INTEGER NOT NULL,
+    record_id INTEGER REFERENCES records (id) ON DELETE SET NULL,
+    FOREIGN KEY (plan_id) REFERENCES meal_plans (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_planned_meals_plan ON planned_meals (plan_id);
+
+CREATE TABLE IF NOT EXISTS planned_meal_nutrients (
+    planned_meal_id INTEGER NOT NULL,
+    nutrient TEXT NOT NULL,
+    amount REAL NOT NULL,
+    PRIMARY KEY (planned_meal_id, nutrient),
+    FOREIGN KEY (planned_meal_id) REFERENCES planned_meals (id) ON DELETE CASCADE
+);
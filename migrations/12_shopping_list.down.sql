@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS shopping_checks;
+
+ALTER TABLE foods DROP COLUMN category;
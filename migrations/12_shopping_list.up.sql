@@ -0,0 +1,10 @@
+ALTER TABLE foods ADD COLUMN category TEXT NOT NULL DEFAULT '';
+
+-- Foods checked off the shopping list, unchecking deletes the row
+CREATE TABLE IF NOT EXISTS shopping_checks (
+    account_id INTEGER NOT NULL,
+    food_id INTEGER NOT NULL,
+    PRIMARY KEY (account_id, food_id),
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+    FOREIGN KEY (food_id) REFERENCES foods (id) ON DELETE CASCADE
+);
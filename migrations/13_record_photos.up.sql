@@ -0,0 +1,17 @@
+-- Photo files live in the blob store, rows keep their keys
+CREATE TABLE IF NOT EXISTS record_photos (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    record_id INTEGER NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    blob_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+    FOREIGN KEY (record_id) REFERENCES records (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_record_photos_record ON record_photos (record_id);
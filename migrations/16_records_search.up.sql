@@ -0,0 +1,21 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS records_search USING fts4 (
+    description,
+    tags,
+    food,
+    tokenize=unicode61
+);
+
+INSERT INTO records_search(docid, description, tags, food)
+SELECT
+    records.id,
+    records.description,
+    COALESCE((
+        SELECT group_concat(tags.name, ' ')
+        FROM record_tags
+        JOIN tags ON record_tags.tag_id = tags.id
+        WHERE record_tags.record_id = records.id
+    ), ''),
+    COALESCE(foods.name, recipes.name, '')
+FROM records
+LEFT JOIN foods ON records.food_id = foods.id
+LEFT JOIN recipes ON records.recipe_id = recipes.id;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_records_deleted_at;
+
+DELETE FROM records WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE records DROP COLUMN deleted_at;
@@ -0,0 +1,3 @@
+ALTER TABLE records ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_records_deleted_at ON records (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS record_revisions (
+    id INTEGER PRIMARY KEY,
+    record_id INTEGER NOT NULL,
+    account_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    old_values TEXT,
+    new_values TEXT,
+    date_created DATETIME NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_record_revisions_record ON record_revisions (record_id);
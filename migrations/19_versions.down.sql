@@ -0,0 +1,2 @@
+ALTER TABLE accounts DROP COLUMN version;
+ALTER TABLE records DROP COLUMN version;
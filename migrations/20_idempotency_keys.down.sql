@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_idempotency_keys_date_created;
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_records_account_seq;
+DROP INDEX IF EXISTS idx_records_account_uuid;
+
+ALTER TABLE accounts DROP COLUMN sync_floor;
+ALTER TABLE accounts DROP COLUMN sync_seq;
+
+ALTER TABLE records DROP COLUMN field_times;
+ALTER TABLE records DROP COLUMN seq;
+ALTER TABLE records DROP COLUMN uuid;
@@ -0,0 +1,22 @@
+ALTER TABLE records ADD COLUMN uuid TEXT NOT NULL DEFAULT '';
+ALTER TABLE records ADD COLUMN seq INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE records ADD COLUMN field_times TEXT NOT NULL DEFAULT '{}';
+
+ALTER TABLE accounts ADD COLUMN sync_seq INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD COLUMN sync_floor INTEGER NOT NULL DEFAULT 0;
+
+UPDATE records SET
+    uuid = lower(
+        hex(randomblob(4)) || '-' ||
+        hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random() % 4), 1) ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        hex(randomblob(6))
+    ),
+    seq = id;
+
+UPDATE accounts SET sync_seq = COALESCE((SELECT MAX(seq) FROM records WHERE records.account_id = accounts.id), 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_records_account_uuid ON records (account_id, uuid);
+CREATE INDEX IF NOT EXISTS idx_records_account_seq ON records (account_id, seq);
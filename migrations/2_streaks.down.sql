@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS streaks;
+
+DROP TABLE IF EXISTS daily_totals;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS daily_totals (
+    account_id INTEGER NOT NULL,
+    day DATE NOT NULL,
+    value INTEGER NOT NULL,
+    records_count INTEGER NOT NULL,
+    PRIMARY KEY (account_id, day),
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+INSERT INTO daily_totals (account_id, day, value, records_count)
+SELECT account_id, date(date_record), SUM(value), COUNT(*)
+FROM records
+GROUP BY account_id, date(date_record);
+
+CREATE TABLE IF NOT EXISTS streaks (
+    account_id INTEGER PRIMARY KEY,
+    logging_current INTEGER NOT NULL,
+    logging_longest INTEGER NOT NULL,
+    logging_last_day DATE,
+    on_target_current INTEGER NOT NULL,
+    on_target_longest INTEGER NOT NULL,
+    on_target_last_day DATE,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
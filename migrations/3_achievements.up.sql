@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS weights (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    date_record DATETIME NOT NULL,
+    date_created DATETIME NOT NULL,
+    value REAL NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_weights_account_id_date_record ON weights (account_id, date_record);
+
+CREATE TABLE IF NOT EXISTS achievements (
+    account_id INTEGER NOT NULL,
+    code TEXT NOT NULL,
+    unlocked_at DATETIME NOT NULL,
+    PRIMARY KEY (account_id, code),
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
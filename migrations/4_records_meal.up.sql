@@ -0,0 +1,8 @@
+ALTER TABLE records ADD COLUMN meal TEXT NOT NULL DEFAULT '';
+
+UPDATE records SET meal = CASE
+    WHEN CAST(strftime('%H', date_record) AS INTEGER) BETWEEN 5 AND 10 THEN 'breakfast'
+    WHEN CAST(strftime('%H', date_record) AS INTEGER) BETWEEN 11 AND 15 THEN 'lunch'
+    WHEN CAST(strftime('%H', date_record) AS INTEGER) BETWEEN 16 AND 20 THEN 'dinner'
+    ELSE 'snack'
+END;
@@ -0,0 +1 @@
+ALTER TABLE records ADD COLUMN description TEXT NOT NULL DEFAULT '';
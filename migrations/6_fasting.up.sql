@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS fasting_sessions (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    protocol TEXT NOT NULL,
+    planned_minutes INTEGER NOT NULL,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+-- Only one fasting session per account may be in progress
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fasting_sessions_active
+ON fasting_sessions (account_id) WHERE ended_at IS NULL;
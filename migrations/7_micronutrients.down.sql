@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS record_nutrients;
+
+ALTER TABLE records DROP COLUMN grams;
+ALTER TABLE records DROP COLUMN food_id;
+
+DROP TABLE IF EXISTS food_nutrients;
+DROP TABLE IF EXISTS foods;
+
+ALTER TABLE accounts DROP COLUMN birth_date;
+ALTER TABLE accounts DROP COLUMN sex;
@@ -0,0 +1,33 @@
+ALTER TABLE accounts ADD COLUMN sex TEXT NOT NULL DEFAULT '';
+ALTER TABLE accounts ADD COLUMN birth_date DATE;
+
+CREATE TABLE IF NOT EXISTS foods (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    value INTEGER NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_foods_account_name
+ON foods (account_id, name COLLATE NOCASE);
+
+-- Amounts per 100 g of food
+CREATE TABLE IF NOT EXISTS food_nutrients (
+    food_id INTEGER NOT NULL,
+    nutrient TEXT NOT NULL,
+    amount REAL NOT NULL,
+    PRIMARY KEY (food_id, nutrient),
+    FOREIGN KEY (food_id) REFERENCES foods (id) ON DELETE CASCADE
+);
+
+ALTER TABLE records ADD COLUMN food_id INTEGER REFERENCES foods (id) ON DELETE SET NULL;
+ALTER TABLE records ADD COLUMN grams REAL;
+
+CREATE TABLE IF NOT EXISTS record_nutrients (
+    record_id INTEGER NOT NULL,
+    nutrient TEXT NOT NULL,
+    amount REAL NOT NULL,
+    PRIMARY KEY (record_id, nutrient),
+    FOREIGN KEY (record_id) REFERENCES records (id) ON DELETE CASCADE
+);
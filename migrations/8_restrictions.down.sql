@@ -0,0 +1,13 @@
+ALTER TABLE records DROP COLUMN servings;
+ALTER TABLE records DROP COLUMN recipe_id;
+
+DROP TABLE IF EXISTS recipe_allergens;
+DROP TABLE IF EXISTS recipe_nutrients;
+DROP TABLE IF EXISTS recipe_ingredients;
+DROP TABLE IF EXISTS recipes;
+
+DROP TABLE IF EXISTS food_allergens;
+DROP TABLE IF EXISTS account_diets;
+DROP TABLE IF EXISTS account_allergens;
+
+ALTER TABLE accounts DROP COLUMN restriction_mode;
@@ -0,0 +1,64 @@
+ALTER TABLE accounts ADD COLUMN restriction_mode TEXT NOT NULL DEFAULT 'warn';
+
+CREATE TABLE IF NOT EXISTS account_allergens (
+    account_id INTEGER NOT NULL,
+    allergen TEXT NOT NULL,
+    PRIMARY KEY (account_id, allergen),
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS account_diets (
+    account_id INTEGER NOT NULL,
+    diet TEXT NOT NULL,
+    PRIMARY KEY (account_id, diet),
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS food_allergens (
+    food_id INTEGER NOT NULL,
+    allergen TEXT NOT NULL,
+    PRIMARY KEY (food_id, allergen),
+    FOREIGN KEY (food_id) REFERENCES foods (id) ON DELETE CASCADE
+);
+
+-- Value, nutrients and allergens are computed from ingredients on create,
+-- value and nutrients are per serving
+CREATE TABLE IF NOT EXISTS recipes (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    servings INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recipes_account_name
+ON recipes (account_id, name COLLATE NOCASE);
+
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+    recipe_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    food_id INTEGER NOT NULL,
+    grams REAL NOT NULL,
+    PRIMARY KEY (recipe_id, position),
+    FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE,
+    FOREIGN KEY (food_id) REFERENCES foods (id)
+);
+
+CREATE TABLE IF NOT EXISTS recipe_nutrients (
+    recipe_id INTEGER NOT NULL,
+    nutrient TEXT NOT NULL,
+    amount REAL NOT NULL,
+    PRIMARY KEY (recipe_id, nutrient),
+    FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS recipe_allergens (
+    recipe_id INTEGER NOT NULL,
+    allergen TEXT NOT NULL,
+    PRIMARY KEY (recipe_id, allergen),
+    FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE
+);
+
+ALTER TABLE records ADD COLUMN recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL;
+ALTER TABLE records ADD COLUMN servings REAL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bolus_calculation_records;
+DROP INDEX IF EXISTS idx_bolus_calculations_account;
+DROP TABLE IF EXISTS bolus_calculations;
+DROP TABLE IF EXISTS insulin_settings;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS insulin_settings (
+    account_id INTEGER PRIMARY KEY,
+    carb_ratio REAL NOT NULL,
+    correction_factor REAL NOT NULL,
+    target_glucose REAL NOT NULL,
+    glucose_unit TEXT NOT NULL,
+    dose_increment REAL NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+-- Audit trail of suggested doses, settings are copied as they were at the time
+CREATE TABLE IF NOT EXISTS bolus_calculations (
+    id INTEGER PRIMARY KEY,
+    account_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL,
+    carbs REAL NOT NULL,
+    carbs_source TEXT NOT NULL,
+    meal TEXT NOT NULL DEFAULT '',
+    day DATE,
+    uncounted_records INTEGER NOT NULL DEFAULT 0,
+    glucose REAL,
+    insulin_on_board REAL NOT NULL,
+    carb_ratio REAL NOT NULL,
+    correction_factor REAL NOT NULL,
+    target_glucose REAL NOT NULL,
+    glucose_unit TEXT NOT NULL,
+    dose_increment REAL NOT NULL,
+    carb_dose REAL NOT NULL,
+    correction_dose REAL NOT NULL,
+    suggested_dose REAL NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bolus_calculations_account
+ON bolus_calculations (account_id, created_at);
+
+-- No foreign key to records, the audit trail outlives deleted records
+CREATE TABLE IF NOT EXISTS bolus_calculation_records (
+    calculation_id INTEGER NOT NULL,
+    record_id INTEGER NOT NULL,
+    PRIMARY KEY (calculation_id, record_id),
+    FOREIGN KEY (calculation_id) REFERENCES bolus_calculations (id) ON DELETE CASCADE
+);